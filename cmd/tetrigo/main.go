@@ -11,6 +11,10 @@ type CLI struct {
 	Menu        MenuCmd        `cmd:"" help:"Start in the menu" default:"1"`
 	Play        PlayCmd        `cmd:"" help:"Play a specific game mode"`
 	Leaderboard LeaderboardCmd `cmd:"" help:"Start on the leaderboard"`
+	Tune        TuneCmd        `cmd:"" help:"Evolve the solver's evaluator weights by playing headless games"`
+	Bot         BotCmd         `cmd:"" help:"Run the solver as a Tetris Bot Protocol bot over stdin and stdout"`
+	Bench       BenchCmd       `cmd:"" help:"Play headless games with a Tetris Bot Protocol bot"`
+	Tournament  TournamentCmd  `cmd:"" help:"Play a headless round robin of versus matches between solvers"`
 }
 
 type GlobalVars struct {
@@ -1,7 +1,17 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -9,6 +19,12 @@ import (
 	"github.com/Broderick-Westrope/tetrigo/internal/data"
 	"github.com/Broderick-Westrope/tetrigo/internal/tui"
 	"github.com/Broderick-Westrope/tetrigo/internal/tui/starter"
+	"github.com/Broderick-Westrope/tetrigo/internal/tune"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tbp"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/versus"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
 )
 
 type MenuCmd struct{}
@@ -18,9 +34,13 @@ func (c *MenuCmd) Run(globals *GlobalVars) error {
 }
 
 type PlayCmd struct {
-	GameMode string `arg:"" help:"Game mode to play" default:"marathon"`
-	Level    int    `help:"Level to start at" short:"l" default:"1"`
-	Name     string `help:"Name of the player" short:"n" default:"Anonymous"`
+	GameMode  string `arg:"" help:"Game mode to play, or the name of a custom mode in config" default:"marathon"`
+	Level     int    `help:"Level to start at" short:"l" default:"1"`
+	Name      string `help:"Name of the player" short:"n" default:"Anonymous"`
+	Seed      uint64 `help:"Seed for the Tetriminos and garbage (0 = random)" short:"s" default:"0"`
+	Pack      string `help:"Path of the puzzle pack to play, for the puzzle game mode" short:"p" type:"path"`
+	Invisible bool   `help:"Hide locked minos after the delay in config, revealing the stack at game over"`
+	Big       bool   `help:"Play every tetrimino at 2x scale on a matrix of half the width and height"`
 }
 
 func (c *PlayCmd) Run(globals *GlobalVars) error {
@@ -28,24 +48,325 @@ func (c *PlayCmd) Run(globals *GlobalVars) error {
 		"marathon": tui.ModeMarathon,
 		"sprint":   tui.ModeSprint,
 		"ultra":    tui.ModeUltra,
+		"master":   tui.ModeMaster,
+		"dig":      tui.ModeDig,
+		"survival": tui.ModeSurvival,
+		"combo":    tui.ModeCombo,
+		"daily":    tui.ModeDaily,
+		"puzzle":   tui.ModePuzzle,
+		"finesse":  tui.ModeFinesse,
+		"pc":       tui.ModePerfectClear,
+		"versus":   tui.ModeVersus,
 	}
 
+	opts := []func(*tui.SingleInput){
+		tui.WithSeed(c.Seed),
+		tui.WithPuzzlePack(c.Pack),
+		tui.WithModifiers(c.Invisible, c.Big),
+	}
 	mode, ok := singlePlayerModes[c.GameMode]
 	if !ok {
-		return fmt.Errorf("invalid game mode: %s", c.GameMode)
+		// Custom modes are validated against the config when the game is created.
+		mode = tui.ModeCustom
+		opts = append(opts, tui.WithCustomMode(c.GameMode))
 	}
 
-	return launchStarter(globals, mode, tui.NewSingleInput(tui.ModeMarathon, c.Level, c.Name))
+	return launchStarter(globals, mode, tui.NewSingleInput(mode, c.Level, c.Name, opts...))
 }
 
 type LeaderboardCmd struct {
-	GameMode string `arg:"" help:"Game mode to display" default:"marathon"`
+	GameMode string `arg:"" help:"Game mode to display, or the name of a custom mode in config" default:"marathon"`
 }
 
 func (c *LeaderboardCmd) Run(globals *GlobalVars) error {
 	return launchStarter(globals, tui.ModeLeaderboard, tui.NewLeaderboardInput(c.GameMode))
 }
 
+type TuneCmd struct {
+	Population   int    `help:"Number of weight vectors in each generation" short:"p" default:"50"`
+	Generations  int    `help:"Number of generations to evolve" short:"g" default:"30"`
+	GamesPerEval int    `help:"Number of games each weight vector plays per generation" short:"n" default:"5"`
+	MaxPieces    int    `help:"Most tetriminos placed per game" default:"1000"`
+	Algorithm    string `help:"Optimisation algorithm" enum:"ga,cmaes" default:"ga"`
+	Seed         uint64 `help:"Seed for the games and the algorithm" short:"s" default:"1"`
+	Workers      int    `help:"Number of games played at once (0 = number of CPUs)" short:"w" default:"0"`
+	Checkpoint   string `help:"Path to save the state to after each generation" type:"path" default:"tune-checkpoint.json"`
+	Resume       bool   `help:"Continue from the checkpoint if it exists, which must have the same settings"`
+	Output       string `help:"Path the best weights are written to" short:"o" type:"path" default:"weights.json"`
+}
+
+func (c *TuneCmd) Run(_ *GlobalVars) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	result, err := tune.Run(ctx, &tune.Input{
+		Algorithm:    c.Algorithm,
+		Population:   c.Population,
+		Generations:  c.Generations,
+		GamesPerEval: c.GamesPerEval,
+		MaxPieces:    c.MaxPieces,
+		Seed:         c.Seed,
+		Workers:      c.Workers,
+		Checkpoint:   c.Checkpoint,
+		Resume:       c.Resume,
+	}, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("tuning weights: %w", err)
+	}
+
+	f, err := os.Create(c.Output)
+	if err != nil {
+		return fmt.Errorf("creating weights file: %w", err)
+	}
+	defer f.Close()
+	if err = result.Weights.Save(f); err != nil {
+		return fmt.Errorf("saving weights: %w", err)
+	}
+
+	fmt.Printf("Wrote the best weights (%.1f lines per game) to %s.\n", result.Fitness, c.Output)
+	return nil
+}
+
+type BotCmd struct {
+	SolverFlags `embed:""`
+
+	ThinkTime time.Duration `help:"Most time spent choosing each move (0 = no limit)" default:"0s"`
+}
+
+func (c *BotCmd) Run(_ *GlobalVars) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	s, err := c.newSolver()
+	if err != nil {
+		return err
+	}
+	bot := tbp.NewBot(s, tbp.WithThinkTime(c.ThinkTime))
+	if err = bot.Serve(ctx, os.Stdin, os.Stdout); err != nil {
+		return fmt.Errorf("serving bot: %w", err)
+	}
+	return nil
+}
+
+// SolverFlags configure the solver used by commands which play games.
+type SolverFlags struct {
+	Solver     string `help:"Search used to choose moves" enum:"beam,mcts,greedy" default:"beam"`
+	Weights    string `help:"Evaluator weights: dellacherie, el-tetris, or the path of a weights file" default:"el-tetris"`
+	Width      int    `help:"Most positions kept at each depth of the beam search" default:"16"`
+	Depth      int    `help:"Most moves the beam search looks ahead (0 = every known tetrimino)" default:"0"`
+	Iterations int    `help:"Most iterations of the Monte Carlo tree search for each move" default:"1000"`
+	Workers    int    `help:"Number of goroutines searching at once (0 = number of CPUs)" short:"w" default:"0"`
+	TableSize  int    `help:"Megabytes of the transposition table shared by searches (0 = none)" default:"64"`
+	Seed       uint64 `help:"Seed for the Monte Carlo tree search" default:"1"`
+}
+
+// newSolver returns the solver configured by the flags.
+func (f *SolverFlags) newSolver() (solver.Solver, error) {
+	weights, err := eval.GetWeights(f.Weights)
+	if err != nil {
+		return nil, fmt.Errorf("getting weights: %w", err)
+	}
+	workers := f.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	var table *solver.TranspositionTable
+	if f.TableSize > 0 {
+		table = solver.NewTranspositionTable(f.TableSize)
+	}
+
+	switch f.Solver {
+	case "mcts":
+		opts := []func(*solver.MCTS){
+			solver.WithMCTSSeed(f.Seed),
+			solver.WithMCTSWorkers(workers),
+			solver.WithIterations(f.Iterations),
+		}
+		if table != nil {
+			opts = append(opts, solver.WithMCTSTable(table))
+		}
+		return solver.NewMCTS(weights, opts...), nil
+	case "greedy":
+		return solver.NewGreedySearch(weights), nil
+	}
+	opts := []func(*solver.BeamSearch){
+		solver.WithBeamWidth(f.Width),
+		solver.WithBeamDepth(f.Depth),
+		solver.WithBeamWorkers(workers),
+	}
+	if table != nil {
+		opts = append(opts, solver.WithBeamTable(table))
+	}
+	return solver.NewBeamSearch(weights, opts...), nil
+}
+
+type BenchCmd struct {
+	Bot       []string `arg:"" help:"Command which runs the bot, and its arguments (after --)" passthrough:""`
+	Games     int      `help:"Number of games to play" short:"n" default:"10"`
+	MaxPieces int      `help:"Most tetriminos placed per game (0 = no limit)" default:"1000"`
+	Previews  int      `help:"Number of tetriminos of the next queue shown to the bot" default:"5"`
+	Seed      uint64   `help:"Seed for the first game, which is incremented for each game after" short:"s" default:"1"`
+}
+
+func (c *BenchCmd) Run(_ *GlobalVars) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	command := c.Bot
+	if command[0] == "--" {
+		command = command[1:]
+	}
+	if len(command) == 0 {
+		return errors.New("no bot command given")
+	}
+	client, err := tbp.Launch(ctx, command[0], command[1:]...)
+	if err != nil {
+		return fmt.Errorf("launching bot: %w", err)
+	}
+	defer client.Close()
+	if err = client.Rules(); err != nil {
+		return err
+	}
+	info := client.Info()
+	fmt.Printf("Playing %d games with %s %s by %s.\n", c.Games, info.Name, info.Version, info.Author)
+
+	lines, pieces := 0, 0
+	var elapsed time.Duration
+	for i := range uint64(max(c.Games, 0)) {
+		seed := c.Seed + i
+		game, err := single.NewGame(&single.Input{
+			Level: 1,
+			//nolint:gosec // This random source is not for any security-related tasks.
+			Rand: rand.New(rand.NewPCG(seed, seed)),
+		})
+		if err != nil {
+			return fmt.Errorf("creating game: %w", err)
+		}
+
+		start := time.Now()
+		if err = tbp.PlayGame(ctx, client, game, c.MaxPieces, c.Previews); err != nil {
+			return fmt.Errorf("playing game %d: %w", i+1, err)
+		}
+		elapsed += time.Since(start)
+		lines += game.GetLinesCleared()
+		pieces += game.GetPiecesPlaced()
+		fmt.Printf("Game %d: %d lines, %d tetriminos, %d points.\n",
+			i+1, game.GetLinesCleared(), game.GetPiecesPlaced(), game.GetTotalScore())
+	}
+
+	if c.Games > 0 && elapsed > 0 {
+		fmt.Printf("Mean of %.1f lines per game, at %.1f tetriminos per second.\n",
+			float64(lines)/float64(c.Games), float64(pieces)/elapsed.Seconds())
+	}
+	return nil
+}
+
+type TournamentCmd struct {
+	Players     []string      `arg:"" help:"Solvers and their settings, eg. beam,depth=2,pps=3"`
+	Games       int           `help:"Number of matches each pair of players plays" short:"n" default:"20"`
+	MaxDuration time.Duration `help:"Most simulated time a match lasts before it is drawn (0 = no limit)" default:"5m"`
+	Seed        uint64        `help:"Seed for the first two matches of each pair of players" short:"s" default:"1"`
+	Workers     int           `help:"Number of matches played at once (0 = number of CPUs)" short:"w" default:"0"`
+}
+
+func (c *TournamentCmd) Help() string {
+	return "Each player is a solver (beam, mcts or greedy) followed by comma separated settings: pps (pieces per " +
+		"second, default 2), depth, width, iterations, weights and name. For example:\n\n" +
+		"  tetrigo tournament greedy,pps=1 beam,depth=2,pps=1,name=deep"
+}
+
+func (c *TournamentCmd) Run(_ *GlobalVars) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	players := make([]*versus.Player, 0, len(c.Players))
+	for _, spec := range c.Players {
+		p, err := parsePlayer(spec)
+		if err != nil {
+			return fmt.Errorf("parsing player %q: %w", spec, err)
+		}
+		players = append(players, p)
+	}
+	workers := c.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	result, err := versus.RoundRobin(ctx, &versus.TournamentInput{
+		Players:      players,
+		GamesPerPair: c.Games,
+		MaxDuration:  c.MaxDuration,
+		Seed:         c.Seed,
+		Workers:      workers,
+	})
+	if err != nil {
+		return fmt.Errorf("playing tournament: %w", err)
+	}
+
+	for _, p := range result.Pairings {
+		fmt.Printf("%s vs %s: %d-%d, %d drawn.\n",
+			players[p.Players[0]].Name, players[p.Players[1]].Name, p.Wins[0], p.Wins[1], p.Draws)
+	}
+	fmt.Println()
+	for i, s := range result.Standings {
+		fmt.Printf("%d. %s: %d won, %d lost, %d drawn. Win rate %.1f%% (95%% CI %.1f%%-%.1f%%).\n",
+			i+1, s.Player.Name, s.Wins, s.Losses, s.Draws, 100*s.WinRate, 100*s.WinRateLow, 100*s.WinRateHigh)
+	}
+	return nil
+}
+
+// parsePlayer returns the versus player described by the spec: a solver, then comma separated settings (eg.
+// "beam,depth=2,pps=3"). Players search on one goroutine each, since matches are played in parallel.
+func parsePlayer(spec string) (*versus.Player, error) {
+	fields := strings.Split(spec, ",")
+	flags := SolverFlags{
+		Solver:     fields[0],
+		Weights:    "el-tetris",
+		Width:      16,
+		Iterations: 1000,
+		Workers:    1,
+		TableSize:  16,
+		Seed:       1,
+	}
+	player := &versus.Player{Name: spec, PiecesPerSecond: 2}
+
+	for _, field := range fields[1:] {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, fmt.Errorf("setting %q is not of the form key=value", field)
+		}
+		var err error
+		switch key {
+		case "name":
+			player.Name = value
+		case "weights":
+			flags.Weights = value
+		case "pps":
+			player.PiecesPerSecond, err = strconv.ParseFloat(value, 64)
+		case "depth":
+			flags.Depth, err = strconv.Atoi(value)
+		case "width":
+			flags.Width, err = strconv.Atoi(value)
+		case "iterations":
+			flags.Iterations, err = strconv.Atoi(value)
+		default:
+			return nil, fmt.Errorf("unknown setting %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", key, err)
+		}
+	}
+	if !slices.Contains([]string{"beam", "mcts", "greedy"}, flags.Solver) {
+		return nil, fmt.Errorf("unknown solver %q", flags.Solver)
+	}
+
+	var err error
+	if player.Solver, err = flags.newSolver(); err != nil {
+		return nil, err
+	}
+	return player, nil
+}
+
 func launchStarter(globals *GlobalVars, starterMode tui.Mode, switchIn tui.SwitchModeInput) error {
 	db, err := data.NewDB(globals.DB)
 	if err != nil {
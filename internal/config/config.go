@@ -4,8 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/BurntSushi/toml"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
 )
 
 type Config struct {
@@ -24,6 +28,52 @@ type Config struct {
 	// Whether the game ends when the max level is reached.
 	EndOnMaxLevel bool `toml:"end_on_max_level"`
 
+	// The rotation system used to spawn and rotate tetriminos.
+	RotationSystem string `toml:"rotation_system"`
+
+	// The rules used to award points and advance levels.
+	ScoringRules string `toml:"scoring_rules"`
+
+	// The curve used to calculate how fast tetriminos fall at each level.
+	GravityCurve string `toml:"gravity_curve"`
+
+	// The order that tetriminos are dealt in.
+	Randomizer string `toml:"randomizer"`
+
+	// The delay between a tetrimino locking down and the next tetrimino spawning (eg. "100ms").
+	ARE time.Duration `toml:"are"`
+
+	// The delay added to ARE when lines are cleared (eg. "400ms").
+	LineClearDelay time.Duration `toml:"line_clear_delay"`
+
+	// The time a tetrimino can rest on a surface before it locks down (eg. "500ms").
+	LockDelay time.Duration `toml:"lock_delay"`
+
+	// The weights of the evaluator used by the solver for hints, reviews and versus: "dellacherie", "el-tetris", or the
+	// path of a weights file, such as one written by the tune command.
+	SolverWeights string `toml:"solver_weights"`
+
+	// The settings for the Dig game mode
+	Dig Dig `toml:"dig"`
+
+	// The settings for the Survival game mode
+	Survival Survival `toml:"survival"`
+
+	// The settings for the Versus game mode
+	Versus Versus `toml:"versus"`
+
+	// The size of the matrix in the game modes, except the daily challenge, puzzles and finesse trainer
+	Matrix Matrix `toml:"matrix"`
+
+	// The modifiers applied to the game modes, except the daily challenge, puzzles and finesse trainer
+	Modifiers Modifiers `toml:"modifiers"`
+
+	// The custom game modes
+	Modes []CustomMode `toml:"modes"`
+
+	// The paths of the puzzle packs shown in the menu
+	PuzzlePacks []string `toml:"puzzle_packs"`
+
 	// The styling for the game in all modes
 	Theme *Theme `toml:"theme"`
 
@@ -31,6 +81,93 @@ type Config struct {
 	Keys *Keys `toml:"keys"`
 }
 
+// Dig contains the settings for the Dig game mode.
+type Dig struct {
+	// The number of garbage rows to clear.
+	GarbageRows int `toml:"garbage_rows"`
+
+	// The chance (0-100) that the hole of each garbage row moves from the row below.
+	Messiness int `toml:"messiness"`
+}
+
+// Survival contains the settings for the Survival game mode.
+type Survival struct {
+	// The time before the first garbage row rises (eg. "10s").
+	GarbageInterval time.Duration `toml:"garbage_interval"`
+
+	// The shortest time between garbage rows rising (eg. "1s").
+	MinGarbageInterval time.Duration `toml:"min_garbage_interval"`
+
+	// The factor the interval is multiplied by after each garbage row rises. Must be between 0 and 1.
+	GarbageAcceleration float64 `toml:"garbage_acceleration"`
+}
+
+// Versus contains the settings for the Versus game mode, which is played against the solver.
+type Versus struct {
+	// The number of Tetriminos the solver places per second. Must be greater than 0.
+	PiecesPerSecond float64 `toml:"pieces_per_second"`
+
+	// The most Tetriminos the solver searches ahead. 0 means every known Tetrimino.
+	Depth int `toml:"depth"`
+}
+
+// Matrix contains the dimensions of the matrix.
+type Matrix struct {
+	// The number of columns.
+	Width int `toml:"width"`
+
+	// The number of visible rows.
+	Height int `toml:"height"`
+
+	// The number of rows above the visible rows, where tetriminos spawn.
+	BufferHeight int `toml:"buffer_height"`
+}
+
+// Dimensions returns the dimensions of the matrix for the given custom mode, which may be nil.
+// The dimensions set by the custom mode override those of the top level setting, and any which are unset (0) in both
+// are the standard dimensions.
+func (c *Config) Dimensions(cm *CustomMode) tetris.Dimensions {
+	dims := tetris.DefaultDimensions
+	override := func(width, height, bufferHeight int) {
+		if width > 0 {
+			dims.Width = width
+		}
+		if height > 0 {
+			dims.Height = height
+		}
+		if bufferHeight > 0 {
+			dims.Buffer = bufferHeight
+		}
+	}
+
+	override(c.Matrix.Width, c.Matrix.Height, c.Matrix.BufferHeight)
+	if cm != nil {
+		override(cm.Width, cm.Height, cm.BufferHeight)
+	}
+	return dims
+}
+
+// GetSolverWeights returns the weights of the evaluator used by the solver, which are loaded from the file if
+// SolverWeights is a path. An empty SolverWeights means "el-tetris".
+func (c *Config) GetSolverWeights() (eval.Weights, error) {
+	if c.SolverWeights == "" {
+		return eval.ElTetris(), nil
+	}
+	return eval.GetWeights(c.SolverWeights)
+}
+
+// Modifiers contains the settings for the novelty modifiers, which change how the game is played.
+type Modifiers struct {
+	// Whether locked minos are hidden once InvisibleDelay has passed. The stack is revealed at game over.
+	Invisible bool `toml:"invisible"`
+
+	// The time locked minos stay visible when playing invisible (eg. "3s").
+	InvisibleDelay time.Duration `toml:"invisible_delay"`
+
+	// Whether tetriminos are played at 2x scale, on a matrix of half the width and height.
+	Big bool `toml:"big"`
+}
+
 func GetConfig(path string) (*Config, error) {
 	c := Config{
 		NextQueueLength: 5,
@@ -38,6 +175,33 @@ func GetConfig(path string) (*Config, error) {
 		LockDownMode:    "Extended",
 		MaxLevel:        15,
 		EndOnMaxLevel:   false,
+		RotationSystem:  "SRS",
+		ScoringRules:    "Guideline",
+		GravityCurve:    "Guideline",
+		Randomizer:      "7-Bag",
+		SolverWeights:   "el-tetris",
+
+		Dig: Dig{
+			GarbageRows: 10,
+			Messiness:   50,
+		},
+		Survival: Survival{
+			GarbageInterval:     10 * time.Second,
+			MinGarbageInterval:  time.Second,
+			GarbageAcceleration: 0.9,
+		},
+		Versus: Versus{
+			PiecesPerSecond: 1,
+			Depth:           2,
+		},
+		Matrix: Matrix{
+			Width:        tetris.DefaultDimensions.Width,
+			Height:       tetris.DefaultDimensions.Height,
+			BufferHeight: tetris.DefaultDimensions.Buffer,
+		},
+		Modifiers: Modifiers{
+			InvisibleDelay: 3 * time.Second,
+		},
 
 		Theme: DefaultTheme(),
 		Keys:  DefaultKeys(),
@@ -66,5 +230,73 @@ func (c *Config) validate() error {
 	if c.LockDownMode != "Extended" && c.LockDownMode != "Infinite" && c.LockDownMode != "Classic" {
 		return fmt.Errorf("LockDownMode '%s' must be one of 'Extended', 'Infinite', or 'Classic'", c.LockDownMode)
 	}
+	if _, err := tetris.GetRotationSystem(c.RotationSystem); err != nil {
+		return fmt.Errorf("RotationSystem '%s' must be one of 'SRS', 'SRS+', 'ARS', or 'NES'", c.RotationSystem)
+	}
+	if _, err := tetris.GetScoringRules(c.ScoringRules); err != nil {
+		return fmt.Errorf("ScoringRules '%s' must be one of 'Guideline', 'NES', or 'TGM'", c.ScoringRules)
+	}
+	if _, err := tetris.GetGravityCurve(c.GravityCurve); err != nil {
+		return fmt.Errorf("GravityCurve '%s' must be one of 'Guideline', 'NES', or 'TGM'", c.GravityCurve)
+	}
+	if _, err := tetris.GetRandomizer(c.Randomizer); err != nil {
+		return fmt.Errorf("Randomizer '%s' must be one of '7-Bag', '14-Bag', 'Random', or 'TGM'", c.Randomizer)
+	}
+	if c.ARE < 0 {
+		return fmt.Errorf("ARE '%s' must not be negative", c.ARE)
+	}
+	if c.LineClearDelay < 0 {
+		return fmt.Errorf("LineClearDelay '%s' must not be negative", c.LineClearDelay)
+	}
+	if c.LockDelay < 0 {
+		return fmt.Errorf("LockDelay '%s' must not be negative", c.LockDelay)
+	}
+	if _, err := c.GetSolverWeights(); err != nil {
+		return fmt.Errorf("SolverWeights '%s' must be 'dellacherie', 'el-tetris', or a weights file: %w",
+			c.SolverWeights, err)
+	}
+	if c.Dig.GarbageRows < 1 || c.Dig.GarbageRows > 18 {
+		return fmt.Errorf("Dig.GarbageRows '%d' must be between 1 and 18", c.Dig.GarbageRows)
+	}
+	if c.Dig.Messiness < 0 || c.Dig.Messiness > 100 {
+		return fmt.Errorf("Dig.Messiness '%d' must be between 0 and 100", c.Dig.Messiness)
+	}
+	if c.Survival.MinGarbageInterval <= 0 {
+		return fmt.Errorf("Survival.MinGarbageInterval '%s' must be positive", c.Survival.MinGarbageInterval)
+	}
+	if c.Survival.GarbageInterval < c.Survival.MinGarbageInterval {
+		return fmt.Errorf("Survival.GarbageInterval '%s' must not be less than Survival.MinGarbageInterval '%s'",
+			c.Survival.GarbageInterval, c.Survival.MinGarbageInterval)
+	}
+	if c.Survival.GarbageAcceleration <= 0 || c.Survival.GarbageAcceleration > 1 {
+		return fmt.Errorf("Survival.GarbageAcceleration '%g' must be greater than 0 and at most 1",
+			c.Survival.GarbageAcceleration)
+	}
+	if c.Versus.PiecesPerSecond <= 0 {
+		return fmt.Errorf("Versus.PiecesPerSecond '%g' must be greater than 0", c.Versus.PiecesPerSecond)
+	}
+	if c.Versus.Depth < 0 {
+		return fmt.Errorf("Versus.Depth '%d' must not be negative", c.Versus.Depth)
+	}
+	if c.Matrix.Width < 0 || c.Matrix.Height < 0 || c.Matrix.BufferHeight < 0 {
+		return errors.New("Matrix.Width, Matrix.Height and Matrix.BufferHeight must not be negative")
+	}
+	if err := c.Dimensions(nil).Validate(); err != nil {
+		return fmt.Errorf("Matrix: %w", err)
+	}
+	if c.Modifiers.InvisibleDelay < 0 {
+		return fmt.Errorf("Modifiers.InvisibleDelay '%s' must not be negative", c.Modifiers.InvisibleDelay)
+	}
+	for i := range c.Modes {
+		if err := c.Modes[i].validate(c.Dimensions(&c.Modes[i])); err != nil {
+			return fmt.Errorf("Modes[%d]: %w", i, err)
+		}
+		if err := c.Dimensions(&c.Modes[i]).Validate(); err != nil {
+			return fmt.Errorf("Modes[%d]: %w", i, err)
+		}
+		if mode, _ := c.GetCustomMode(c.Modes[i].Name); mode != &c.Modes[i] {
+			return fmt.Errorf("Modes[%d]: Name '%s' is used by more than one mode", i, c.Modes[i].Name)
+		}
+	}
 	return nil
 }
@@ -11,6 +11,8 @@ type Keys struct {
 	Right                  []string `toml:"right"`
 	RotateCounterClockwise []string `toml:"rotate_counter_clockwise"`
 	RotateClockwise        []string `toml:"rotate_clockwise"`
+	Hint                   []string `toml:"hint"`
+	Review                 []string `toml:"review"`
 }
 
 func DefaultKeys() *Keys {
@@ -25,5 +27,7 @@ func DefaultKeys() *Keys {
 		Right:                  []string{"d"},
 		RotateCounterClockwise: []string{"q"},
 		RotateClockwise:        []string{"e"},
+		Hint:                   []string{"t"},
+		Review:                 []string{"r"},
 	}
 }
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Broderick-Westrope/tetrigo/internal/data"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+)
+
+// CustomMode is a single player game mode defined in config.
+// Each custom mode has its own leaderboard, which is named after the mode.
+type CustomMode struct {
+	// The name shown in the menu, and used to play the mode and view its leaderboard.
+	Name string `toml:"name"`
+
+	// The level to start at. 0 uses the level chosen in the menu or play command.
+	Level int `toml:"level"`
+	// The maximum level to reach before the game ends or the level stops increasing. 0 means no limit.
+	MaxLevel int `toml:"max_level"`
+	// Whether the level increases as lines are cleared.
+	IncreaseLevel bool `toml:"increase_level"`
+	// Whether the game ends when the max level is reached.
+	EndOnMaxLevel bool `toml:"end_on_max_level"`
+
+	// The number of lines to clear before the game ends or the lines stop counting. 0 means no limit.
+	MaxLines int `toml:"max_lines"`
+	// Whether the game ends when the max lines are cleared.
+	EndOnMaxLines bool `toml:"end_on_max_lines"`
+
+	// The time before the game ends (eg. "2m"). 0 means no time limit.
+	TimeLimit time.Duration `toml:"time_limit"`
+
+	// Whether a ghost piece is displayed. Unset uses the top level setting.
+	GhostEnabled *bool `toml:"ghost_enabled"`
+
+	// The rotation system, scoring rules, gravity curve and randomizer. Empty uses the top level setting.
+	RotationSystem string `toml:"rotation_system"`
+	ScoringRules   string `toml:"scoring_rules"`
+	GravityCurve   string `toml:"gravity_curve"`
+	Randomizer     string `toml:"randomizer"`
+
+	// The ARE, line clear delay and lock delay. Unset uses the top level setting.
+	ARE            *time.Duration `toml:"are"`
+	LineClearDelay *time.Duration `toml:"line_clear_delay"`
+	LockDelay      *time.Duration `toml:"lock_delay"`
+
+	// The number of garbage rows at the bottom of the matrix when the game starts. Must be less than the matrix height.
+	GarbageRows int `toml:"garbage_rows"`
+	// The chance (0-100) that the hole of each garbage row moves from the row below.
+	GarbageMessiness int `toml:"garbage_messiness"`
+	// Whether the game ends when all garbage rows are cleared.
+	EndOnGarbageCleared bool `toml:"end_on_garbage_cleared"`
+
+	// The width, visible height and buffer height of the matrix. 0 uses the top level setting.
+	Width        int `toml:"width"`
+	Height       int `toml:"height"`
+	BufferHeight int `toml:"buffer_height"`
+
+	// Whether the Invisible and Big modifiers are enabled. Unset uses the modifiers setting.
+	Invisible *bool `toml:"invisible"`
+	Big       *bool `toml:"big"`
+
+	// How the leaderboard is ranked. One of "score", "fastest_time", "longest_time", or "max_combo". Empty ranks by
+	// score.
+	Ranking string `toml:"ranking"`
+}
+
+// GetCustomMode returns the custom mode with the given name, ignoring case.
+func (c *Config) GetCustomMode(name string) (*CustomMode, bool) {
+	for i := range c.Modes {
+		if strings.EqualFold(c.Modes[i].Name, name) {
+			return &c.Modes[i], true
+		}
+	}
+	return nil, false
+}
+
+// validate checks the settings of the custom mode, which is played on a matrix of the given dimensions.
+func (cm *CustomMode) validate(dims tetris.Dimensions) error {
+	if strings.TrimSpace(cm.Name) == "" {
+		return errors.New("Name must not be empty")
+	}
+	if cm.Level < 0 {
+		return fmt.Errorf("Level '%d' must not be negative", cm.Level)
+	}
+	if cm.MaxLevel < 0 {
+		return fmt.Errorf("MaxLevel '%d' must not be negative", cm.MaxLevel)
+	}
+	if cm.MaxLines < 0 {
+		return fmt.Errorf("MaxLines '%d' must not be negative", cm.MaxLines)
+	}
+	if cm.TimeLimit < 0 {
+		return fmt.Errorf("TimeLimit '%s' must not be negative", cm.TimeLimit)
+	}
+	if cm.RotationSystem != "" {
+		if _, err := tetris.GetRotationSystem(cm.RotationSystem); err != nil {
+			return fmt.Errorf("RotationSystem: %w", err)
+		}
+	}
+	if cm.ScoringRules != "" {
+		if _, err := tetris.GetScoringRules(cm.ScoringRules); err != nil {
+			return fmt.Errorf("ScoringRules: %w", err)
+		}
+	}
+	if cm.GravityCurve != "" {
+		if _, err := tetris.GetGravityCurve(cm.GravityCurve); err != nil {
+			return fmt.Errorf("GravityCurve: %w", err)
+		}
+	}
+	if _, err := tetris.GetRandomizer(cm.Randomizer); err != nil {
+		return fmt.Errorf("Randomizer: %w", err)
+	}
+	for name, d := range map[string]*time.Duration{
+		"ARE": cm.ARE, "LineClearDelay": cm.LineClearDelay, "LockDelay": cm.LockDelay,
+	} {
+		if d != nil && *d < 0 {
+			return fmt.Errorf("%s '%s' must not be negative", name, *d)
+		}
+	}
+	if cm.GarbageRows < 0 || cm.GarbageRows >= dims.Height {
+		return fmt.Errorf("GarbageRows '%d' must be between 0 and %d (less than the matrix height)",
+			cm.GarbageRows, dims.Height-1)
+	}
+	if cm.Width < 0 || cm.Height < 0 || cm.BufferHeight < 0 {
+		return errors.New("Width, Height and BufferHeight must not be negative")
+	}
+	if cm.GarbageMessiness < 0 || cm.GarbageMessiness > 100 {
+		return fmt.Errorf("GarbageMessiness '%d' must be between 0 and 100", cm.GarbageMessiness)
+	}
+	if _, err := data.ParseRanking(cm.Ranking); err != nil {
+		return fmt.Errorf("Ranking: %w", err)
+	}
+	return nil
+}
@@ -11,8 +11,10 @@ type Theme struct {
 			J string `toml:"J"`
 			L string `toml:"L"`
 		} `toml:"tetrimino_cells"`
-		EmptyCell string `toml:"empty_cell"`
-		GhostCell string `toml:"ghost_cell"`
+		EmptyCell   string `toml:"empty_cell"`
+		GhostCell   string `toml:"ghost_cell"`
+		GarbageCell string `toml:"garbage_cell"`
+		HintCell    string `toml:"hint_cell"`
 	} `toml:"colours"`
 	Characters struct {
 		Tetriminos string `toml:"tetriminos"`
@@ -33,6 +35,8 @@ func DefaultTheme() *Theme {
 	theme.Colours.TetriminoCells.L = "#E07F3A"
 	theme.Colours.EmptyCell = "#303040"
 	theme.Colours.GhostCell = "white"
+	theme.Colours.GarbageCell = "#808080"
+	theme.Colours.HintCell = "#FF5FD7"
 
 	theme.Characters.Tetriminos = "██"
 	theme.Characters.EmptyCell = "▕ "
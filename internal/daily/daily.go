@@ -0,0 +1,72 @@
+// Package daily derives the daily challenge, which is the same for every player on a given UTC date.
+package daily
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+const (
+	// DateFormat is the format of the date of a challenge.
+	DateFormat = "2006-01-02"
+
+	// GameModePrefix prefixes the date of a challenge to make the name of its leaderboard.
+	GameModePrefix = "Daily "
+)
+
+var (
+	randomizers = []string{"7-Bag", "7-Bag", "14-Bag", "TGM", "Random"}
+	garbageRows = []int{0, 0, 4, 6, 8}
+	timeLimits  = []time.Duration{0, 2 * time.Minute, 3 * time.Minute}
+)
+
+// Challenge is the seed and modifiers of the daily challenge for a date.
+type Challenge struct {
+	Date        string        // The UTC date of the challenge, in DateFormat.
+	Seed        uint64        // The seed for the random source of the game.
+	Randomizer  string        // The name of the randomizer which deals the Tetriminos.
+	GarbageRows int           // The number of garbage rows when the game starts.
+	TimeLimit   time.Duration // The time before the game ends. 0 means no time limit.
+}
+
+// Today returns the Challenge for the current UTC date.
+func Today() Challenge {
+	return ForDate(time.Now())
+}
+
+// ForDate returns the Challenge for the UTC date of the given time.
+// The seed is a hash of the date, and the modifiers are chosen by a random source with that seed.
+func ForDate(t time.Time) Challenge {
+	date := t.UTC().Format(DateFormat)
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(date)) // Writing to a hash never returns an error.
+	seed := h.Sum64()
+
+	//nolint:gosec // This random source is not for any security-related tasks.
+	r := rand.New(rand.NewPCG(seed, ^seed))
+	return Challenge{
+		Date:        date,
+		Seed:        seed,
+		Randomizer:  randomizers[r.IntN(len(randomizers))],
+		GarbageRows: garbageRows[r.IntN(len(garbageRows))],
+		TimeLimit:   timeLimits[r.IntN(len(timeLimits))],
+	}
+}
+
+// GameMode returns the name of the leaderboard of the Challenge.
+func (c Challenge) GameMode() string {
+	return GameModePrefix + c.Date
+}
+
+// IsGameMode returns true if the given game mode is the leaderboard of a Challenge.
+func IsGameMode(gameMode string) bool {
+	date, ok := strings.CutPrefix(gameMode, GameModePrefix)
+	if !ok {
+		return false
+	}
+	_, err := time.Parse(DateFormat, date)
+	return err == nil
+}
@@ -0,0 +1,71 @@
+package daily
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForDate(t *testing.T) {
+	day := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := map[string]struct {
+		a, b      time.Time
+		wantEqual bool
+	}{
+		"same UTC date": {
+			a:         day.Add(time.Hour),
+			b:         day.Add(23 * time.Hour),
+			wantEqual: true,
+		},
+		"same UTC date in another time zone": {
+			a:         day.Add(time.Hour),
+			b:         day.Add(2 * time.Hour).In(time.FixedZone("UTC-10", -10*60*60)),
+			wantEqual: true,
+		},
+		"next UTC date": {
+			a:         day,
+			b:         day.Add(24 * time.Hour),
+			wantEqual: false,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			a, b := ForDate(tc.a), ForDate(tc.b)
+			if tc.wantEqual {
+				assert.Equal(t, a, b)
+			} else {
+				assert.NotEqual(t, a.Seed, b.Seed)
+				assert.NotEqual(t, a.Date, b.Date)
+			}
+		})
+	}
+}
+
+func TestIsGameMode(t *testing.T) {
+	tt := map[string]struct {
+		gameMode string
+		want     bool
+	}{
+		"challenge": {
+			gameMode: ForDate(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)).GameMode(),
+			want:     true,
+		},
+		"missing date": {
+			gameMode: "Daily ",
+			want:     false,
+		},
+		"other game mode": {
+			gameMode: "Marathon",
+			want:     false,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IsGameMode(tc.gameMode))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package data
 
 import (
 	"database/sql"
+	"fmt"
 
 	// Import the sqlite3 driver.
 	_ "github.com/mattn/go-sqlite3"
@@ -31,5 +32,60 @@ func EnsureTablesExist(db *sql.DB) error {
 		return err
 	}
 
+	// Columns added since the leaderboard table was created.
+	err = ensureColumnExists(db, "leaderboard", "grade", "TEXT NOT NULL DEFAULT ''")
+	if err != nil {
+		return err
+	}
+	err = ensureColumnExists(db, "leaderboard", "max_combo", "INTEGER NOT NULL DEFAULT 0")
+	if err != nil {
+		return err
+	}
+
+	// Attempts table, which records the players who have started each game mode which ranks only the first attempt
+	_, err = db.Exec(
+		`CREATE TABLE IF NOT EXISTS attempts
+(game_mode TEXT, name TEXT, PRIMARY KEY (game_mode, name))`,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Puzzle progress table
+	_, err = db.Exec(
+		`CREATE TABLE IF NOT EXISTS puzzle_progress
+(pack TEXT, name TEXT, solved INTEGER NOT NULL DEFAULT 0, PRIMARY KEY (pack, name))`,
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
+
+// ensureColumnExists adds a column with the given name and definition to the table if it does not already exist.
+func ensureColumnExists(db *sql.DB, table, column, definition string) error {
+	//nolint:gosec // The table name is never user input.
+	rows, err := db.Query(fmt.Sprintf("SELECT name FROM pragma_table_info('%s')", table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	//nolint:gosec // The table, column and definition are never user input.
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
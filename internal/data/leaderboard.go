@@ -2,6 +2,9 @@ package data
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -14,6 +17,49 @@ type Score struct {
 	Score    int
 	Lines    int
 	Level    int
+	Grade    string // The grade awarded, for game modes with grades.
+	MaxCombo int    // The highest combo reached.
+}
+
+// ErrAlreadySaved is returned by SaveOnce when the player already has a score in the game mode.
+var ErrAlreadySaved = errors.New("player already has a score in this game mode")
+
+// Ranking is the order in which scores are ranked on a leaderboard.
+type Ranking int
+
+const (
+	// RankByScore ranks the highest score first, with ties broken by the fastest time.
+	RankByScore = Ranking(iota)
+	// RankByFastestTime ranks the fastest time first, for game modes which are completed as fast as possible.
+	RankByFastestTime
+	// RankByLongestTime ranks the longest time first, for game modes which are survived as long as possible.
+	RankByLongestTime
+	// RankByMaxCombo ranks the highest combo first, with ties broken by the highest score.
+	RankByMaxCombo
+)
+
+var rankingToOrderMap = map[Ranking]string{
+	RankByScore:       "score DESC, time ASC",
+	RankByFastestTime: "time ASC, score DESC",
+	RankByLongestTime: "time DESC, score DESC",
+	RankByMaxCombo:    "max_combo DESC, score DESC",
+}
+
+// ParseRanking returns the Ranking with the given name.
+// Valid names are "score", "fastest_time", "longest_time", and "max_combo". An empty name returns RankByScore.
+func ParseRanking(name string) (Ranking, error) {
+	switch strings.ToLower(name) {
+	case "", "score":
+		return RankByScore, nil
+	case "fastest_time":
+		return RankByFastestTime, nil
+	case "longest_time":
+		return RankByLongestTime, nil
+	case "max_combo":
+		return RankByMaxCombo, nil
+	default:
+		return 0, fmt.Errorf("unknown ranking %q", name)
+	}
 }
 
 type LeaderboardRepository struct {
@@ -24,8 +70,19 @@ func NewLeaderboardRepository(db *sql.DB) *LeaderboardRepository {
 	return &LeaderboardRepository{db}
 }
 
-func (r *LeaderboardRepository) All(gameMode string) ([]Score, error) {
-	rows, err := r.db.Query("SELECT * FROM leaderboard WHERE game_mode = $1 ORDER BY score DESC, time ASC", gameMode)
+// All returns the scores for the given game mode in order of the given ranking.
+func (r *LeaderboardRepository) All(gameMode string, ranking Ranking) ([]Score, error) {
+	order, ok := rankingToOrderMap[ranking]
+	if !ok {
+		return nil, fmt.Errorf("invalid ranking %d", ranking)
+	}
+
+	//nolint:gosec // The order is one of the constant values in rankingToOrderMap.
+	rows, err := r.db.Query(
+		`SELECT id, game_mode, name, time, score, lines, level, grade, max_combo FROM leaderboard
+WHERE game_mode = $1 ORDER BY `+order,
+		gameMode,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +94,9 @@ func (r *LeaderboardRepository) All(gameMode string) ([]Score, error) {
 	var scores []Score
 	for rows.Next() {
 		var s Score
-		if err = rows.Scan(&s.ID, &s.GameMode, &s.Name, &s.Time, &s.Score, &s.Lines, &s.Level); err != nil {
+		if err = rows.Scan(
+			&s.ID, &s.GameMode, &s.Name, &s.Time, &s.Score, &s.Lines, &s.Level, &s.Grade, &s.MaxCombo,
+		); err != nil {
 			return nil, err
 		}
 		s.Rank = len(scores) + 1
@@ -50,8 +109,9 @@ func (r *LeaderboardRepository) All(gameMode string) ([]Score, error) {
 // Save saves a score to the leaderboard and returns the ID of the new score.
 func (r *LeaderboardRepository) Save(score *Score) (int, error) {
 	res, err := r.db.Exec(
-		"INSERT INTO leaderboard (game_mode, name, time, score, lines, level) VALUES ($1, $2, $3, $4, $5, $6)",
-		score.GameMode, score.Name, score.Time, score.Score, score.Lines, score.Level,
+		`INSERT INTO leaderboard (game_mode, name, time, score, lines, level, grade, max_combo)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		score.GameMode, score.Name, score.Time, score.Score, score.Lines, score.Level, score.Grade, score.MaxCombo,
 	)
 	if err != nil {
 		return 0, err
@@ -62,3 +122,82 @@ func (r *LeaderboardRepository) Save(score *Score) (int, error) {
 	}
 	return int(id), err
 }
+
+// SaveOnce saves a score to the leaderboard and returns the ID of the new score, unless the player already has a
+// score in the game mode, in which case ErrAlreadySaved is returned. This ranks only the first attempt of each player.
+func (r *LeaderboardRepository) SaveOnce(score *Score) (int, error) {
+	res, err := r.db.Exec(
+		`INSERT INTO leaderboard (game_mode, name, time, score, lines, level, grade, max_combo)
+SELECT $1, $2, $3, $4, $5, $6, $7, $8
+WHERE NOT EXISTS (SELECT 1 FROM leaderboard WHERE game_mode = $1 AND name = $2)`,
+		score.GameMode, score.Name, score.Time, score.Score, score.Lines, score.Level, score.Grade, score.MaxCombo,
+	)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if affected == 0 {
+		return 0, ErrAlreadySaved
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), err
+}
+
+// StartAttempt records that the player has started an attempt at the game mode, and returns true if it is their first.
+// Game modes which rank only the first attempt of each player (ie. the daily challenge) record attempts when they
+// start, so attempts which are abandoned before they are saved still count.
+func (r *LeaderboardRepository) StartAttempt(gameMode, name string) (bool, error) {
+	res, err := r.db.Exec(
+		`INSERT INTO attempts (game_mode, name) VALUES ($1, $2) ON CONFLICT (game_mode, name) DO NOTHING`,
+		gameMode, name,
+	)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// Winners returns the highest score of each game mode which starts with the given prefix, up to the given limit.
+// The game modes are in descending order, so game modes suffixed by a date are listed from the most recent.
+func (r *LeaderboardRepository) Winners(gameModePrefix string, limit int) ([]Score, error) {
+	//nolint:gosec // The order is one of the constant values in rankingToOrderMap.
+	rows, err := r.db.Query(
+		`SELECT id, game_mode, name, time, score, lines, level, grade, max_combo FROM leaderboard AS l
+WHERE substr(game_mode, 1, length($1)) = $1 AND id = (
+	SELECT id FROM leaderboard WHERE game_mode = l.game_mode ORDER BY `+rankingToOrderMap[RankByScore]+` LIMIT 1
+)
+ORDER BY game_mode DESC LIMIT $2`,
+		gameModePrefix, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []Score
+	for rows.Next() {
+		var s Score
+		if err = rows.Scan(
+			&s.ID, &s.GameMode, &s.Name, &s.Time, &s.Score, &s.Lines, &s.Level, &s.Grade, &s.MaxCombo,
+		); err != nil {
+			return nil, err
+		}
+		s.Rank = 1
+		scores = append(scores, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return scores, nil
+}
@@ -0,0 +1,41 @@
+package data
+
+import (
+	"database/sql"
+	"errors"
+)
+
+type PuzzleRepository struct {
+	db *sql.DB
+}
+
+func NewPuzzleRepository(db *sql.DB) *PuzzleRepository {
+	return &PuzzleRepository{db}
+}
+
+// Progress returns the number of puzzles the player has solved in the given pack of puzzles.
+func (r *PuzzleRepository) Progress(pack, name string) (int, error) {
+	var solved int
+	err := r.db.QueryRow(
+		`SELECT solved FROM puzzle_progress WHERE pack = $1 AND name = $2`,
+		pack, name,
+	).Scan(&solved)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return solved, nil
+}
+
+// SaveProgress saves the number of puzzles the player has solved in the given pack of puzzles.
+// Progress is never lost, so the number is only saved if it is greater than the saved number.
+func (r *PuzzleRepository) SaveProgress(pack, name string, solved int) error {
+	_, err := r.db.Exec(
+		`INSERT INTO puzzle_progress (pack, name, solved) VALUES ($1, $2, $3)
+ON CONFLICT (pack, name) DO UPDATE SET solved = max(solved, excluded.solved)`,
+		pack, name, solved,
+	)
+	return err
+}
@@ -0,0 +1,237 @@
+// Package puzzle loads packs of puzzles, and checks whether a game has reached the goal of a puzzle.
+package puzzle
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+)
+
+// dimensions are the dimensions of the Matrix puzzles are played on.
+var dimensions = tetris.DefaultDimensions
+
+// Goal is the type of goal of a puzzle.
+type Goal string
+
+const (
+	// GoalPerfectClear is reached by clearing every mino from the matrix.
+	GoalPerfectClear Goal = "perfect_clear"
+	// GoalAction is reached by performing a specific action, such as a T-Spin Double.
+	GoalAction Goal = "action"
+	// GoalLines is reached by clearing a number of lines.
+	GoalLines Goal = "lines"
+)
+
+// Result is the outcome of checking a game against the goal of a puzzle.
+type Result int
+
+const (
+	// Pending means the goal has not been reached, but still can be.
+	Pending = Result(iota)
+	// Solved means the goal has been reached.
+	Solved
+	// Failed means the goal can no longer be reached.
+	Failed
+)
+
+// Pack is a collection of puzzles, which are played in order.
+type Pack struct {
+	Name    string   `toml:"name"`
+	Puzzles []Puzzle `toml:"puzzles"`
+}
+
+// Puzzle is a starting matrix and a fixed sequence of Tetriminos, with a goal to reach using them.
+type Puzzle struct {
+	// The name shown while playing the puzzle.
+	Name string `toml:"name"`
+
+	// The bottom rows of the matrix, one line per row. Each row has a character per cell: "." is empty, "X" is garbage,
+	// and I, O, T, S, Z, J or L is a mino of that Tetrimino.
+	Matrix string `toml:"matrix"`
+	// The Tetriminos to play, in order (eg. "TIO").
+	Sequence string `toml:"sequence"`
+	// The Tetrimino in the hold slot when the puzzle starts. Empty means the hold slot is empty.
+	Hold string `toml:"hold"`
+
+	// The type of goal to reach.
+	Goal Goal `toml:"goal"`
+	// The action to perform, for GoalAction (eg. "TSpinDouble").
+	Action string `toml:"action"`
+	// The number of lines to clear, for GoalLines.
+	Lines int `toml:"lines"`
+	// The number of Tetriminos the goal must be reached within. 0 means the whole sequence can be played.
+	Pieces int `toml:"pieces"`
+}
+
+// LoadPack reads and validates the pack of puzzles at the given path.
+// If the pack has no name, the name of the file is used.
+func LoadPack(path string) (*Pack, error) {
+	var pack Pack
+	_, err := toml.DecodeFile(path, &pack)
+	if err != nil {
+		return nil, fmt.Errorf("decoding toml file: %w", err)
+	}
+
+	if pack.Name == "" {
+		pack.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	err = pack.validate()
+	if err != nil {
+		return nil, fmt.Errorf("validating pack: %w", err)
+	}
+	return &pack, nil
+}
+
+func (p *Pack) validate() error {
+	if len(p.Puzzles) == 0 {
+		return errors.New("pack has no puzzles")
+	}
+	for i := range p.Puzzles {
+		if err := p.Puzzles[i].validate(); err != nil {
+			return fmt.Errorf("puzzle %d (%q): %w", i+1, p.Puzzles[i].Name, err)
+		}
+	}
+	return nil
+}
+
+func (p *Puzzle) validate() error {
+	if _, err := p.Input(); err != nil {
+		return err
+	}
+	switch p.Goal {
+	case GoalPerfectClear:
+	case GoalAction:
+		if !tetris.ParseAction(p.Action).IsValid() {
+			return fmt.Errorf("invalid action %q", p.Action)
+		}
+	case GoalLines:
+		if p.Lines <= 0 {
+			return fmt.Errorf("lines '%d' must be greater than 0", p.Lines)
+		}
+	default:
+		return fmt.Errorf("goal %q must be one of %q, %q, or %q", p.Goal, GoalPerfectClear, GoalAction, GoalLines)
+	}
+	if p.Pieces < 0 {
+		return fmt.Errorf("pieces '%d' must not be negative", p.Pieces)
+	}
+	return nil
+}
+
+// Input returns the input for a game of the puzzle.
+func (p *Puzzle) Input() (*single.Input, error) {
+	matrix, err := p.parseMatrix()
+	if err != nil {
+		return nil, fmt.Errorf("parsing matrix: %w", err)
+	}
+	if len(p.Sequence) == 0 {
+		return nil, errors.New("sequence must not be empty")
+	}
+	if len(p.Hold) > 1 {
+		return nil, fmt.Errorf("hold %q must be a single Tetrimino", p.Hold)
+	}
+
+	in := &single.Input{
+		Level:        1,
+		GhostEnabled: true,
+		Matrix:       matrix,
+		Dimensions:   dimensions,
+		Sequence:     []byte(strings.ToUpper(p.Sequence)),
+	}
+	if p.Hold != "" {
+		in.Hold = strings.ToUpper(p.Hold)[0]
+	}
+	return in, nil
+}
+
+// parseMatrix returns a matrix with the rows of the puzzle at the bottom.
+func (p *Puzzle) parseMatrix() (tetris.Matrix, error) {
+	matrix, err := tetris.NewMatrixWithDimensions(dimensions)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := strings.Split(strings.TrimSpace(p.Matrix), "\n")
+	if p.Matrix == "" {
+		rows = nil
+	}
+	if len(rows) > dimensions.Height {
+		return nil, fmt.Errorf("matrix has %d rows, but the limit is %d", len(rows), dimensions.Height)
+	}
+
+	offset := len(matrix) - len(rows)
+	for i, row := range rows {
+		row = strings.TrimSpace(row)
+		if len(row) != dimensions.Width {
+			return nil, fmt.Errorf("row %d has %d cells, but must have %d", i+1, len(row), dimensions.Width)
+		}
+		for col := range len(row) {
+			cell := row[col]
+			switch {
+			case cell == '.':
+				cell = 0
+			case cell == tetris.GarbageCell:
+			case strings.IndexByte("IOTSZJL", cell) >= 0:
+			default:
+				return nil, fmt.Errorf("row %d has invalid cell %q", i+1, cell)
+			}
+			matrix[offset+i][col] = cell
+		}
+	}
+	return matrix, nil
+}
+
+// Check returns whether the game has reached the goal of the puzzle.
+// A puzzle is failed if the game is over, or all of the allowed pieces were placed, without reaching the goal.
+func (p *Puzzle) Check(game *single.Game) Result {
+	var solved bool
+	switch p.Goal {
+	case GoalPerfectClear:
+		solved = game.GetPerfectClears() > 0
+	case GoalAction:
+		solved = game.GetLastAction() == tetris.ParseAction(p.Action)
+	case GoalLines:
+		solved = game.GetLinesCleared() >= p.Lines
+	}
+
+	switch {
+	case solved:
+		return Solved
+	case game.IsGameOver(), p.Pieces > 0 && game.GetPiecesPlaced() >= p.Pieces:
+		return Failed
+	default:
+		return Pending
+	}
+}
+
+// PiecesLeft returns the number of Tetriminos which can still be placed to reach the goal of the puzzle.
+func (p *Puzzle) PiecesLeft(game *single.Game) int {
+	limit := len(p.Sequence) + len(p.Hold)
+	if p.Pieces > 0 {
+		limit = min(p.Pieces, limit)
+	}
+	return max(limit-game.GetPiecesPlaced(), 0)
+}
+
+// Description returns a short description of the goal of the puzzle.
+func (p *Puzzle) Description() string {
+	var goal string
+	switch p.Goal {
+	case GoalPerfectClear:
+		goal = "Perfect Clear"
+	case GoalAction:
+		goal = tetris.ParseAction(p.Action).String()
+	case GoalLines:
+		goal = fmt.Sprintf("Clear %d lines", p.Lines)
+	}
+	if p.Pieces > 0 {
+		goal += fmt.Sprintf(" in %d pieces", p.Pieces)
+	}
+	return goal
+}
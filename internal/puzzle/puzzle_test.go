@@ -0,0 +1,174 @@
+package puzzle
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+)
+
+func TestLoadPack_Example(t *testing.T) {
+	pack, err := LoadPack("../../example.puzzles.toml")
+	require.NoError(t, err)
+	assert.Equal(t, "Basics", pack.Name)
+
+	// The moves which solve each puzzle of the example pack.
+	solutions := map[string]func(*testing.T, *single.Game){
+		"Tetris": func(t *testing.T, game *single.Game) {
+			require.NoError(t, game.Rotate(true))
+			for range 5 {
+				game.MoveRight()
+			}
+			hardDrop(t, game)
+		},
+		"Perfect Clear": func(t *testing.T, game *single.Game) {
+			_, err := game.Hold()
+			require.NoError(t, err)
+			for range 5 {
+				game.MoveRight()
+			}
+			hardDrop(t, game)
+		},
+		"T-Spin Double": func(t *testing.T, game *single.Game) {
+			require.NoError(t, game.Rotate(true))
+			game.MoveLeft()
+			game.MoveLeft()
+			softDropToFloor(t, game)
+			require.NoError(t, game.Rotate(true))
+			hardDrop(t, game)
+		},
+	}
+
+	require.Len(t, pack.Puzzles, len(solutions))
+	for _, p := range pack.Puzzles {
+		t.Run(p.Name, func(t *testing.T) {
+			game := newGame(t, &p)
+			assert.Equal(t, Pending, p.Check(game))
+
+			solutions[p.Name](t, game)
+			assert.Equal(t, Solved, p.Check(game))
+		})
+	}
+}
+
+func TestPuzzle_Check(t *testing.T) {
+	p := Puzzle{
+		Matrix:   "XXXXXXXX..\nXXXXXXXX..",
+		Sequence: "OO",
+		Goal:     GoalPerfectClear,
+	}
+
+	tt := map[string]struct {
+		pieces int
+		want   Result
+	}{
+		"failed when the pieces run out": {
+			pieces: 1,
+			want:   Failed,
+		},
+		"pending while pieces remain": {
+			pieces: 0,
+			want:   Pending,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			p.Pieces = tc.pieces
+			game := newGame(t, &p)
+
+			// Dropping the O in the middle misses the Perfect Clear.
+			hardDrop(t, game)
+			assert.Equal(t, tc.want, p.Check(game))
+		})
+	}
+}
+
+func TestPuzzle_Validate(t *testing.T) {
+	tt := map[string]struct {
+		puzzle  Puzzle
+		wantErr bool
+	}{
+		"valid": {
+			puzzle: Puzzle{Matrix: "XXXXXXXX..", Sequence: "O", Goal: GoalLines, Lines: 1},
+		},
+		"empty matrix": {
+			puzzle: Puzzle{Sequence: "T", Goal: GoalAction, Action: "TSpin"},
+		},
+		"short row": {
+			puzzle:  Puzzle{Matrix: "XXXX", Sequence: "O", Goal: GoalPerfectClear},
+			wantErr: true,
+		},
+		"taller than the visible matrix": {
+			puzzle: Puzzle{
+				Matrix:   strings.Repeat("XXXXXXXX..\n", 21),
+				Sequence: "O",
+				Goal:     GoalPerfectClear,
+			},
+			wantErr: true,
+		},
+		"invalid cell": {
+			puzzle:  Puzzle{Matrix: "XXXXXXXX?.", Sequence: "O", Goal: GoalPerfectClear},
+			wantErr: true,
+		},
+		"empty sequence": {
+			puzzle:  Puzzle{Goal: GoalPerfectClear},
+			wantErr: true,
+		},
+		"invalid action": {
+			puzzle:  Puzzle{Sequence: "T", Goal: GoalAction, Action: "Quadruple"},
+			wantErr: true,
+		},
+		"unknown goal": {
+			puzzle:  Puzzle{Sequence: "T", Goal: "win"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			err := tc.puzzle.validate()
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func newGame(t *testing.T, p *Puzzle) *single.Game {
+	in, err := p.Input()
+	require.NoError(t, err)
+	in.Rand = rand.New(rand.NewPCG(0, 0))
+
+	game, err := single.NewGame(in)
+	require.NoError(t, err)
+	return game
+}
+
+func hardDrop(t *testing.T, game *single.Game) {
+	_, err := game.HardDrop()
+	require.NoError(t, err)
+}
+
+// softDropToFloor lowers the Tetrimino in play until it lands, without locking it down.
+// The Tetrimino has landed once it covers its ghost, since puzzles always show the ghost.
+func softDropToFloor(t *testing.T, game *single.Game) {
+	for range 40 {
+		matrix, err := game.GetVisibleMatrix()
+		require.NoError(t, err)
+		if !slices.ContainsFunc(matrix, func(row []byte) bool { return slices.Contains(row, 'G') }) {
+			return
+		}
+
+		_, err = game.TickLower()
+		require.NoError(t, err)
+	}
+	t.Fatal("the Tetrimino did not land")
+}
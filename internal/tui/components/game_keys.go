@@ -18,6 +18,8 @@ type GameKeyMap struct {
 	SoftDrop         key.Binding
 	HardDrop         key.Binding
 	Hold             key.Binding
+	Hint             key.Binding
+	Review           key.Binding
 }
 
 func ConstructGameKeyMap(keys *config.Keys) *GameKeyMap {
@@ -32,6 +34,8 @@ func ConstructGameKeyMap(keys *config.Keys) *GameKeyMap {
 		SoftDrop:         charmutils.ConstructKeyBinding(keys.Down, "toggle soft drop"),
 		HardDrop:         charmutils.ConstructKeyBinding(keys.Up, "hard drop"),
 		Hold:             charmutils.ConstructKeyBinding(keys.Submit, "hold"),
+		Hint:             charmutils.ConstructKeyBinding(keys.Hint, "hint"),
+		Review:           charmutils.ConstructKeyBinding(keys.Review, "review"),
 	}
 }
 
@@ -59,5 +63,9 @@ func (k *GameKeyMap) FullHelp() [][]key.Binding {
 			k.HardDrop,
 			k.Hold,
 		},
+		{
+			k.Hint,
+			k.Review,
+		},
 	}
 }
@@ -10,8 +10,12 @@ type GameStyles struct {
 	EmptyCell           lipgloss.Style
 	TetriminoCellStyles map[byte]lipgloss.Style
 	GhostCell           lipgloss.Style
+	GarbageCell         lipgloss.Style
+	HintCell            lipgloss.Style
+	GarbageMeter        lipgloss.Style
 	Hold                holdStyles
 	Information         lipgloss.Style
+	Callout             lipgloss.Style
 	RowIndicator        lipgloss.Style
 	Bag                 lipgloss.Style
 	CellChar            cellCharacters
@@ -42,7 +46,11 @@ func CreateGameStyles(theme *config.Theme) *GameStyles {
 			'J': lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Colours.TetriminoCells.J)),
 			'L': lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Colours.TetriminoCells.L)),
 		},
-		GhostCell: lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Colours.GhostCell)),
+		GhostCell:   lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Colours.GhostCell)),
+		GarbageCell: lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Colours.GarbageCell)),
+		HintCell:    lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Colours.HintCell)),
+		GarbageMeter: lipgloss.NewStyle().Border(lipgloss.RoundedBorder(), true, false, true, true).
+			Foreground(lipgloss.Color(theme.Colours.GarbageCell)),
 		Hold: holdStyles{
 			View: lipgloss.NewStyle().Width(10).Height(5).
 				Border(lipgloss.RoundedBorder(), true, false, true, true).
@@ -51,6 +59,7 @@ func CreateGameStyles(theme *config.Theme) *GameStyles {
 			Item:  lipgloss.NewStyle().Width(10).Height(2).Align(lipgloss.Center, lipgloss.Center),
 		},
 		Information: lipgloss.NewStyle().Width(13).Align(lipgloss.Left, lipgloss.Top),
+		Callout:     lipgloss.NewStyle().Width(13).Bold(true).Align(lipgloss.Center).PaddingTop(1),
 		RowIndicator: lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Characters.EmptyCell)).
 			Align(lipgloss.Left).Padding(0, 1, 0),
 		Bag: lipgloss.NewStyle().PaddingTop(1),
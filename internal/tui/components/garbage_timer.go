@@ -0,0 +1,114 @@
+package components
+
+import (
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// GarbageTimer sends a GarbageMsg at an interval which accelerates after each message.
+// This is used to send rising garbage in single player modes without an opponent.
+type GarbageTimer interface {
+	Init() tea.Cmd
+	Update(tea.Msg) (tea.Model, tea.Cmd)
+	View() string
+	ID() int
+	Interval() time.Duration
+	Toggle() tea.Cmd
+	Stop() tea.Cmd
+}
+
+// GarbageMsg is sent by a GarbageTimer when garbage should be sent.
+type GarbageMsg struct {
+	ID   int // The ID of the GarbageTimer which sent the message.
+	Rows int // The number of garbage rows to send.
+}
+
+// garbageTickMsg is sent when the interval of a GarbageTimer elapses.
+// The tag is used to ignore ticks which were scheduled before the timer was paused or stopped.
+type garbageTickMsg struct {
+	id  int
+	tag int
+}
+
+var lastGarbageTimerID atomic.Int64
+
+type garbageTimerImpl struct {
+	id      int
+	tag     int
+	running bool
+
+	interval     time.Duration
+	minInterval  time.Duration
+	acceleration float64
+	rows         int
+}
+
+// NewGarbageTimer creates a GarbageTimer which sends the given number of rows after each interval.
+// After each message the interval is multiplied by the acceleration (eg. 0.9), down to the minimum interval.
+func NewGarbageTimer(interval, minInterval time.Duration, acceleration float64, rows int) GarbageTimer {
+	return &garbageTimerImpl{
+		id:           int(lastGarbageTimerID.Add(1)),
+		interval:     interval,
+		minInterval:  minInterval,
+		acceleration: acceleration,
+		rows:         rows,
+	}
+}
+
+func (g *garbageTimerImpl) Init() tea.Cmd {
+	g.running = true
+	return g.tick()
+}
+
+func (g *garbageTimerImpl) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	tickMsg, ok := msg.(garbageTickMsg)
+	if !ok || tickMsg.id != g.id || tickMsg.tag != g.tag || !g.running {
+		return g, nil
+	}
+
+	g.interval = max(time.Duration(float64(g.interval)*g.acceleration), g.minInterval)
+	g.tag++
+
+	garbageMsg := GarbageMsg{ID: g.id, Rows: g.rows}
+	return g, tea.Batch(
+		func() tea.Msg { return garbageMsg },
+		g.tick(),
+	)
+}
+
+func (g *garbageTimerImpl) View() string {
+	return g.interval.String()
+}
+
+func (g *garbageTimerImpl) ID() int {
+	return g.id
+}
+
+// Interval returns the time between the next messages.
+func (g *garbageTimerImpl) Interval() time.Duration {
+	return g.interval
+}
+
+// Toggle pauses or resumes the timer. When resumed, the current interval starts again from the beginning.
+func (g *garbageTimerImpl) Toggle() tea.Cmd {
+	if g.running {
+		return g.Stop()
+	}
+	g.running = true
+	return g.tick()
+}
+
+func (g *garbageTimerImpl) Stop() tea.Cmd {
+	g.running = false
+	g.tag++
+	return nil
+}
+
+func (g *garbageTimerImpl) tick() tea.Cmd {
+	id, tag := g.id, g.tag
+	return tea.Tick(g.interval, func(time.Time) tea.Msg {
+		return garbageTickMsg{id: id, tag: tag}
+	})
+}
@@ -1,9 +1,12 @@
 package tui
 
 import (
+	"strings"
+
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/Broderick-Westrope/tetrigo/internal/data"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/analysis"
 )
 
 type SwitchModeMsg struct {
@@ -31,39 +34,111 @@ const (
 	ModeMarathon
 	ModeSprint
 	ModeUltra
+	ModeMaster
+	ModeDig
+	ModeSurvival
+	ModeCombo
+	ModeCustom
+	ModeDaily
+	ModePuzzle
+	ModeFinesse
+	ModePerfectClear
+	ModeVersus
 	ModeLeaderboard
+	ModeReview
 )
 
 var modeToStrMap = map[Mode]string{
-	ModeMenu:        "Menu",
-	ModeMarathon:    "Marathon",
-	ModeSprint:      "Sprint",
-	ModeUltra:       "Ultra",
-	ModeLeaderboard: "Leaderboard",
+	ModeMenu:         "Menu",
+	ModeMarathon:     "Marathon",
+	ModeSprint:       "Sprint",
+	ModeUltra:        "Ultra",
+	ModeMaster:       "Master",
+	ModeDig:          "Dig",
+	ModeSurvival:     "Survival",
+	ModeCombo:        "Combo",
+	ModeCustom:       "Custom",
+	ModeDaily:        "Daily",
+	ModePuzzle:       "Puzzle",
+	ModeFinesse:      "Finesse",
+	ModePerfectClear: "Perfect Clear",
+	ModeVersus:       "Versus",
+	ModeLeaderboard:  "Leaderboard",
+	ModeReview:       "Review",
 }
 
 func (m Mode) String() string {
 	return modeToStrMap[m]
 }
 
+// IsModeName returns true if the given name is the name of a Mode, ignoring case.
+func IsModeName(name string) bool {
+	for _, str := range modeToStrMap {
+		if strings.EqualFold(str, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // SwitchModeInput values --------------------------------------------------
 
 type SingleInput struct {
-	Mode     Mode
-	Level    int
-	Username string
+	Mode       Mode
+	Level      int
+	Username   string
+	Seed       uint64 // The seed for the random source of the game. 0 means a random seed.
+	CustomMode string // The name of the custom game mode from config, when Mode is ModeCustom.
+	PuzzlePack string // The path of the pack of puzzles to play, when Mode is ModePuzzle.
+	Invisible  bool   // Whether to play with the Invisible modifier, regardless of config.
+	Big        bool   // Whether to play with the Big modifier, regardless of config.
 }
 
-func NewSingleInput(mode Mode, level int, username string) *SingleInput {
-	return &SingleInput{
+func NewSingleInput(mode Mode, level int, username string, opts ...func(*SingleInput)) *SingleInput {
+	in := &SingleInput{
 		Mode:     mode,
 		Level:    level,
 		Username: username,
 	}
+
+	for _, opt := range opts {
+		opt(in)
+	}
+
+	return in
 }
 
 func (in *SingleInput) isSwitchModeInput() {}
 
+// WithSeed sets the seed for the random source of the game, so the same Tetriminos and garbage are generated.
+func WithSeed(seed uint64) func(*SingleInput) {
+	return func(in *SingleInput) {
+		in.Seed = seed
+	}
+}
+
+// WithCustomMode sets the name of the custom game mode from config to play.
+func WithCustomMode(name string) func(*SingleInput) {
+	return func(in *SingleInput) {
+		in.CustomMode = name
+	}
+}
+
+// WithPuzzlePack sets the path of the pack of puzzles to play.
+func WithPuzzlePack(path string) func(*SingleInput) {
+	return func(in *SingleInput) {
+		in.PuzzlePack = path
+	}
+}
+
+// WithModifiers enables the Invisible and Big modifiers, in addition to those enabled in config.
+func WithModifiers(invisible, big bool) func(*SingleInput) {
+	return func(in *SingleInput) {
+		in.Invisible = invisible
+		in.Big = big
+	}
+}
+
 type MenuInput struct{}
 
 func NewMenuInput() *MenuInput {
@@ -75,6 +150,7 @@ func (in *MenuInput) isSwitchModeInput() {}
 type LeaderboardInput struct {
 	GameMode string
 	NewEntry *data.Score
+	Ranking  data.Ranking // The ranking of game modes which do not have their own ranking (ie. custom game modes).
 }
 
 func NewLeaderboardInput(gameMode string, opts ...func(input *LeaderboardInput)) *LeaderboardInput {
@@ -96,3 +172,27 @@ func WithNewEntry(entry *data.Score) func(input *LeaderboardInput) {
 		in.NewEntry = entry
 	}
 }
+
+// WithRanking sets the ranking of the leaderboard for a custom game mode.
+func WithRanking(ranking data.Ranking) func(input *LeaderboardInput) {
+	return func(in *LeaderboardInput) {
+		in.Ranking = ranking
+	}
+}
+
+// ReviewInput is the input of the review of the placements of a finished game.
+type ReviewInput struct {
+	Turns       []analysis.Turn   // The placements of the game, in order.
+	Skyline     int               // The number of rows of the Matrix above the visible Matrix.
+	Leaderboard *LeaderboardInput // The leaderboard to show once the review is exited. Nil means the menu is shown.
+}
+
+func NewReviewInput(turns []analysis.Turn, skyline int, leaderboard *LeaderboardInput) *ReviewInput {
+	return &ReviewInput{
+		Turns:       turns,
+		Skyline:     skyline,
+		Leaderboard: leaderboard,
+	}
+}
+
+func (in *ReviewInput) isSwitchModeInput() {}
@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/Broderick-Westrope/charmutils"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/Broderick-Westrope/tetrigo/internal/config"
+	"github.com/Broderick-Westrope/tetrigo/internal/daily"
+	"github.com/Broderick-Westrope/tetrigo/internal/data"
 	"github.com/Broderick-Westrope/tetrigo/internal/tui"
 	"github.com/Broderick-Westrope/tetrigo/internal/tui/views"
 )
@@ -46,6 +49,13 @@ type Model struct {
 }
 
 func NewModel(in *Input) (*Model, error) {
+	// Custom modes share leaderboards with the modes of the same name, so they must not use those names.
+	for _, cm := range in.cfg.Modes {
+		if tui.IsModeName(cm.Name) || daily.IsGameMode(cm.Name) {
+			return nil, fmt.Errorf("custom mode name %q is already used by a built-in mode", cm.Name)
+		}
+	}
+
 	m := &Model{
 		db:           in.db,
 		cfg:          in.cfg,
@@ -107,30 +117,102 @@ func (m *Model) setChild(mode tui.Mode, switchIn tui.SwitchModeInput) error {
 		if !ok {
 			return fmt.Errorf("switchIn is not a MenuInput: %w", charmutils.ErrInvalidTypeAssertion)
 		}
-		m.child = views.NewMenuModel(menuIn)
+		m.child = views.NewMenuModel(menuIn,
+			views.WithCustomModes(m.cfg.Modes),
+			views.WithPuzzlePacks(m.cfg.PuzzlePacks),
+		)
 
-	case tui.ModeMarathon, tui.ModeSprint, tui.ModeUltra:
+	case tui.ModeMarathon, tui.ModeSprint, tui.ModeUltra, tui.ModeMaster, tui.ModeDig, tui.ModeSurvival,
+		tui.ModeCombo, tui.ModeCustom, tui.ModeDaily:
 		singleIn, ok := switchIn.(*tui.SingleInput)
 		if !ok {
 			return fmt.Errorf("switchIn is not a SingleInput: %w", charmutils.ErrInvalidTypeAssertion)
 		}
-		child, err := views.NewSingleModel(singleIn, m.cfg)
+		child, err := views.NewSingleModel(singleIn, m.cfg,
+			views.WithAttempts(data.NewLeaderboardRepository(m.db)),
+		)
 		if err != nil {
 			return fmt.Errorf("creating single model: %w", err)
 		}
 		m.child = child
 
+	case tui.ModePuzzle:
+		singleIn, ok := switchIn.(*tui.SingleInput)
+		if !ok {
+			return fmt.Errorf("switchIn is not a SingleInput: %w", charmutils.ErrInvalidTypeAssertion)
+		}
+		child, err := views.NewPuzzleModel(singleIn, m.cfg, m.db)
+		if err != nil {
+			return fmt.Errorf("creating puzzle model: %w", err)
+		}
+		m.child = child
+
+	case tui.ModeFinesse:
+		singleIn, ok := switchIn.(*tui.SingleInput)
+		if !ok {
+			return fmt.Errorf("switchIn is not a SingleInput: %w", charmutils.ErrInvalidTypeAssertion)
+		}
+		child, err := views.NewFinesseModel(singleIn, m.cfg)
+		if err != nil {
+			return fmt.Errorf("creating finesse model: %w", err)
+		}
+		m.child = child
+
+	case tui.ModePerfectClear:
+		singleIn, ok := switchIn.(*tui.SingleInput)
+		if !ok {
+			return fmt.Errorf("switchIn is not a SingleInput: %w", charmutils.ErrInvalidTypeAssertion)
+		}
+		child, err := views.NewPerfectClearModel(singleIn, m.cfg)
+		if err != nil {
+			return fmt.Errorf("creating perfect clear model: %w", err)
+		}
+		m.child = child
+
+	case tui.ModeVersus:
+		singleIn, ok := switchIn.(*tui.SingleInput)
+		if !ok {
+			return fmt.Errorf("switchIn is not a SingleInput: %w", charmutils.ErrInvalidTypeAssertion)
+		}
+		child, err := views.NewVersusModel(singleIn, m.cfg)
+		if err != nil {
+			return fmt.Errorf("creating versus model: %w", err)
+		}
+		m.child = child
+
 	case tui.ModeLeaderboard:
 		leaderboardIn, ok := switchIn.(*tui.LeaderboardInput)
 		if !ok {
 			return fmt.Errorf("switchIn is not a LeaderboardInput: %w", charmutils.ErrInvalidTypeAssertion)
 		}
+		if strings.EqualFold(leaderboardIn.GameMode, tui.ModeDaily.String()) {
+			leaderboardIn.GameMode = daily.Today().GameMode()
+		}
+		if cm, ok := m.cfg.GetCustomMode(leaderboardIn.GameMode); ok {
+			ranking, err := data.ParseRanking(cm.Ranking)
+			if err != nil {
+				return fmt.Errorf("parsing ranking of custom mode: %w", err)
+			}
+			leaderboardIn.GameMode = cm.Name
+			leaderboardIn.Ranking = ranking
+		}
 		child, err := views.NewLeaderboardModel(leaderboardIn, m.db)
 		if err != nil {
 			return fmt.Errorf("creating leaderboard model: %w", err)
 		}
 		m.child = child
 
+	case tui.ModeReview:
+		reviewIn, ok := switchIn.(*tui.ReviewInput)
+		if !ok {
+			return fmt.Errorf("switchIn is not a ReviewInput: %w", charmutils.ErrInvalidTypeAssertion)
+		}
+		child, err := views.NewReviewModel(reviewIn, m.cfg)
+		if err != nil {
+			return fmt.Errorf("creating review model: %w", err)
+		}
+		m.child = child
+
 	default:
 		return errors.New("invalid Mode")
 	}
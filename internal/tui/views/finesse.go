@@ -0,0 +1,197 @@
+package views
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Broderick-Westrope/tetrigo/internal/config"
+	"github.com/Broderick-Westrope/tetrigo/internal/tui"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+)
+
+var _ tea.Model = &FinesseModel{}
+
+// FinesseModel trains finesse with drills. Each drill is a single Tetrimino on an empty matrix with a target placement
+// shown as a ghost. A drill is passed by placing the Tetrimino on the target with the fewest inputs, otherwise it is
+// retried.
+type FinesseModel struct {
+	child *SingleModel
+	in    *tui.SingleInput
+	cfg   *config.Config
+
+	rand           *rand.Rand
+	rotationSystem tetris.RotationSystem
+	randomizer     tetris.Randomizer
+	queue          []tetris.Tetrimino // The Tetriminos of the upcoming drills.
+	matrix         tetris.Matrix      // The empty matrix of the drills.
+	spawn          *tetris.Tetrimino  // The Tetrimino of the current drill, where it spawns.
+	target         *tetris.Tetrimino  // The placement of the current drill.
+
+	passed int    // The number of drills passed.
+	faults int    // The number of drills retried.
+	notice string // The outcome of the last drill.
+
+	width  int
+	height int
+}
+
+func NewFinesseModel(in *tui.SingleInput, cfg *config.Config, opts ...func(*FinesseModel)) (*FinesseModel, error) {
+	rs, err := tetris.GetRotationSystem(cfg.RotationSystem)
+	if err != nil {
+		return nil, fmt.Errorf("getting rotation system: %w", err)
+	}
+
+	m := &FinesseModel{
+		in:             in,
+		cfg:            cfg,
+		rotationSystem: rs,
+		randomizer:     tetris.NewBagRandomizer(1),
+		//nolint:gosec // This random source is not for any security-related tasks.
+		rand: rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+	if in.Seed != 0 {
+		//nolint:gosec // This random source is not for any security-related tasks.
+		m.rand = rand.New(rand.NewPCG(in.Seed, in.Seed))
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	err = m.nextDrill()
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// WithFinesseRandSource sets the random source used to choose the Tetriminos and target placements of the drills.
+func WithFinesseRandSource(r *rand.Rand) func(*FinesseModel) {
+	return func(m *FinesseModel) {
+		m.rand = r
+	}
+}
+
+// nextDrill chooses the Tetrimino and target placement of the next drill, and starts it.
+func (m *FinesseModel) nextDrill() error {
+	if len(m.queue) == 0 {
+		m.queue = m.randomizer.Generate(m.rand, tetris.GetValidTetriminosFor(m.rotationSystem))
+	}
+	m.spawn = m.queue[0].DeepCopy()
+	m.queue = m.queue[1:]
+
+	// Drills are played on the standard Matrix (see SingleModel.applyMatrixSettings).
+	dims := tetris.DefaultDimensions
+	var err error
+	m.matrix, err = tetris.NewMatrixWithDimensions(dims)
+	if err != nil {
+		return fmt.Errorf("creating matrix: %w", err)
+	}
+	m.spawn.Position.Y += dims.Buffer
+	m.spawn.Position.X = tetris.SpawnColumn(m.spawn.Position.X, dims.Width)
+
+	placements := tetris.FinessePlacements(m.spawn, m.matrix)
+	m.target = placements[m.rand.IntN(len(placements))].Tetrimino
+	// The target is shown where it lands.
+	for {
+		if !m.target.MoveDown(m.matrix) {
+			break
+		}
+	}
+
+	return m.startDrill()
+}
+
+// startDrill creates a new game of the current drill.
+func (m *FinesseModel) startDrill() error {
+	child, err := NewSingleModel(m.in, m.cfg, withTarget(m.target), WithRandSource(m.rand))
+	if err != nil {
+		return fmt.Errorf("creating single model for finesse drill: %w", err)
+	}
+	m.child = child
+	return nil
+}
+
+func (m *FinesseModel) Init() tea.Cmd {
+	return m.child.Init()
+}
+
+func (m *FinesseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width = msg.Width
+		m.height = msg.Height
+		_, cmd := m.child.Update(m.childSizeMsg())
+		return m, cmd
+	}
+
+	_, cmd := m.child.Update(msg)
+	if m.child.game.GetPiecesPlaced() == 0 {
+		return m, cmd
+	}
+
+	// The Tetrimino has been placed, so the drill is judged and the next one (or a retry) is started.
+	var err error
+	placement := m.child.game.GetLastPlacement()
+	finesse := m.child.game.GetLastFinesse()
+	switch {
+	case !tetris.SamePlacement(placement, m.target):
+		m.faults++
+		m.notice = "Missed the target. Try again."
+		err = m.startDrill()
+	case finesse.IsFault():
+		m.faults++
+		m.notice = fmt.Sprintf("Finesse fault: %d inputs used, %d needed%s. Try again.",
+			finesse.Inputs, finesse.Minimum, m.solution())
+		err = m.startDrill()
+	default:
+		m.passed++
+		m.notice = fmt.Sprintf("Correct in %d inputs.", finesse.Inputs)
+		err = m.nextDrill()
+	}
+	if err != nil {
+		return m, tui.FatalErrorCmd(err)
+	}
+
+	_, sizeCmd := m.child.Update(m.childSizeMsg())
+	return m, tea.Batch(cmd, m.child.Init(), sizeCmd)
+}
+
+// solution returns the fewest inputs which reach the target of the drill, for showing after a finesse fault.
+// Nothing is returned if they cannot be found.
+//
+// These are the inputs of tetris.FinessePlacements, not solver.Path, since the game counts finesse faults with the
+// same rules: holding left or right to DAS to the wall is one input, and soft drops are not used. solver.Path instead
+// counts each step of a DAS, since its inputs are played into the game one at a time, and can soft drop to tuck or spin
+// the Tetrimino, which finesse drills never need.
+func (m *FinesseModel) solution() string {
+	for _, p := range tetris.FinessePlacements(m.spawn, m.matrix) {
+		if !tetris.SamePlacement(p.Tetrimino, m.target) || len(p.Path) == 0 {
+			continue
+		}
+		inputs := make([]string, 0, len(p.Path))
+		for _, input := range p.Path {
+			inputs = append(inputs, input.String())
+		}
+		return " (" + strings.Join(inputs, ", ") + ")"
+	}
+	return ""
+}
+
+// childSizeMsg returns the size of the space left for the game by the header and notice.
+func (m *FinesseModel) childSizeMsg() tea.WindowSizeMsg {
+	return tea.WindowSizeMsg{Width: m.width, Height: max(m.height-2, 0)}
+}
+
+func (m *FinesseModel) View() string {
+	header := fmt.Sprintf("Finesse Trainer - Passed: %d  Faults: %d", m.passed, m.faults)
+	output := lipgloss.JoinVertical(lipgloss.Center,
+		lipgloss.NewStyle().Bold(true).Render(header),
+		m.notice,
+		m.child.View(),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, output)
+}
@@ -0,0 +1,64 @@
+package views
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/internal/config"
+	"github.com/Broderick-Westrope/tetrigo/internal/tui"
+)
+
+func TestFinesse_Drills(t *testing.T) {
+	m, err := NewFinesseModel(
+		tui.NewSingleInput(tui.ModeFinesse, 1, "testuser"),
+		&config.Config{
+			NextQueueLength: 5,
+			GhostEnabled:    true,
+			RotationSystem:  "SRS",
+			Theme:           config.DefaultTheme(),
+			Keys:            config.DefaultKeys(),
+		},
+		WithFinesseRandSource(rand.New(rand.NewPCG(0, 0))),
+	)
+	require.NoError(t, err)
+	hardDrop := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")}
+
+	// Target the spawn orientation two columns to the right, which takes two inputs to reach.
+	spawn := m.child.game.GetTetInPlay()
+	spawn.Position.X += 2
+	m.target = spawn
+	require.NoError(t, m.startDrill())
+
+	// Missing the target retries the drill.
+	m.Update(hardDrop)
+	assert.Equal(t, 0, m.passed)
+	assert.Equal(t, 1, m.faults)
+	assert.Same(t, spawn, m.target)
+	assert.Equal(t, 0, m.child.game.GetPiecesPlaced())
+
+	// Placing the Tetrimino on the target with extra inputs is a fault, which shows the fewest inputs.
+	for _, key := range "dddada" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+	}
+	m.Update(hardDrop)
+	assert.Equal(t, 0, m.passed)
+	assert.Equal(t, 2, m.faults)
+	assert.Contains(t, m.notice, "6 inputs used, 2 needed (right, right)")
+
+	// Placing the Tetrimino on the target with the fewest inputs moves on to the next drill.
+	for range 2 {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	}
+	m.Update(hardDrop)
+	assert.Equal(t, 1, m.passed)
+	assert.Equal(t, 2, m.faults)
+	assert.NotSame(t, spawn, m.target)
+
+	// The target of each drill is a placement of its Tetrimino.
+	assert.Equal(t, m.target.Value, m.child.game.GetTetInPlay().Value)
+	assert.Contains(t, m.View(), "Passed: 1")
+}
@@ -2,8 +2,11 @@ package views
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -11,12 +14,24 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/Broderick-Westrope/tetrigo/internal/daily"
 	"github.com/Broderick-Westrope/tetrigo/internal/data"
 	"github.com/Broderick-Westrope/tetrigo/internal/tui"
 )
 
 var _ tea.Model = &LeaderboardModel{}
 
+// dailyHistoryLength is the number of previous daily challenges shown on a daily leaderboard.
+const dailyHistoryLength = 7
+
+// leaderboardRankings maps game modes to the ranking of their leaderboard.
+// Other game modes are ranked by score, except custom game modes which are ranked by the ranking in the input.
+var leaderboardRankings = map[string]data.Ranking{
+	tui.ModeDig.String():      data.RankByFastestTime,
+	tui.ModeSurvival.String(): data.RankByLongestTime,
+	tui.ModeCombo.String():    data.RankByMaxCombo,
+}
+
 type LeaderboardModel struct {
 	keys *leaderboardKeyMap
 	help help.Model
@@ -24,6 +39,10 @@ type LeaderboardModel struct {
 	repo  *data.LeaderboardRepository
 	table table.Model
 
+	isDaily bool         // Whether the leaderboard is of a daily challenge.
+	notice  string       // A message shown above the table, such as why the new entry was not saved.
+	winners []data.Score // The winners of previous daily challenges.
+
 	width  int
 	height int
 }
@@ -31,6 +50,13 @@ type LeaderboardModel struct {
 func NewLeaderboardModel(in *tui.LeaderboardInput, db *sql.DB) (*LeaderboardModel, error) {
 	repo := data.NewLeaderboardRepository(db)
 
+	m := &LeaderboardModel{
+		keys:    defaultLeaderboardKeyMap(),
+		help:    help.New(),
+		repo:    repo,
+		isDaily: daily.IsGameMode(in.GameMode),
+	}
+
 	var err error
 	newEntryID := 0
 	if in.NewEntry != nil {
@@ -38,23 +64,46 @@ func NewLeaderboardModel(in *tui.LeaderboardInput, db *sql.DB) (*LeaderboardMode
 			in.NewEntry.Name = "Anonymous"
 		}
 
-		newEntryID, err = repo.Save(in.NewEntry)
+		// Only the first attempt of each player at a daily challenge is ranked.
+		if m.isDaily {
+			newEntryID, err = repo.SaveOnce(in.NewEntry)
+			if errors.Is(err, data.ErrAlreadySaved) {
+				m.notice = "Only your first attempt at the daily challenge is ranked."
+				err = nil
+			}
+		} else {
+			newEntryID, err = repo.Save(in.NewEntry)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("saving new entry: %w", err)
 		}
 	}
 
-	scores, err := repo.All(in.GameMode)
+	ranking, ok := leaderboardRankings[in.GameMode]
+	if !ok {
+		ranking = in.Ranking
+	}
+
+	scores, err := repo.All(in.GameMode, ranking)
 	if err != nil {
 		return nil, fmt.Errorf("fetching scores: %w", err)
 	}
 
-	return &LeaderboardModel{
-		keys:  defaultLeaderboardKeyMap(),
-		help:  help.New(),
-		repo:  repo,
-		table: buildLeaderboardTable(scores, newEntryID),
-	}, nil
+	m.table = buildLeaderboardTable(scores, newEntryID, ranking)
+
+	if m.isDaily {
+		winners, err := repo.Winners(daily.GameModePrefix, dailyHistoryLength+1)
+		if err != nil {
+			return nil, fmt.Errorf("fetching daily winners: %w", err)
+		}
+		for _, w := range winners {
+			if w.GameMode != in.GameMode && len(m.winners) < dailyHistoryLength {
+				m.winners = append(m.winners, w)
+			}
+		}
+	}
+
+	return m, nil
 }
 
 func (m *LeaderboardModel) Init() tea.Cmd {
@@ -83,11 +132,31 @@ func (m *LeaderboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *LeaderboardModel) View() string {
-	output := m.table.View() + "\n" + m.help.View(m.keys)
+	output := m.table.View()
+	if m.notice != "" {
+		output = m.notice + "\n\n" + output
+	}
+	if m.isDaily {
+		output += "\n\n" + m.dailyHistoryView()
+	}
+	output += "\n" + m.help.View(m.keys)
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, output)
 }
 
-func buildLeaderboardTable(scores []data.Score, focusID int) table.Model {
+// dailyHistoryView lists the winners of the previous daily challenges.
+func (m *LeaderboardModel) dailyHistoryView() string {
+	output := "Previous Winners:"
+	if len(m.winners) == 0 {
+		return output + "\n  None yet"
+	}
+	for _, w := range m.winners {
+		date := strings.TrimPrefix(w.GameMode, daily.GameModePrefix)
+		output += fmt.Sprintf("\n  %s  %-10s %10d", date, w.Name, w.Score)
+	}
+	return output
+}
+
+func buildLeaderboardTable(scores []data.Score, focusID int, ranking data.Ranking) table.Model {
 	cols := []table.Column{
 		{Title: "Rank", Width: 4},
 		{Title: "Name", Width: 10},
@@ -97,6 +166,19 @@ func buildLeaderboardTable(scores []data.Score, focusID int) table.Model {
 		{Title: "Level", Width: 5},
 	}
 
+	// Only show grades if the game mode awards them.
+	showGrade := slices.ContainsFunc(scores, func(s data.Score) bool {
+		return s.Grade != ""
+	})
+	if showGrade {
+		cols = append(cols, table.Column{Title: "Grade", Width: 5})
+	}
+	// Only show max combos if the game mode is ranked by them.
+	showCombo := ranking == data.RankByMaxCombo
+	if showCombo {
+		cols = append(cols, table.Column{Title: "Combo", Width: 5})
+	}
+
 	focusIndex := 0
 	rows := make([]table.Row, len(scores))
 	for i, s := range scores {
@@ -112,6 +194,12 @@ func buildLeaderboardTable(scores []data.Score, focusID int) table.Model {
 			strconv.Itoa(s.Lines),
 			strconv.Itoa(s.Level),
 		}
+		if showGrade {
+			rows[i] = append(rows[i], s.Grade)
+		}
+		if showCombo {
+			rows[i] = append(rows[i], strconv.Itoa(s.MaxCombo))
+		}
 	}
 
 	s := table.DefaultStyles()
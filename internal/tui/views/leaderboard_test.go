@@ -5,11 +5,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Broderick-Westrope/tetrigo/internal/daily"
 	"github.com/Broderick-Westrope/tetrigo/internal/data"
 	"github.com/Broderick-Westrope/tetrigo/internal/tui"
 	"github.com/Broderick-Westrope/tetrigo/internal/tui/testutils"
 	"github.com/Broderick-Westrope/x/exp/teatest"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -150,3 +152,92 @@ func TestLeaderboard_SwitchModeMsg(t *testing.T) {
 		t.Fatal("Timeout waiting for switch mode message")
 	}
 }
+
+func TestLeaderboard_Ranking(t *testing.T) {
+	tt := map[string]struct {
+		gameMode  string
+		ranking   data.Ranking
+		wantNames []string
+	}{
+		"by score": {
+			gameMode:  tui.ModeMarathon.String(),
+			wantNames: []string{"high-score", "fast", "slow"},
+		},
+		"by fastest time": {
+			gameMode:  tui.ModeDig.String(),
+			wantNames: []string{"fast", "high-score", "slow"},
+		},
+		"by longest time": {
+			gameMode:  tui.ModeSurvival.String(),
+			wantNames: []string{"slow", "high-score", "fast"},
+		},
+		"by max combo": {
+			gameMode:  tui.ModeCombo.String(),
+			wantNames: []string{"slow", "fast", "high-score"},
+		},
+		"custom mode by fastest time": {
+			gameMode:  "Sprint 20",
+			ranking:   data.RankByFastestTime,
+			wantNames: []string{"fast", "high-score", "slow"},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			db := testutils.SetupInMemoryDB(t)
+			repo := data.NewLeaderboardRepository(db)
+
+			for _, s := range []data.Score{
+				{GameMode: tc.gameMode, Name: "slow", Time: 3 * time.Minute, Score: 100, MaxCombo: 8},
+				{GameMode: tc.gameMode, Name: "fast", Time: time.Minute, Score: 200, MaxCombo: 5},
+				{GameMode: tc.gameMode, Name: "high-score", Time: 2 * time.Minute, Score: 300, MaxCombo: 2},
+			} {
+				_, err := repo.Save(&s)
+				require.NoError(t, err)
+			}
+
+			m, err := NewLeaderboardModel(tui.NewLeaderboardInput(tc.gameMode, tui.WithRanking(tc.ranking)), db)
+			require.NoError(t, err)
+
+			var names []string
+			for _, row := range m.table.Rows() {
+				names = append(names, row[1])
+			}
+			require.Equal(t, tc.wantNames, names)
+		})
+	}
+}
+
+func TestLeaderboard_Daily(t *testing.T) {
+	db := testutils.SetupInMemoryDB(t)
+	repo := data.NewLeaderboardRepository(db)
+
+	today := daily.ForDate(time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC)).GameMode()
+	yesterday := daily.ForDate(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)).GameMode()
+	for _, s := range []data.Score{
+		{GameMode: yesterday, Name: "winner", Score: 300},
+		{GameMode: yesterday, Name: "runner-up", Score: 200},
+	} {
+		_, err := repo.Save(&s)
+		require.NoError(t, err)
+	}
+
+	// The first attempt is ranked.
+	m, err := NewLeaderboardModel(tui.NewLeaderboardInput(today,
+		tui.WithNewEntry(&data.Score{GameMode: today, Name: "player", Score: 100}),
+	), db)
+	require.NoError(t, err)
+	assert.Empty(t, m.notice)
+	assert.Len(t, m.table.Rows(), 1)
+	require.Len(t, m.winners, 1)
+	assert.Equal(t, "winner", m.winners[0].Name)
+
+	// Later attempts are not.
+	m, err = NewLeaderboardModel(tui.NewLeaderboardInput(today,
+		tui.WithNewEntry(&data.Score{GameMode: today, Name: "player", Score: 500}),
+	), db)
+	require.NoError(t, err)
+	assert.NotEmpty(t, m.notice)
+	require.Len(t, m.table.Rows(), 1)
+	assert.Equal(t, "100", m.table.Rows()[0][3])
+}
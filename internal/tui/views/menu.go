@@ -3,6 +3,8 @@ package views
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/Broderick-Westrope/charmutils"
 	"github.com/charmbracelet/bubbles/key"
@@ -10,6 +12,7 @@ import (
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/Broderick-Westrope/tetrigo/internal/config"
 	"github.com/Broderick-Westrope/tetrigo/internal/tui"
 )
 
@@ -30,6 +33,8 @@ type MenuModel struct {
 	hasAnnouncedCompletion bool
 	keys                   *menuKeyMap
 	formData               *MenuFormData
+	customModes            []string
+	puzzlePacks            []string
 
 	width  int
 	height int
@@ -37,39 +42,90 @@ type MenuModel struct {
 
 type MenuFormData struct {
 	Username string
-	GameMode tui.Mode
+	GameMode MenuGameMode
 	Level    int
 }
 
-func NewMenuModel(_ *tui.MenuInput) *MenuModel {
+// MenuGameMode is a game mode which can be selected in the menu.
+type MenuGameMode struct {
+	Mode       tui.Mode
+	CustomMode string // The name of the custom game mode, when Mode is tui.ModeCustom.
+	PuzzlePack string // The path of the pack of puzzles, when Mode is tui.ModePuzzle.
+}
+
+func NewMenuModel(_ *tui.MenuInput, opts ...func(*MenuModel)) *MenuModel {
 	formData := new(MenuFormData)
 	keys := defaultMenuKeyMap()
 
-	return &MenuModel{
+	m := &MenuModel{
 		formData: formData,
-		form: huh.NewForm(
-			huh.NewGroup(
-				huh.NewInput().Value(&formData.Username).
-					Title("Username:").CharLimit(100).
-					Validate(func(s string) error {
-						if len(s) == 0 {
-							return errors.New("empty username not allowed")
-						}
-						return nil
-					}),
-				huh.NewSelect[tui.Mode]().Value(&formData.GameMode).
-					Title("Game Mode:").
-					Options(
-						huh.NewOption("Marathon", tui.ModeMarathon),
-						huh.NewOption("Sprint (40 Lines)", tui.ModeSprint),
-						huh.NewOption("Ultra (Time Trial)", tui.ModeUltra),
-					),
-				huh.NewSelect[int]().Value(&formData.Level).
-					Title("Starting Level:").
-					Options(charmutils.HuhIntRangeOptions(1, 15)...),
-			),
-		).WithKeyMap(keys.formKeys),
-		keys: keys,
+		keys:     keys,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	gameModeOptions := []huh.Option[MenuGameMode]{
+		huh.NewOption("Marathon", MenuGameMode{Mode: tui.ModeMarathon}),
+		huh.NewOption("Sprint (40 Lines)", MenuGameMode{Mode: tui.ModeSprint}),
+		huh.NewOption("Ultra (Time Trial)", MenuGameMode{Mode: tui.ModeUltra}),
+		huh.NewOption("Master (20G)", MenuGameMode{Mode: tui.ModeMaster}),
+		huh.NewOption("Dig (Clear Garbage)", MenuGameMode{Mode: tui.ModeDig}),
+		huh.NewOption("Survival (Rising Garbage)", MenuGameMode{Mode: tui.ModeSurvival}),
+		huh.NewOption("Combo (4-Wide)", MenuGameMode{Mode: tui.ModeCombo}),
+		huh.NewOption("Daily (Challenge)", MenuGameMode{Mode: tui.ModeDaily}),
+		huh.NewOption("Finesse (Trainer)", MenuGameMode{Mode: tui.ModeFinesse}),
+		huh.NewOption("Perfect Clear (Practice)", MenuGameMode{Mode: tui.ModePerfectClear}),
+		huh.NewOption("Versus (vs Solver)", MenuGameMode{Mode: tui.ModeVersus}),
+	}
+	for _, name := range m.customModes {
+		gameModeOptions = append(gameModeOptions,
+			huh.NewOption(name+" (Custom)", MenuGameMode{Mode: tui.ModeCustom, CustomMode: name}),
+		)
+	}
+	for _, path := range m.puzzlePacks {
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		gameModeOptions = append(gameModeOptions,
+			huh.NewOption("Puzzle: "+name, MenuGameMode{Mode: tui.ModePuzzle, PuzzlePack: path}),
+		)
+	}
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().Value(&formData.Username).
+				Title("Username:").CharLimit(100).
+				Validate(func(s string) error {
+					if len(s) == 0 {
+						return errors.New("empty username not allowed")
+					}
+					return nil
+				}),
+			huh.NewSelect[MenuGameMode]().Value(&formData.GameMode).
+				Title("Game Mode:").
+				Options(gameModeOptions...),
+			huh.NewSelect[int]().Value(&formData.Level).
+				Title("Starting Level:").
+				Options(charmutils.HuhIntRangeOptions(1, 15)...),
+		),
+	).WithKeyMap(keys.formKeys)
+
+	return m
+}
+
+// WithCustomModes adds the given custom game modes to the game mode options.
+func WithCustomModes(modes []config.CustomMode) func(*MenuModel) {
+	return func(m *MenuModel) {
+		for _, cm := range modes {
+			m.customModes = append(m.customModes, cm.Name)
+		}
+	}
+}
+
+// WithPuzzlePacks adds the puzzle packs at the given paths to the game mode options.
+func WithPuzzlePacks(paths []string) func(*MenuModel) {
+	return func(m *MenuModel) {
+		m.puzzlePacks = append(m.puzzlePacks, paths...)
 	}
 }
 
@@ -110,15 +166,29 @@ func (m *MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m *MenuModel) announceCompletion() tea.Cmd {
 	m.hasAnnouncedCompletion = true
 
-	switch m.formData.GameMode {
-	case tui.ModeMarathon, tui.ModeSprint, tui.ModeUltra:
-		in := tui.NewSingleInput(m.formData.GameMode, m.formData.Level, m.formData.Username)
-		return tui.SwitchModeCmd(m.formData.GameMode, in)
-
-	case tui.ModeMenu, tui.ModeLeaderboard:
+	mode := m.formData.GameMode.Mode
+	switch mode {
+	case tui.ModeMarathon, tui.ModeSprint, tui.ModeUltra, tui.ModeMaster, tui.ModeDig, tui.ModeSurvival,
+		tui.ModeCombo, tui.ModeDaily, tui.ModeFinesse, tui.ModePerfectClear, tui.ModeVersus:
+		in := tui.NewSingleInput(mode, m.formData.Level, m.formData.Username)
+		return tui.SwitchModeCmd(mode, in)
+
+	case tui.ModeCustom:
+		in := tui.NewSingleInput(mode, m.formData.Level, m.formData.Username,
+			tui.WithCustomMode(m.formData.GameMode.CustomMode),
+		)
+		return tui.SwitchModeCmd(mode, in)
+
+	case tui.ModePuzzle:
+		in := tui.NewSingleInput(mode, m.formData.Level, m.formData.Username,
+			tui.WithPuzzlePack(m.formData.GameMode.PuzzlePack),
+		)
+		return tui.SwitchModeCmd(mode, in)
+
+	case tui.ModeMenu, tui.ModeLeaderboard, tui.ModeReview:
 		fallthrough
 	default:
-		return tui.FatalErrorCmd(fmt.Errorf("invalid mode for starting game %q", m.formData.GameMode))
+		return tui.FatalErrorCmd(fmt.Errorf("invalid mode for starting game %q", mode))
 	}
 }
 
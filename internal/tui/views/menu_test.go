@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Broderick-Westrope/tetrigo/internal/config"
 	"github.com/Broderick-Westrope/tetrigo/internal/tui"
 	"github.com/Broderick-Westrope/tetrigo/internal/tui/testutils"
 	"github.com/Broderick-Westrope/x/exp/teatest"
@@ -138,3 +139,42 @@ func TestMenu_SwitchModeMsg(t *testing.T) {
 		})
 	}
 }
+
+func TestMenu_CustomModeSwitchModeMsg(t *testing.T) {
+	m := NewMenuModel(&tui.MenuInput{}, WithCustomModes([]config.CustomMode{{Name: "Sprint 20"}}))
+	tm := teatest.NewTestModel(t, m)
+
+	switchModeMsgCh := make(chan tui.SwitchModeMsg, 1)
+	go testutils.WaitForMsgOfType(t, tm, switchModeMsgCh, time.Second)
+
+	// Input username
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("testuser")})
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	time.Sleep(10 * time.Millisecond)
+
+	// Select the custom game mode, which is after the built-in game modes
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnd})
+	time.Sleep(10 * time.Millisecond)
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	time.Sleep(10 * time.Millisecond)
+
+	// Select level
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	time.Sleep(10 * time.Millisecond)
+
+	// Wait for switch mode message with timeout
+	select {
+	case switchModeMsg := <-switchModeMsgCh:
+		require.Equal(t, tui.ModeCustom, switchModeMsg.Target)
+
+		singleInput, ok := switchModeMsg.Input.(*tui.SingleInput)
+		require.True(t, ok, "Expected %T, got %T", &tui.SingleInput{}, switchModeMsg.Input)
+
+		assert.Equal(t, tui.ModeCustom, singleInput.Mode)
+		assert.Equal(t, "Sprint 20", singleInput.CustomMode)
+		assert.Equal(t, 1, singleInput.Level)
+
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for switch mode message")
+	}
+}
@@ -0,0 +1,334 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Broderick-Westrope/tetrigo/internal/config"
+	"github.com/Broderick-Westrope/tetrigo/internal/tui"
+	"github.com/Broderick-Westrope/tetrigo/internal/tui/components"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+const (
+	// perfectClearHeight is the most rows the stack may reach in perfect clear practice.
+	perfectClearHeight = 4
+	// perfectClearHintTimeout is how long a hint may search for a perfect clear.
+	perfectClearHintTimeout = 3 * time.Second
+	// perfectClearHintWidth is the width of the space beside the game for the hint.
+	perfectClearHintWidth = 26
+)
+
+// perfectClearSetup is a matrix a round of perfect clear practice can start from.
+type perfectClearSetup struct {
+	name string
+	rows []string // The rows at the bottom of the matrix, where '.' is an empty cell.
+}
+
+var perfectClearSetups = []perfectClearSetup{
+	{name: "Empty"},
+	// The residue of a perfect clear opener, which leaves a 4x4 box to fill with the rest of the bag and the next.
+	{name: "Opener", rows: []string{
+		"SIIIIZ....",
+		"SSOOZZ....",
+		"JSOOZL....",
+		"JJJLLL....",
+	}},
+}
+
+var _ tea.Model = &PerfectClearModel{}
+
+// PerfectClearModel practices perfect clears in rounds. Each round starts from a random setup and ends when the
+// matrix is perfect cleared, or when a perfect clear is no longer possible within the bottom 4 rows.
+// A hint can be asked for, which searches for a perfect clear with the Tetriminos in play, held and in the Next Queue.
+type PerfectClearModel struct {
+	child *SingleModel
+	in    *tui.SingleInput
+	cfg   *config.Config
+	keys  *components.GameKeyMap
+
+	rand     *rand.Rand
+	setups   []perfectClearSetup
+	setup    int    // The index of the setup of the current round.
+	round    int    // The number of rounds started.
+	played   int    // The number of rounds ended.
+	cleared  int    // The number of rounds perfect cleared.
+	finished bool   // Whether the current round has ended.
+	notice   string // The outcome of the current round.
+
+	hinting bool              // Whether a hint is being searched for.
+	hint    *perfectClearHint // The hint for the current position, or nil.
+
+	width  int
+	height int
+}
+
+// perfectClearHint is the result of searching for a perfect clear.
+type perfectClearHint struct {
+	round  int  // The round the hint was asked for in.
+	pieces int  // The number of Tetriminos placed when the hint was asked for.
+	hold   byte // The value of the held Tetrimino when the hint was asked for.
+
+	matrix    tetris.Matrix // The matrix searched from.
+	overlay   tetris.Matrix // The matrix with the solution overlaid.
+	holdFirst bool          // Whether the solution starts by holding.
+	err       error
+}
+
+func NewPerfectClearModel(in *tui.SingleInput, cfg *config.Config) (*PerfectClearModel, error) {
+	m := &PerfectClearModel{
+		in:     in,
+		cfg:    cfg,
+		keys:   components.ConstructGameKeyMap(cfg.Keys),
+		setups: perfectClearSetups,
+		//nolint:gosec // This random source is not for any security-related tasks.
+		rand: rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+	if in.Seed != 0 {
+		//nolint:gosec // This random source is not for any security-related tasks.
+		m.rand = rand.New(rand.NewPCG(in.Seed, in.Seed))
+	}
+
+	err := m.nextRound()
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// nextRound chooses a random setup and starts a new game from it.
+func (m *PerfectClearModel) nextRound() error {
+	m.setup = m.rand.IntN(len(m.setups))
+	matrix, err := m.setups[m.setup].matrix()
+	if err != nil {
+		return fmt.Errorf("creating matrix of setup %q: %w", m.setups[m.setup].name, err)
+	}
+
+	child, err := NewSingleModel(m.in, m.cfg, withSetup(matrix), WithRandSource(m.rand))
+	if err != nil {
+		return fmt.Errorf("creating single model for perfect clear practice: %w", err)
+	}
+	m.child = child
+	m.round++
+	m.finished = false
+	m.notice = ""
+	m.hinting = false
+	m.hint = nil
+	return nil
+}
+
+// matrix returns a default matrix with the rows of the setup at the bottom.
+func (s perfectClearSetup) matrix() (tetris.Matrix, error) {
+	matrix := tetris.DefaultMatrix()
+	offset := len(matrix) - len(s.rows)
+	for i, row := range s.rows {
+		if len(row) != len(matrix[0]) {
+			return nil, fmt.Errorf("row %d has %d cells, but must have %d", i+1, len(row), len(matrix[0]))
+		}
+		for col := range len(row) {
+			if row[col] != '.' {
+				matrix[offset+i][col] = row[col]
+			}
+		}
+	}
+	return matrix, nil
+}
+
+func (m *PerfectClearModel) Init() tea.Cmd {
+	return m.child.Init()
+}
+
+func (m *PerfectClearModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		_, cmd := m.child.Update(m.childSizeMsg())
+		return m, cmd
+
+	case perfectClearHint:
+		if msg.round != m.round {
+			return m, nil
+		}
+		m.hinting = false
+		if msg.isStale(m.child.game) {
+			return m, nil
+		}
+		if !isExpectedHintErr(msg.err) {
+			return m, tui.FatalErrorCmd(fmt.Errorf("searching for perfect clear: %w", msg.err))
+		}
+		m.hint = &msg
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.finished {
+			return m.finishedKeyMsgUpdate(msg)
+		}
+		if key.Matches(msg, m.keys.Hint) && !m.child.isPaused {
+			return m, m.requestHint()
+		}
+	}
+
+	_, cmd := m.child.Update(msg)
+	if m.finished {
+		return m, cmd
+	}
+
+	game := m.child.game
+	if m.hint != nil && m.hint.isStale(game) {
+		m.hint = nil
+	}
+
+	switch {
+	case game.GetPerfectClears() > 0:
+		m.cleared++
+		m.notice = "Perfect clear! Press HOLD for the next round or EXIT to return to the menu."
+	case game.IsGameOver() || !solver.CanPerfectClear(game.GetMatrix(), perfectClearHeight-game.GetLinesCleared()):
+		m.notice = "A perfect clear is no longer possible. " +
+			"Press HOLD for the next round or EXIT to return to the menu."
+	default:
+		return m, cmd
+	}
+	m.played++
+	m.finished = true
+	m.hint = nil
+	return m, tea.Batch(cmd, m.child.triggerGameOver())
+}
+
+// finishedKeyMsgUpdate handles key presses once the round has ended.
+// Hold starts the next round, and Exit returns to the menu.
+func (m *PerfectClearModel) finishedKeyMsgUpdate(msg tea.KeyMsg) (*PerfectClearModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Exit):
+		return m, tui.SwitchModeCmd(tui.ModeMenu, tui.NewMenuInput())
+
+	case key.Matches(msg, m.keys.Hold):
+		err := m.nextRound()
+		if err != nil {
+			return m, tui.FatalErrorCmd(err)
+		}
+		_, cmd := m.child.Update(m.childSizeMsg())
+		return m, tea.Batch(m.child.Init(), cmd)
+	}
+	return m, nil
+}
+
+// requestHint returns a command which searches for a perfect clear from the current position.
+// The position is taken now, since the game keeps changing while the search runs.
+func (m *PerfectClearModel) requestHint() tea.Cmd {
+	if m.hinting {
+		return nil
+	}
+	m.hinting = true
+
+	game := m.child.game
+	pos := solver.NewPosition(game)
+	hint := perfectClearHint{
+		round:  m.round,
+		pieces: game.GetPiecesPlaced(),
+		hold:   game.GetHoldTetrimino().Value,
+		matrix: pos.Matrix,
+	}
+	height := perfectClearHeight - game.GetLinesCleared()
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), perfectClearHintTimeout)
+		defer cancel()
+
+		moves, err := solver.FindPerfectClear(ctx, pos, solver.WithMaxHeight(height))
+		if err != nil {
+			hint.err = err
+			return hint
+		}
+		hint.overlay, hint.err = solver.Overlay(pos.Matrix, moves)
+		hint.holdFirst = len(moves) > 0 && moves[0].Hold
+		return hint
+	}
+}
+
+// isStale returns true if a Tetrimino has been placed or held in the game since the hint was asked for.
+func (h *perfectClearHint) isStale(game *single.Game) bool {
+	return h.pieces != game.GetPiecesPlaced() || h.hold != game.GetHoldTetrimino().Value
+}
+
+// isExpectedHintErr returns true if the error is nil or a reason a hint has no solution.
+func isExpectedHintErr(err error) bool {
+	return err == nil ||
+		errors.Is(err, solver.ErrNoPerfectClear) ||
+		errors.Is(err, solver.ErrNotEnoughTetriminos) ||
+		errors.Is(err, solver.ErrSearchLimit) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
+
+// childSizeMsg returns the size of the space left for the game by the header, notice and hint.
+func (m *PerfectClearModel) childSizeMsg() tea.WindowSizeMsg {
+	return tea.WindowSizeMsg{Width: max(m.width-perfectClearHintWidth, 0), Height: max(m.height-2, 0)}
+}
+
+func (m *PerfectClearModel) View() string {
+	header := fmt.Sprintf("Perfect Clear Practice (%s) - Cleared: %d/%d", m.setups[m.setup].name, m.cleared, m.played)
+
+	output := lipgloss.JoinVertical(lipgloss.Center,
+		lipgloss.NewStyle().Bold(true).Render(header),
+		m.notice,
+		lipgloss.JoinHorizontal(lipgloss.Center,
+			m.child.View(),
+			lipgloss.NewStyle().Width(perfectClearHintWidth).Render(m.hintView()),
+		),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, output)
+}
+
+// hintView returns the hint for the current position. A solution is shown as the bottom rows of the matrix, with the
+// existing stack as ghost cells and the Tetriminos to place in their colours.
+func (m *PerfectClearModel) hintView() string {
+	switch {
+	case m.finished:
+		return ""
+	case m.hinting:
+		return "Searching..."
+	case m.hint == nil:
+		return fmt.Sprintf("Press %s for a hint.", m.keys.Hint.Help().Key)
+	case errors.Is(m.hint.err, solver.ErrNoPerfectClear):
+		return "No perfect clear is possible."
+	case errors.Is(m.hint.err, solver.ErrNotEnoughTetriminos):
+		return "Too few Tetriminos are known to find a perfect clear yet."
+	case m.hint.err != nil:
+		return "No perfect clear was found in time."
+	}
+
+	top := len(m.hint.overlay) - perfectClearHeight
+	lines := make([]string, 0, perfectClearHeight)
+	for row := top; row < len(m.hint.overlay); row++ {
+		var line string
+		for col, cell := range m.hint.overlay[row] {
+			if m.hint.matrix[row][col] != 0 {
+				cell = 'G'
+			}
+			line += m.child.renderCell(cell)
+		}
+		lines = append(lines, line)
+	}
+
+	title := "A perfect clear is possible:"
+	if m.hint.holdFirst {
+		title = "A perfect clear is possible (hold first):"
+	}
+	return lipgloss.JoinVertical(lipgloss.Center,
+		title,
+		m.child.styles.Playfield.Render(strings.Join(lines, "\n")),
+	)
+}
@@ -0,0 +1,124 @@
+package views
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/internal/config"
+	"github.com/Broderick-Westrope/tetrigo/internal/tui"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+func newTestPerfectClearModel(t *testing.T) *PerfectClearModel {
+	m, err := NewPerfectClearModel(
+		tui.NewSingleInput(tui.ModePerfectClear, 1, "testuser"),
+		&config.Config{
+			NextQueueLength: 5,
+			GhostEnabled:    true,
+			RotationSystem:  "SRS",
+			Theme:           config.DefaultTheme(),
+			Keys:            config.DefaultKeys(),
+		},
+	)
+	require.NoError(t, err)
+	return m
+}
+
+// startTestRound starts a round from the given setup, with a random source seeded by the given seed.
+func startTestRound(t *testing.T, m *PerfectClearModel, seed uint64, setup perfectClearSetup) {
+	m.setups = []perfectClearSetup{setup}
+	m.rand = rand.New(rand.NewPCG(seed, seed))
+	require.NoError(t, m.nextRound())
+}
+
+func TestPerfectClear_Rounds(t *testing.T) {
+	m := newTestPerfectClearModel(t)
+	press := func(keys ...string) {
+		for _, k := range keys {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		}
+	}
+
+	// Find a seed which starts with an O Tetrimino, and leave a gap under it which it perfect clears.
+	var seed uint64
+	for ; seed < 100; seed++ {
+		startTestRound(t, m, seed, perfectClearSetup{name: "Test"})
+		if m.child.game.GetTetInPlay().Value == 'O' {
+			break
+		}
+	}
+	o := m.child.game.GetTetInPlay()
+	require.Equal(t, byte('O'), o.Value)
+	row := []byte("XXXXXXXXXX")
+	row[o.Position.X], row[o.Position.X+1] = '.', '.'
+
+	// Hard dropping the Tetrimino perfect clears the matrix.
+	startTestRound(t, m, seed, perfectClearSetup{name: "Test", rows: []string{string(row), string(row)}})
+	press("w")
+	assert.True(t, m.finished)
+	assert.Equal(t, 1, m.cleared)
+	assert.Equal(t, 1, m.played)
+	assert.Contains(t, m.View(), "Perfect clear!")
+
+	// Hold starts the next round.
+	press("enter")
+	assert.False(t, m.finished)
+	assert.Equal(t, 0, m.child.game.GetPiecesPlaced())
+
+	// Placing a Tetrimino above the bottom 4 rows fails the round.
+	startTestRound(t, m, 0, perfectClearSetup{name: "Test", rows: []string{
+		"XXXXXXXX..",
+		"XXXXXXXX..",
+		"XXXXXXXX..",
+		"XXXXXXXX..",
+	}})
+	press("w")
+	assert.True(t, m.finished)
+	assert.Equal(t, 1, m.cleared)
+	assert.Equal(t, 2, m.played)
+	assert.Contains(t, m.View(), "no longer possible")
+}
+
+func TestPerfectClear_Hint(t *testing.T) {
+	m := newTestPerfectClearModel(t)
+
+	// Only an I Tetrimino can fill the well, so a perfect clear is possible if the I is in play or can be held for.
+	startTestRound(t, m, 0, perfectClearSetup{name: "Test", rows: []string{
+		"XXXXXXXXX.",
+		"XXXXXXXXX.",
+		"XXXXXXXXX.",
+		"XXXXXXXXX.",
+	}})
+	game := m.child.game
+	possible := game.GetTetInPlay().Value == 'I' || game.GetBagTetriminos()[0].Value == 'I'
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	require.NotNil(t, cmd)
+	assert.True(t, m.hinting)
+	assert.Contains(t, m.View(), "Searching...")
+
+	msg := cmd()
+	hint, ok := msg.(perfectClearHint)
+	require.True(t, ok)
+	m.Update(msg)
+	assert.False(t, m.hinting)
+	require.NotNil(t, m.hint)
+
+	if possible {
+		require.NoError(t, hint.err)
+		assert.Equal(t, game.GetTetInPlay().Value != 'I', hint.holdFirst)
+		assert.Contains(t, m.View(), "A perfect clear")
+	} else {
+		assert.True(t, errors.Is(hint.err, solver.ErrNoPerfectClear))
+		assert.Contains(t, m.View(), "No perfect clear")
+	}
+
+	// The hint is removed once a Tetrimino is placed.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	assert.Nil(t, m.hint)
+}
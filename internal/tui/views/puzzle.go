@@ -0,0 +1,175 @@
+package views
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Broderick-Westrope/tetrigo/internal/config"
+	"github.com/Broderick-Westrope/tetrigo/internal/data"
+	"github.com/Broderick-Westrope/tetrigo/internal/puzzle"
+	"github.com/Broderick-Westrope/tetrigo/internal/tui"
+	"github.com/Broderick-Westrope/tetrigo/internal/tui/components"
+)
+
+var _ tea.Model = &PuzzleModel{}
+
+// PuzzleModel plays the puzzles of a pack in order. Each puzzle is played as a single player game, which is
+// checked against the goal of the puzzle after every update. The number of puzzles solved is saved per player.
+type PuzzleModel struct {
+	child *SingleModel
+	in    *tui.SingleInput
+	cfg   *config.Config
+	keys  *components.GameKeyMap
+
+	repo   *data.PuzzleRepository
+	pack   *puzzle.Pack
+	index  int           // The index of the puzzle being played.
+	result puzzle.Result // The result of the puzzle being played.
+
+	width  int
+	height int
+}
+
+func NewPuzzleModel(in *tui.SingleInput, cfg *config.Config, db *sql.DB) (*PuzzleModel, error) {
+	pack, err := puzzle.LoadPack(in.PuzzlePack)
+	if err != nil {
+		return nil, fmt.Errorf("loading puzzle pack: %w", err)
+	}
+
+	m := &PuzzleModel{
+		in:   in,
+		cfg:  cfg,
+		keys: components.ConstructGameKeyMap(cfg.Keys),
+		repo: data.NewPuzzleRepository(db),
+		pack: pack,
+	}
+
+	// Continue from the first unsolved puzzle, or start again if they are all solved.
+	m.index, err = m.repo.Progress(pack.Name, in.Username)
+	if err != nil {
+		return nil, fmt.Errorf("getting puzzle progress: %w", err)
+	}
+	if m.index >= len(pack.Puzzles) {
+		m.index = 0
+	}
+
+	err = m.startPuzzle()
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// startPuzzle creates a new game of the puzzle at the current index.
+func (m *PuzzleModel) startPuzzle() error {
+	child, err := NewSingleModel(m.in, m.cfg, withPuzzle(&m.pack.Puzzles[m.index]))
+	if err != nil {
+		return fmt.Errorf("creating single model for puzzle %d: %w", m.index+1, err)
+	}
+	m.child = child
+	m.result = puzzle.Pending
+	return nil
+}
+
+func (m *PuzzleModel) Init() tea.Cmd {
+	return m.child.Init()
+}
+
+func (m *PuzzleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		_, cmd := m.child.Update(m.childSizeMsg())
+		return m, cmd
+
+	case tea.KeyMsg:
+		if m.result != puzzle.Pending {
+			return m.finishedKeyMsgUpdate(msg)
+		}
+	}
+
+	var cmd tea.Cmd
+	var cmds []tea.Cmd
+	_, cmd = m.child.Update(msg)
+	cmds = append(cmds, cmd)
+
+	if m.result != puzzle.Pending {
+		return m, tea.Batch(cmds...)
+	}
+
+	m.result = m.pack.Puzzles[m.index].Check(m.child.game)
+	switch m.result {
+	case puzzle.Solved:
+		err := m.repo.SaveProgress(m.pack.Name, m.in.Username, m.index+1)
+		if err != nil {
+			return m, tui.FatalErrorCmd(fmt.Errorf("saving puzzle progress: %w", err))
+		}
+		cmds = append(cmds, m.child.triggerGameOver())
+	case puzzle.Failed:
+		cmds = append(cmds, m.child.triggerGameOver())
+	case puzzle.Pending:
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// finishedKeyMsgUpdate handles key presses once the puzzle is solved or failed.
+// Hold moves on to the next puzzle (or retries a failed puzzle), and Exit returns to the menu.
+func (m *PuzzleModel) finishedKeyMsgUpdate(msg tea.KeyMsg) (*PuzzleModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Exit):
+		return m, tui.SwitchModeCmd(tui.ModeMenu, tui.NewMenuInput())
+
+	case key.Matches(msg, m.keys.Hold):
+		if m.result == puzzle.Solved {
+			if m.index == len(m.pack.Puzzles)-1 {
+				return m, tui.SwitchModeCmd(tui.ModeMenu, tui.NewMenuInput())
+			}
+			m.index++
+		}
+		err := m.startPuzzle()
+		if err != nil {
+			return m, tui.FatalErrorCmd(err)
+		}
+		_, cmd := m.child.Update(m.childSizeMsg())
+		return m, tea.Batch(m.child.Init(), cmd)
+	}
+	return m, nil
+}
+
+// childSizeMsg returns the size of the space left for the game by the header and notice.
+func (m *PuzzleModel) childSizeMsg() tea.WindowSizeMsg {
+	return tea.WindowSizeMsg{Width: m.width, Height: max(m.height-2, 0)}
+}
+
+func (m *PuzzleModel) View() string {
+	p := m.pack.Puzzles[m.index]
+	header := fmt.Sprintf("%s (%d/%d): %s", m.pack.Name, m.index+1, len(m.pack.Puzzles), p.Description())
+
+	var notice string
+	switch m.result {
+	case puzzle.Solved:
+		if m.index == len(m.pack.Puzzles)-1 {
+			notice = "Pack complete! Press HOLD or EXIT to return to the menu."
+		} else {
+			notice = "Solved! Press HOLD for the next puzzle or EXIT to return to the menu."
+		}
+	case puzzle.Failed:
+		notice = "Failed. Press HOLD to retry or EXIT to return to the menu."
+	case puzzle.Pending:
+	}
+
+	output := lipgloss.JoinVertical(lipgloss.Center,
+		lipgloss.NewStyle().Bold(true).Render(header),
+		notice,
+		m.child.View(),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, output)
+}
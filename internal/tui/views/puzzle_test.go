@@ -0,0 +1,89 @@
+package views
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/internal/config"
+	"github.com/Broderick-Westrope/tetrigo/internal/data"
+	"github.com/Broderick-Westrope/tetrigo/internal/puzzle"
+	"github.com/Broderick-Westrope/tetrigo/internal/tui"
+	"github.com/Broderick-Westrope/tetrigo/internal/tui/testutils"
+)
+
+const testPuzzlePack = `
+name = "Test Pack"
+
+[[puzzles]]
+name = "First"
+matrix = """
+XXXXXXXX..
+XXXXXXXX..
+"""
+sequence = "O"
+goal = "perfect_clear"
+
+[[puzzles]]
+name = "Second"
+matrix = "XXXXXXXX.."
+sequence = "OO"
+goal = "lines"
+lines = 1
+pieces = 1
+`
+
+func TestPuzzle_Progress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pack.toml")
+	require.NoError(t, os.WriteFile(path, []byte(testPuzzlePack), 0o600))
+
+	db := testutils.SetupInMemoryDB(t)
+	cfg := &config.Config{
+		NextQueueLength: 5,
+		GhostEnabled:    true,
+		Theme:           config.DefaultTheme(),
+		Keys:            config.DefaultKeys(),
+	}
+	newModel := func(t *testing.T) *PuzzleModel {
+		m, err := NewPuzzleModel(tui.NewSingleInput(tui.ModePuzzle, 1, "testuser", tui.WithPuzzlePack(path)), cfg, db)
+		require.NoError(t, err)
+		return m
+	}
+	press := func(m *PuzzleModel, keys ...string) {
+		for _, k := range keys {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		}
+	}
+
+	// Solving the first puzzle saves the progress and moves on to the next puzzle.
+	m := newModel(t)
+	assert.Equal(t, 0, m.index)
+	press(m, "d", "d", "d", "d", "w")
+	assert.Equal(t, puzzle.Solved, m.result)
+	press(m, "enter")
+	assert.Equal(t, 1, m.index)
+	assert.Equal(t, puzzle.Pending, m.result)
+
+	// The progress is kept when the pack is played again.
+	m = newModel(t)
+	assert.Equal(t, 1, m.index)
+
+	// Failing a puzzle retries it.
+	press(m, "w")
+	assert.Equal(t, puzzle.Failed, m.result)
+	press(m, "enter")
+	assert.Equal(t, 1, m.index)
+	assert.Equal(t, puzzle.Pending, m.result)
+
+	// Solving every puzzle starts the pack again next time.
+	press(m, "d", "d", "d", "d", "w")
+	assert.Equal(t, puzzle.Solved, m.result)
+	solved, err := data.NewPuzzleRepository(db).Progress("Test Pack", "testuser")
+	require.NoError(t, err)
+	assert.Equal(t, 2, solved)
+	assert.Equal(t, 0, newModel(t).index)
+}
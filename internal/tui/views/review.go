@@ -0,0 +1,244 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Broderick-Westrope/tetrigo/internal/config"
+	"github.com/Broderick-Westrope/tetrigo/internal/tui"
+	"github.com/Broderick-Westrope/tetrigo/internal/tui/components"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/analysis"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+// reviewListHeight is the number of placements shown in the list at once.
+const reviewListHeight = 20
+
+var _ tea.Model = &ReviewModel{}
+
+// ReviewModel reviews the placements of a finished game. Each placement is compared with the one the solver chooses
+// from the same position, and those which scored much worse are marked as blunders. The selected placement and the
+// solver's are shown side by side.
+type ReviewModel struct {
+	in     *tui.ReviewInput
+	styles *components.GameStyles
+	keys   *reviewKeyMap
+	help   help.Model
+
+	reviews  []analysis.Review  // The reviews of the placements, or nil while they are analysed.
+	blunders int                // The number of placements which are blunders.
+	selected int                // The index of the selected placement.
+	cancel   context.CancelFunc // Stops the analysis, if it is running.
+	weights  eval.Weights       // The weights of the evaluator the placements are analysed with.
+
+	width  int
+	height int
+}
+
+// reviewMsg is the result of analysing the placements of the game.
+type reviewMsg struct {
+	reviews []analysis.Review
+	err     error
+}
+
+func NewReviewModel(in *tui.ReviewInput, cfg *config.Config) (*ReviewModel, error) {
+	weights, err := cfg.GetSolverWeights()
+	if err != nil {
+		return nil, fmt.Errorf("getting solver weights: %w", err)
+	}
+	return &ReviewModel{
+		in:      in,
+		styles:  components.CreateGameStyles(cfg.Theme),
+		keys:    defaultReviewKeyMap(),
+		help:    help.New(),
+		weights: weights,
+	}, nil
+}
+
+// Init starts analysing the placements of the game, which is done in the background until it finishes or the review
+// is exited.
+func (m *ReviewModel) Init() tea.Cmd {
+	var ctx context.Context
+	ctx, m.cancel = context.WithCancel(context.Background())
+	turns := m.in.Turns
+	weights := m.weights
+	return func() tea.Msg {
+		reviews, err := analysis.Analyze(ctx, &analysis.Input{
+			Turns:     turns,
+			Evaluator: weights,
+			Workers:   runtime.NumCPU(),
+		})
+		return reviewMsg{reviews: reviews, err: err}
+	}
+}
+
+func (m *ReviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case reviewMsg:
+		m.stopAnalysis()
+		if errors.Is(msg.err, context.Canceled) {
+			// The review was exited before the analysis finished.
+			break
+		}
+		if msg.err != nil {
+			return m, tui.FatalErrorCmd(fmt.Errorf("analysing placements: %w", msg.err))
+		}
+		m.reviews = msg.reviews
+		for _, r := range m.reviews {
+			if r.Blunder {
+				m.blunders++
+			}
+		}
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Exit):
+			m.stopAnalysis()
+			if m.in.Leaderboard == nil {
+				return m, tui.SwitchModeCmd(tui.ModeMenu, tui.NewMenuInput())
+			}
+			return m, tui.SwitchModeCmd(tui.ModeLeaderboard, m.in.Leaderboard)
+		case key.Matches(msg, m.keys.Help):
+			m.help.ShowAll = !m.help.ShowAll
+		case key.Matches(msg, m.keys.Up):
+			m.selected = max(m.selected-1, 0)
+		case key.Matches(msg, m.keys.Down):
+			m.selected = min(m.selected+1, max(len(m.reviews)-1, 0))
+		case key.Matches(msg, m.keys.PrevBlunder):
+			m.selectBlunder(-1)
+		case key.Matches(msg, m.keys.NextBlunder):
+			m.selectBlunder(1)
+		}
+	}
+	return m, nil
+}
+
+// stopAnalysis cancels the analysis started by Init, if it is still running.
+func (m *ReviewModel) stopAnalysis() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+}
+
+// selectBlunder selects the nearest blunder before (-1) or after (1) the selected placement, if there is one.
+func (m *ReviewModel) selectBlunder(step int) {
+	for i := m.selected + step; i >= 0 && i < len(m.reviews); i += step {
+		if m.reviews[i].Blunder {
+			m.selected = i
+			return
+		}
+	}
+}
+
+func (m *ReviewModel) View() string {
+	var output string
+	if m.reviews == nil {
+		output = fmt.Sprintf("Analysing %d placements...", len(m.in.Turns))
+	} else {
+		header := fmt.Sprintf("Review - Placements: %d, Blunders: %d", len(m.reviews), m.blunders)
+		output = lipgloss.JoinVertical(lipgloss.Center,
+			lipgloss.NewStyle().Bold(true).Render(header),
+			lipgloss.JoinHorizontal(lipgloss.Top, m.listView(), m.boardsView()),
+		)
+	}
+
+	output = lipgloss.JoinVertical(lipgloss.Left, output, m.help.View(m.keys))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, output)
+}
+
+// listView shows a window of the placements around the selected one, with the loss of each and whether it is a
+// blunder.
+func (m *ReviewModel) listView() string {
+	start := min(max(m.selected-reviewListHeight/2, 0), max(len(m.reviews)-reviewListHeight, 0))
+	end := min(start+reviewListHeight, len(m.reviews))
+
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		r := m.reviews[i]
+		line := fmt.Sprintf("%4d %c %6.1f", i+1, r.Played.Placement.Value, r.Loss)
+		if r.Blunder {
+			line += " ??"
+		}
+		style := lipgloss.NewStyle().Width(18)
+		if i == m.selected {
+			style = style.Reverse(true)
+		}
+		lines = append(lines, style.Render(line))
+	}
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(strings.Join(lines, "\n"))
+}
+
+// boardsView shows the selected placement beside the solver's, each in the Matrix it was placed in.
+func (m *ReviewModel) boardsView() string {
+	r := m.reviews[m.selected]
+	played := m.boardView("You played", r.Played, r.Position.Matrix)
+	best := m.boardView("Best", r.Best, r.Position.Matrix)
+
+	var verdict string
+	switch {
+	case r.Blunder:
+		verdict = fmt.Sprintf("Blunder: %.1f worse than the best placement.", r.Loss)
+	case r.Loss > 0:
+		verdict = fmt.Sprintf("%.1f worse than the best placement.", r.Loss)
+	default:
+		verdict = "The best placement found."
+	}
+	return lipgloss.JoinVertical(lipgloss.Center,
+		lipgloss.JoinHorizontal(lipgloss.Top, played, " ", best),
+		verdict,
+	)
+}
+
+// boardView shows the move overlaid on the Matrix, with the stack it was placed on as ghost cells. The visible Matrix
+// is shown, along with any rows above it the placement is in.
+func (m *ReviewModel) boardView(title string, move solver.Move, matrix tetris.Matrix) string {
+	if move.Hold {
+		title += " (hold)"
+	}
+	overlay, err := solver.Overlay(matrix, []solver.Move{move})
+	if err != nil {
+		return title + "\n** FAILED TO OVERLAY PLACEMENT **"
+	}
+
+	top := min(m.in.Skyline, move.Placement.Position.Y)
+	lines := make([]string, 0, len(overlay)-top)
+	for row := max(top, 0); row < len(overlay); row++ {
+		var line string
+		for col, cell := range overlay[row] {
+			if matrix[row][col] != 0 {
+				cell = 'G'
+			}
+			line += m.renderCell(cell)
+		}
+		lines = append(lines, line)
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, title, m.styles.Playfield.Render(strings.Join(lines, "\n")))
+}
+
+func (m *ReviewModel) renderCell(cell byte) string {
+	switch cell {
+	case 0:
+		return m.styles.EmptyCell.Render(m.styles.CellChar.Empty)
+	case 'G':
+		return m.styles.GhostCell.Render(m.styles.CellChar.Ghost)
+	default:
+		if cellStyle, ok := m.styles.TetriminoCellStyles[cell]; ok {
+			return cellStyle.Render(m.styles.CellChar.Tetriminos)
+		}
+	}
+	return "??"
+}
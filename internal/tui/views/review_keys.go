@@ -0,0 +1,49 @@
+package views
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type reviewKeyMap struct {
+	Exit        key.Binding
+	Help        key.Binding
+	Up          key.Binding
+	Down        key.Binding
+	PrevBlunder key.Binding
+	NextBlunder key.Binding
+}
+
+func defaultReviewKeyMap() *reviewKeyMap {
+	return &reviewKeyMap{
+		Exit:        key.NewBinding(key.WithKeys("esc"), key.WithHelp("escape", "exit")),
+		Help:        key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
+		Up:          key.NewBinding(key.WithKeys("up"), key.WithHelp("up arrow", "previous placement")),
+		Down:        key.NewBinding(key.WithKeys("down"), key.WithHelp("down arrow", "next placement")),
+		PrevBlunder: key.NewBinding(key.WithKeys("left"), key.WithHelp("left arrow", "previous blunder")),
+		NextBlunder: key.NewBinding(key.WithKeys("right"), key.WithHelp("right arrow", "next blunder")),
+	}
+}
+
+func (k *reviewKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.Exit,
+		k.Help,
+	}
+}
+
+func (k *reviewKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{
+			k.Exit,
+			k.Help,
+		},
+		{
+			k.Up,
+			k.Down,
+		},
+		{
+			k.PrevBlunder,
+			k.NextBlunder,
+		},
+	}
+}
@@ -0,0 +1,126 @@
+package views
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/internal/config"
+	"github.com/Broderick-Westrope/tetrigo/internal/tui"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/analysis"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+func TestReview(t *testing.T) {
+	// Only a vertical I in the well clears the lines, so placing it on the stack instead is a blunder.
+	matrix := tetris.DefaultMatrix()
+	for row := len(matrix) - 4; row < len(matrix); row++ {
+		for col := range len(matrix[row]) - 1 {
+			matrix[row][col] = 'X'
+		}
+	}
+	game, err := single.NewGame(&single.Input{Level: 1, Matrix: matrix, Sequence: []byte("IOT")})
+	require.NoError(t, err)
+	pos := solver.NewPosition(game)
+
+	best, err := solver.Greedy(pos, eval.ElTetris())
+	require.NoError(t, err)
+	var stacked solver.Move
+	for _, move := range solver.Drops(pos.Current, pos.Matrix) {
+		if move.Placement.Position.X != best.Placement.Position.X {
+			stacked = move
+			break
+		}
+	}
+	require.NotNil(t, stacked.Placement)
+
+	turns := []analysis.Turn{
+		{Position: pos, Played: best},
+		{Position: pos, Played: stacked},
+		{Position: pos, Played: best},
+	}
+	leaderboard := tui.NewLeaderboardInput(tui.ModeMarathon.String())
+	m, err := NewReviewModel(tui.NewReviewInput(turns, game.GetSkyline(), leaderboard), &config.Config{
+		Theme: config.DefaultTheme(),
+		Keys:  config.DefaultKeys(),
+	})
+	require.NoError(t, err)
+	assert.Contains(t, m.View(), "Analysing 3 placements...")
+
+	cmd := m.Init()
+	require.NotNil(t, cmd)
+	m.Update(cmd())
+	require.Len(t, m.reviews, 3)
+	assert.Equal(t, 1, m.blunders)
+	assert.Contains(t, m.View(), "Review - Placements: 3, Blunders: 1")
+	assert.Contains(t, m.View(), "The best placement found.")
+
+	// The blunders can be stepped between, and the placements scrolled through.
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Equal(t, 1, m.selected)
+	assert.Contains(t, m.View(), "Blunder:")
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Equal(t, 1, m.selected)
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 2, m.selected)
+	m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.Equal(t, 1, m.selected)
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	assert.Equal(t, 0, m.selected)
+
+	// Exiting shows the leaderboard of the game.
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	require.NotNil(t, cmd)
+	switchModeMsg, ok := cmd().(tui.SwitchModeMsg)
+	require.True(t, ok)
+	assert.Equal(t, tui.ModeLeaderboard, switchModeMsg.Target)
+	assert.Equal(t, leaderboard, switchModeMsg.Input)
+}
+
+func TestReview_ExitWhileAnalysing(t *testing.T) {
+	game, err := single.NewGame(&single.Input{Level: 1, Sequence: []byte("IOT")})
+	require.NoError(t, err)
+	pos := solver.NewPosition(game)
+	move, err := solver.Greedy(pos, eval.ElTetris())
+	require.NoError(t, err)
+
+	m, err := NewReviewModel(tui.NewReviewInput([]analysis.Turn{{Position: pos, Played: move}}, game.GetSkyline(), nil),
+		&config.Config{
+			Theme: config.DefaultTheme(),
+			Keys:  config.DefaultKeys(),
+		})
+	require.NoError(t, err)
+	analyse := m.Init()
+	require.NotNil(t, analyse)
+
+	// Exiting stops the analysis, so it does not keep running after the review is gone.
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	require.NotNil(t, cmd)
+	switchModeMsg, ok := cmd().(tui.SwitchModeMsg)
+	require.True(t, ok)
+	assert.Equal(t, tui.ModeMenu, switchModeMsg.Target)
+
+	msg, ok := analyse().(reviewMsg)
+	require.True(t, ok)
+	require.ErrorIs(t, msg.err, context.Canceled)
+	_, cmd = m.Update(msg)
+	assert.Nil(t, cmd)
+}
+
+func TestReview_SolverWeights(t *testing.T) {
+	m, err := NewReviewModel(tui.NewReviewInput(nil, 0, nil), &config.Config{
+		SolverWeights: "dellacherie",
+		Theme:         config.DefaultTheme(),
+		Keys:          config.DefaultKeys(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, eval.Dellacherie(), m.weights)
+}
@@ -1,9 +1,12 @@
 package views
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/rand/v2"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Broderick-Westrope/tetrigo/internal/tui/components"
@@ -18,10 +21,15 @@ import (
 	"github.com/Broderick-Westrope/charmutils"
 
 	"github.com/Broderick-Westrope/tetrigo/internal/config"
+	"github.com/Broderick-Westrope/tetrigo/internal/daily"
 	"github.com/Broderick-Westrope/tetrigo/internal/data"
+	"github.com/Broderick-Westrope/tetrigo/internal/puzzle"
 	"github.com/Broderick-Westrope/tetrigo/internal/tui"
 	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/analysis"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
 	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
 )
 
 const (
@@ -33,29 +41,82 @@ const (
 /_/    \__,_/\__,_/____/\___/\__,_/
 Press PAUSE to continue or HOLD to exit.
 `
-	gameOverMessage = `
+	gameOverTitle = `
    ______                        ____                 
   / ____/___ _____ ___  ___     / __ \_   _____  _____
  / / __/ __ ^/ __ ^__ \/ _ \   / / / / | / / _ \/ ___/
 / /_/ / /_/ / / / / / /  __/  / /_/ /| |/ /  __/ /
 \____/\__,_/_/ /_/ /_/\___/   \____/ |___/\___/_/
 
-			Press EXIT or HOLD to continue.
 `
+	gameOverMessage = gameOverTitle + "\t\t\tPress EXIT or HOLD to continue.\n"
+	// reviewGameOverMessage is shown instead of gameOverMessage when the placements of the game can be reviewed. It has
+	// as many lines, so it covers no more of the matrix.
+	reviewGameOverMessage = gameOverTitle + " Press EXIT or HOLD to continue, or REVIEW to review.\n"
+
 	timerUpdateInterval = time.Millisecond * 13
+
+	// targetCell marks the cells of the target placement in the visible matrix, so they can be rendered.
+	targetCell byte = 't'
+
+	// hintCell marks the cells of the hinted placement in the visible matrix, so they can be rendered.
+	hintCell byte = 'h'
+	// hintDepth is the number of Tetriminos a hint searches ahead, from the Tetrimino in play into the Next Queue.
+	hintDepth = 3
+	// hintTimeout is how long a hint may search before the best placement found so far is shown.
+	hintTimeout = time.Second
+
+	// fadingCell marks the minos which are about to be hidden when playing invisible, so they can be rendered.
+	fadingCell byte = 'f'
+	// invisibleFadeTime is how long minos fade for before they are hidden when playing invisible.
+	invisibleFadeTime = time.Millisecond * 500
 )
 
 var _ tea.Model = &SingleModel{}
 
+// singleHint is the result of searching for the best placement of the Tetrimino in play.
+type singleHint struct {
+	game   *single.Game // The game the hint was asked for in.
+	pieces int          // The number of Tetriminos placed when the hint was asked for.
+	hold   byte         // The value of the held Tetrimino when the hint was asked for.
+
+	move solver.Move
+	err  error
+}
+
 type SingleModel struct {
 	username        string
 	game            *single.Game
 	nextQueueLength int
 	fallStopwatch   components.Stopwatch
 	mode            tui.Mode
+	modeName        string             // The name of the mode, which is also the name of its leaderboard.
+	customMode      *config.CustomMode // The custom mode from config, when mode is tui.ModeCustom.
+	puzzle          *puzzle.Puzzle     // The puzzle being played, when mode is tui.ModePuzzle.
+	target          *tetris.Tetrimino  // The placement to show as a ghost, when mode is tui.ModeFinesse.
+	setup           tetris.Matrix      // The starting matrix, when mode is tui.ModePerfectClear. Nil means empty.
 
 	gameTimer     components.Timer
 	gameStopwatch components.Stopwatch
+	trackedTime   time.Duration // The play time which has been passed to the game.
+	playTime      time.Duration // The time on the game stopwatch at game over, which is saved to the leaderboard.
+	garbageTimer  components.GarbageTimer
+
+	invisible      bool          // Whether locked minos are hidden once invisibleDelay has passed, until game over.
+	invisibleDelay time.Duration // The time locked minos stay visible when playing invisible.
+	lockTimes      []time.Time   // When each Tetrimino locked down, indexed by its piece ID minus one.
+	big            bool          // Whether each cell of the matrix is shown at 2x scale.
+
+	hinting bool         // Whether a hint is being searched for.
+	hint    *singleHint  // The hint for the current position, or nil.
+	hints   int          // The number of hints asked for. Games with hints are unranked.
+	weights eval.Weights // The weights of the evaluator the solver uses to find hints.
+
+	attempts *data.LeaderboardRepository // Where attempts at the daily challenge are recorded, or nil.
+	unranked bool                        // Whether the game is not saved, because it is not the first daily attempt.
+
+	turns   []analysis.Turn  // The placements made, so they can be reviewed after the game.
+	turnPos *solver.Position // The position the Tetrimino in play entered from, before it could be held.
 
 	styles   *components.GameStyles
 	help     help.Model
@@ -81,15 +142,82 @@ func NewSingleModel(
 		isPaused:        false,
 		nextQueueLength: cfg.NextQueueLength,
 		mode:            in.Mode,
+		modeName:        in.Mode.String(),
 		//nolint:gosec // This random source is not for any security-related tasks.
 		rand: rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
 	}
+	if in.Seed != 0 {
+		//nolint:gosec // This random source is not for any security-related tasks.
+		m.rand = rand.New(rand.NewPCG(in.Seed, in.Seed))
+	}
 
 	for _, opt := range opts {
 		opt(m)
 	}
 
 	// Get game input
+	gameIn, err := m.newGameInput(in, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	m.weights, err = cfg.GetSolverWeights()
+	if err != nil {
+		return nil, fmt.Errorf("getting solver weights: %w", err)
+	}
+
+	// Create game
+	m.game, err = single.NewGame(gameIn)
+	if err != nil {
+		return nil, fmt.Errorf("creating single player game: %w", err)
+	}
+
+	// Only the first attempt at the daily challenge is ranked, so it is recorded before it can be abandoned.
+	if m.mode == tui.ModeDaily && m.attempts != nil {
+		first, err := m.attempts.StartAttempt(m.modeName, m.username)
+		if err != nil {
+			return nil, fmt.Errorf("recording daily attempt: %w", err)
+		}
+		m.unranked = !first
+	}
+
+	// Setup game dependents
+	m.fallStopwatch = components.NewStopwatchWithInterval(m.game.GetDefaultFallInterval())
+	if m.canSolve() {
+		m.turnPos = solver.NewPosition(m.game)
+	}
+
+	return m, nil
+}
+
+// newGameInput returns the input for the game of the given mode, with the rules and matrix settings chosen in config
+// or by the custom mode applied, and sets up the timers of the mode.
+func (m *SingleModel) newGameInput(in *tui.SingleInput, cfg *config.Config) (*single.Input, error) {
+	gameIn, err := m.gameInput(in, cfg)
+	if err != nil {
+		return nil, err
+	}
+	gameIn.Rand = m.rand
+
+	err = applyConfigRules(gameIn, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cm, ok := cfg.GetCustomMode(in.CustomMode); ok && in.Mode == tui.ModeCustom {
+		err = applyCustomModeRules(gameIn, cm)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if in.Mode == tui.ModeDaily {
+		applyDailyRules(gameIn)
+	}
+	m.applyMatrixSettings(gameIn, in, cfg)
+	return gameIn, nil
+}
+
+// gameInput returns the input for the game of the given mode, and sets up the timers of the mode.
+func (m *SingleModel) gameInput(in *tui.SingleInput, cfg *config.Config) (*single.Input, error) {
 	var gameIn *single.Input
 	switch in.Mode {
 	case tui.ModeMarathon:
@@ -123,24 +251,231 @@ func NewSingleModel(
 		}
 		m.gameTimer = components.NewTimerWithInterval(time.Minute*2, timerUpdateInterval)
 
-	case tui.ModeMenu, tui.ModeLeaderboard:
+	case tui.ModeMaster:
+		gameIn = &single.Input{
+			Level:         0,
+			MaxLevel:      tetris.MasterMaxLevel,
+			IncreaseLevel: true,
+			EndOnMaxLevel: true,
+
+			GhostEnabled: cfg.GhostEnabled,
+			ScoringRules: tetris.NewMasterRules(),
+			GravityCurve: tetris.TGMGravity,
+			DelayCurve:   tetris.MasterDelays,
+		}
+		m.gameStopwatch = components.NewStopwatchWithInterval(timerUpdateInterval)
+
+	case tui.ModeDig:
+		gameIn = &single.Input{
+			Level:         in.Level,
+			MaxLevel:      cfg.MaxLevel,
+			IncreaseLevel: true,
+
+			GhostEnabled: cfg.GhostEnabled,
+
+			GarbageRows:         cfg.Dig.GarbageRows,
+			GarbageMessiness:    cfg.Dig.Messiness,
+			EndOnGarbageCleared: true,
+		}
+		m.gameStopwatch = components.NewStopwatchWithInterval(timerUpdateInterval)
+
+	case tui.ModeSurvival:
+		gameIn = &single.Input{
+			Level:         in.Level,
+			MaxLevel:      cfg.MaxLevel,
+			IncreaseLevel: true,
+
+			GhostEnabled: cfg.GhostEnabled,
+		}
+		m.gameStopwatch = components.NewStopwatchWithInterval(timerUpdateInterval)
+		m.garbageTimer = components.NewGarbageTimer(
+			cfg.Survival.GarbageInterval,
+			cfg.Survival.MinGarbageInterval,
+			cfg.Survival.GarbageAcceleration,
+			1,
+		)
+
+	case tui.ModeVersus:
+		gameIn = versusGameInput(in, cfg)
+		m.gameStopwatch = components.NewStopwatchWithInterval(timerUpdateInterval)
+
+	case tui.ModeCombo:
+		return m.comboGameInput(in, cfg), nil
+
+	case tui.ModeCustom:
+		return m.customGameInput(in, cfg)
+
+	case tui.ModeDaily:
+		return m.dailyGameInput(daily.Today())
+
+	case tui.ModePuzzle, tui.ModeFinesse, tui.ModePerfectClear:
+		return m.practiceGameInput(in, cfg)
+
+	case tui.ModeMenu, tui.ModeLeaderboard, tui.ModeReview:
 		fallthrough
 	default:
 		return nil, fmt.Errorf("invalid single player game mode: %v", in.Mode)
 	}
-	gameIn.Rand = m.rand
+	return gameIn, nil
+}
 
-	// Create game
-	var err error
-	m.game, err = single.NewGame(gameIn)
+// comboGameInput returns the input for the game of combo practice, which is timed by a stopwatch.
+// The game is played in a 4-wide well with 3 residual minos, whose walls are refilled whenever lines are cleared, and
+// ends when the combo breaks.
+func (m *SingleModel) comboGameInput(in *tui.SingleInput, cfg *config.Config) *single.Input {
+	m.gameStopwatch = components.NewStopwatchWithInterval(timerUpdateInterval)
+	return &single.Input{
+		Level:         in.Level,
+		MaxLevel:      cfg.MaxLevel,
+		IncreaseLevel: true,
+
+		GhostEnabled: cfg.GhostEnabled,
+
+		Prefill: &single.Prefill{
+			WellColumn: 3,
+			WellWidth:  4,
+			Residue: [][]bool{
+				{true, false, false, false},
+				{true, true, false, false},
+			},
+		},
+		EndOnComboBreak: true,
+	}
+}
+
+// practiceGameInput returns the input for the game of a puzzle, finesse drill or perfect clear practice, which are
+// timed by a stopwatch.
+func (m *SingleModel) practiceGameInput(in *tui.SingleInput, cfg *config.Config) (*single.Input, error) {
+	m.gameStopwatch = components.NewStopwatchWithInterval(timerUpdateInterval)
+
+	switch {
+	case in.Mode == tui.ModePerfectClear:
+		return &single.Input{
+			Level:        1,
+			GhostEnabled: cfg.GhostEnabled,
+			Matrix:       m.setup,
+		}, nil
+
+	case m.puzzle != nil:
+		gameIn, err := m.puzzle.Input()
+		if err != nil {
+			return nil, fmt.Errorf("getting puzzle input: %w", err)
+		}
+		return gameIn, nil
+
+	case m.target != nil:
+		return &single.Input{
+			Level:        1,
+			GhostEnabled: cfg.GhostEnabled,
+			Sequence:     []byte{m.target.Value},
+		}, nil
+
+	default:
+		return nil, errors.New("no puzzle, target placement or perfect clear setup to practice")
+	}
+}
+
+// customGameInput returns the input for the game of a custom mode from config.
+// The custom mode is timed by a game timer if it has a time limit, otherwise it is timed by a stopwatch.
+func (m *SingleModel) customGameInput(in *tui.SingleInput, cfg *config.Config) (*single.Input, error) {
+	cm, ok := cfg.GetCustomMode(in.CustomMode)
+	if !ok {
+		return nil, fmt.Errorf("invalid custom game mode: %q", in.CustomMode)
+	}
+	m.modeName = cm.Name
+	m.customMode = cm
+
+	gameIn := &single.Input{
+		Level:         in.Level,
+		MaxLevel:      cm.MaxLevel,
+		IncreaseLevel: cm.IncreaseLevel,
+		EndOnMaxLevel: cm.EndOnMaxLevel,
+
+		MaxLines:      cm.MaxLines,
+		EndOnMaxLines: cm.EndOnMaxLines,
+
+		GhostEnabled: cfg.GhostEnabled,
+
+		GarbageRows:         cm.GarbageRows,
+		GarbageMessiness:    cm.GarbageMessiness,
+		EndOnGarbageCleared: cm.EndOnGarbageCleared,
+	}
+	if cm.Level > 0 {
+		gameIn.Level = cm.Level
+	}
+	if cm.GhostEnabled != nil {
+		gameIn.GhostEnabled = *cm.GhostEnabled
+	}
+
+	if cm.TimeLimit > 0 {
+		m.gameTimer = components.NewTimerWithInterval(cm.TimeLimit, timerUpdateInterval)
+	} else {
+		m.gameStopwatch = components.NewStopwatchWithInterval(timerUpdateInterval)
+	}
+	return gameIn, nil
+}
+
+// dailyGameInput returns the input for the game of the daily challenge.
+// Every player gets the same Tetriminos, garbage and rules, regardless of their chosen level and config. The rules
+// which are always set from config are fixed by applyDailyRules.
+func (m *SingleModel) dailyGameInput(challenge daily.Challenge) (*single.Input, error) {
+	m.modeName = challenge.GameMode()
+	//nolint:gosec // This random source is not for any security-related tasks.
+	m.rand = rand.New(rand.NewPCG(challenge.Seed, challenge.Seed))
+
+	randomizer, err := tetris.GetRandomizer(challenge.Randomizer)
 	if err != nil {
-		return nil, fmt.Errorf("creating single player game: %w", err)
+		return nil, fmt.Errorf("getting daily randomizer: %w", err)
 	}
 
-	// Setup game dependents
-	m.fallStopwatch = components.NewStopwatchWithInterval(m.game.GetDefaultFallInterval())
+	if challenge.TimeLimit > 0 {
+		m.gameTimer = components.NewTimerWithInterval(challenge.TimeLimit, timerUpdateInterval)
+	} else {
+		m.gameStopwatch = components.NewStopwatchWithInterval(timerUpdateInterval)
+	}
 
-	return m, nil
+	return &single.Input{
+		Level:         1,
+		MaxLevel:      15,
+		IncreaseLevel: true,
+
+		GhostEnabled: true,
+		ScoringRules: tetris.NewGuidelineRules(),
+		GravityCurve: tetris.GuidelineGravity,
+		Randomizer:   randomizer,
+
+		GarbageRows:      challenge.GarbageRows,
+		GarbageMessiness: 50,
+	}, nil
+}
+
+// withPuzzle sets the puzzle to play, when the mode is tui.ModePuzzle.
+func withPuzzle(p *puzzle.Puzzle) func(*SingleModel) {
+	return func(m *SingleModel) {
+		m.puzzle = p
+	}
+}
+
+// withSetup sets the starting matrix, when the mode is tui.ModePerfectClear.
+func withSetup(matrix tetris.Matrix) func(*SingleModel) {
+	return func(m *SingleModel) {
+		m.setup = matrix
+	}
+}
+
+// withTarget sets the placement to show as a ghost, when the mode is tui.ModeFinesse.
+func withTarget(target *tetris.Tetrimino) func(*SingleModel) {
+	return func(m *SingleModel) {
+		m.target = target
+	}
+}
+
+// WithAttempts sets where attempts at the daily challenge are recorded. Every attempt after the first of the day is
+// unranked, whether or not the first was finished.
+func WithAttempts(repo *data.LeaderboardRepository) func(*SingleModel) {
+	return func(m *SingleModel) {
+		m.attempts = repo
+	}
 }
 
 func WithRandSource(r *rand.Rand) func(*SingleModel) {
@@ -149,6 +484,111 @@ func WithRandSource(r *rand.Rand) func(*SingleModel) {
 	}
 }
 
+// applyMatrixSettings sets the dimensions of the matrix chosen in config, and enables the Invisible and Big modifiers
+// chosen in config, the custom mode or the play command. The daily challenge, practice modes and versus are always
+// played on the standard matrix without modifiers.
+func (m *SingleModel) applyMatrixSettings(gameIn *single.Input, in *tui.SingleInput, cfg *config.Config) {
+	switch in.Mode {
+	case tui.ModeDaily, tui.ModeCombo, tui.ModePuzzle, tui.ModeFinesse, tui.ModePerfectClear, tui.ModeVersus:
+		return
+	}
+	gameIn.Dimensions = cfg.Dimensions(m.customMode)
+
+	invisible, big := cfg.Modifiers.Invisible, cfg.Modifiers.Big
+	if m.customMode != nil && m.customMode.Invisible != nil {
+		invisible = *m.customMode.Invisible
+	}
+	if m.customMode != nil && m.customMode.Big != nil {
+		big = *m.customMode.Big
+	}
+
+	m.invisible = invisible || in.Invisible
+	m.invisibleDelay = cfg.Modifiers.InvisibleDelay
+	m.big = big || in.Big
+	gameIn.Big = m.big
+}
+
+// applyConfigRules sets the rules of the game input which are chosen in config.
+// The scoring rules, gravity curve and randomizer are only set if the game mode has not already set them.
+func applyConfigRules(gameIn *single.Input, cfg *config.Config) error {
+	var err error
+	gameIn.RotationSystem, err = tetris.GetRotationSystem(cfg.RotationSystem)
+	if err != nil {
+		return fmt.Errorf("getting rotation system: %w", err)
+	}
+	if gameIn.ScoringRules == nil {
+		gameIn.ScoringRules, err = tetris.GetScoringRules(cfg.ScoringRules)
+		if err != nil {
+			return fmt.Errorf("getting scoring rules: %w", err)
+		}
+	}
+	if gameIn.GravityCurve == nil {
+		gameIn.GravityCurve, err = tetris.GetGravityCurve(cfg.GravityCurve)
+		if err != nil {
+			return fmt.Errorf("getting gravity curve: %w", err)
+		}
+	}
+	if gameIn.Randomizer == nil {
+		gameIn.Randomizer, err = tetris.GetRandomizer(cfg.Randomizer)
+		if err != nil {
+			return fmt.Errorf("getting randomizer: %w", err)
+		}
+	}
+	gameIn.ARE = cfg.ARE
+	gameIn.LineClearDelay = cfg.LineClearDelay
+	gameIn.LockDelay = cfg.LockDelay
+	return nil
+}
+
+// applyDailyRules overrides the rules of the game input which applyConfigRules always sets from config, so the daily
+// challenge is played with the same rotation system and delays by every player.
+func applyDailyRules(gameIn *single.Input) {
+	gameIn.RotationSystem = tetris.SRS
+	gameIn.ARE = 0
+	gameIn.LineClearDelay = 0
+	gameIn.LockDelay = 0
+}
+
+// applyCustomModeRules overrides the rules of the game input with those chosen by the custom mode.
+func applyCustomModeRules(gameIn *single.Input, cm *config.CustomMode) error {
+	var err error
+	if cm.RotationSystem != "" {
+		gameIn.RotationSystem, err = tetris.GetRotationSystem(cm.RotationSystem)
+		if err != nil {
+			return fmt.Errorf("getting rotation system: %w", err)
+		}
+	}
+	if cm.ScoringRules != "" {
+		gameIn.ScoringRules, err = tetris.GetScoringRules(cm.ScoringRules)
+		if err != nil {
+			return fmt.Errorf("getting scoring rules: %w", err)
+		}
+	}
+	if cm.GravityCurve != "" {
+		gameIn.GravityCurve, err = tetris.GetGravityCurve(cm.GravityCurve)
+		if err != nil {
+			return fmt.Errorf("getting gravity curve: %w", err)
+		}
+	}
+	if cm.Randomizer != "" {
+		gameIn.Randomizer, err = tetris.GetRandomizer(cm.Randomizer)
+		if err != nil {
+			return fmt.Errorf("getting randomizer: %w", err)
+		}
+	}
+
+	if cm.ARE != nil {
+		gameIn.ARE = *cm.ARE
+	}
+	if cm.LineClearDelay != nil {
+		gameIn.LineClearDelay = *cm.LineClearDelay
+	}
+	if cm.LockDelay != nil {
+		gameIn.LockDelay = *cm.LockDelay
+	}
+	return nil
+}
+
 func (m *SingleModel) Init() tea.Cmd {
 	var cmd tea.Cmd
 	if m.gameTimer != nil {
@@ -157,7 +597,11 @@ func (m *SingleModel) Init() tea.Cmd {
 		cmd = m.gameStopwatch.Init()
 	}
 
-	return tea.Batch(m.fallStopwatch.Init(), cmd)
+	cmds := []tea.Cmd{m.fallStopwatch.Init(), cmd}
+	if m.garbageTimer != nil {
+		cmds = append(cmds, m.garbageTimer.Init())
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m *SingleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -183,6 +627,10 @@ func (m *SingleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		return m, tea.Batch(cmds...)
+
+	case singleHint:
+		cmds = append(cmds, m.hintUpdate(msg))
+		return m, tea.Batch(cmds...)
 	}
 
 	// Game Over
@@ -202,9 +650,98 @@ func (m *SingleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Playing
 	m, cmd = m.playingUpdate(msg)
 	cmds = append(cmds, cmd)
+	m.recordLockTimes()
+	m.recordTurns()
+	if m.hint != nil && m.hint.isStale(m.game) {
+		m.hint = nil
+	}
 	return m, tea.Batch(cmds...)
 }
 
+// recordTurns records the placement of the last Tetrimino to lock down and the position it entered from, so the
+// game can be reviewed once it is over. The position of the next Tetrimino is taken before it can be held.
+func (m *SingleModel) recordTurns() {
+	if m.turnPos == nil || m.game.GetPiecesPlaced() == len(m.turns) {
+		return
+	}
+	placement := m.game.GetLastPlacement().DeepCopy()
+	m.turns = append(m.turns, analysis.Turn{
+		Position: m.turnPos,
+		Played:   solver.Move{Hold: m.game.IsLastPlacementHeld(), Placement: placement},
+	})
+
+	// There may be no Tetrimino to enter once the game is over (eg. at the end of a puzzle's sequence).
+	m.turnPos = nil
+	if !m.game.IsGameOver() {
+		m.turnPos = solver.NewPosition(m.game)
+	}
+}
+
+// hintUpdate shows the hint, unless it was asked for in another game or a Tetrimino has since been placed or held.
+func (m *SingleModel) hintUpdate(hint singleHint) tea.Cmd {
+	if hint.game != m.game {
+		return nil
+	}
+	m.hinting = false
+	switch {
+	case m.game.IsGameOver() || hint.isStale(m.game) || errors.Is(hint.err, solver.ErrNoMoves):
+		return nil
+	case hint.err != nil:
+		return tui.FatalErrorCmd(fmt.Errorf("searching for hint: %w", hint.err))
+	}
+	m.hint = &hint
+	return nil
+}
+
+// canSolve returns false if the game mode has its own guidance, or the matrix is played big, which the solver does not
+// support. Hints and reviews are only available when the solver can be used.
+func (m *SingleModel) canSolve() bool {
+	return m.target == nil && m.mode != tui.ModePerfectClear && !m.big
+}
+
+// requestHint returns a command which searches for the best placement of the Tetrimino in play, considering the held
+// Tetrimino and the Next Queue. The position is taken now, since the game keeps changing while the search runs.
+func (m *SingleModel) requestHint() tea.Cmd {
+	if m.hinting || !m.canSolve() {
+		return nil
+	}
+	m.hinting = true
+	m.hints++
+
+	pos := solver.NewPosition(m.game)
+	weights := m.weights
+	hint := singleHint{
+		game:   m.game,
+		pieces: m.game.GetPiecesPlaced(),
+		hold:   m.game.GetHoldTetrimino().Value,
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), hintTimeout)
+		defer cancel()
+
+		s := solver.NewBeamSearch(weights, solver.WithBeamDepth(hintDepth))
+		hint.move, hint.err = s.Solve(ctx, pos)
+		return hint
+	}
+}
+
+// isStale returns true if a Tetrimino has been placed or held in the game since the hint was asked for.
+func (h *singleHint) isStale(game *single.Game) bool {
+	return h.pieces != game.GetPiecesPlaced() || h.hold != game.GetHoldTetrimino().Value
+}
+
+// recordLockTimes records when each Tetrimino locked down, so its minos can be hidden when playing invisible.
+func (m *SingleModel) recordLockTimes() {
+	if !m.invisible {
+		return
+	}
+	now := time.Now()
+	for len(m.lockTimes) < m.game.GetPiecesPlaced() {
+		m.lockTimes = append(m.lockTimes, now)
+	}
+}
+
 func (m *SingleModel) dependenciesUpdate(msg tea.Msg) (*SingleModel, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
@@ -220,6 +757,11 @@ func (m *SingleModel) dependenciesUpdate(msg tea.Msg) (*SingleModel, tea.Cmd) {
 		if err != nil {
 			cmds = append(cmds, tui.FatalErrorCmd(err))
 		}
+
+		if elapsed := m.gameStopwatch.Elapsed(); elapsed > m.trackedTime {
+			m.game.AddTime(elapsed - m.trackedTime)
+			m.trackedTime = elapsed
+		}
 	}
 	cmds = append(cmds, cmd)
 
@@ -229,23 +771,27 @@ func (m *SingleModel) dependenciesUpdate(msg tea.Msg) (*SingleModel, tea.Cmd) {
 	}
 	cmds = append(cmds, cmd)
 
+	if m.garbageTimer != nil {
+		cmd, err = charmutils.UpdateTypedModel(&m.garbageTimer, msg)
+		if err != nil {
+			cmds = append(cmds, tui.FatalErrorCmd(err))
+		}
+		cmds = append(cmds, cmd)
+	}
+
 	return m, tea.Batch(cmds...)
 }
 
 func (m *SingleModel) gameOverUpdate(msg tea.Msg) (*SingleModel, tea.Cmd) {
 	if msg, ok := msg.(tea.KeyMsg); ok {
-		if key.Matches(msg, m.keys.Exit, m.keys.Hold) {
-			modeStr := m.mode.String()
-			newEntry := &data.Score{
-				GameMode: modeStr,
-				Name:     m.username,
-				Score:    m.game.GetTotalScore(),
-				Lines:    m.game.GetLinesCleared(),
-				Level:    m.game.GetLevel(),
-			}
+		switch {
+		case key.Matches(msg, m.keys.Exit, m.keys.Hold):
+			return m, tui.SwitchModeCmd(tui.ModeLeaderboard, m.leaderboardInput())
 
-			return m, tui.SwitchModeCmd(tui.ModeLeaderboard,
-				tui.NewLeaderboardInput(modeStr, tui.WithNewEntry(newEntry)),
+		// The review shows the leaderboard once it is exited, so the score is still saved.
+		case key.Matches(msg, m.keys.Review) && len(m.turns) > 0:
+			return m, tui.SwitchModeCmd(tui.ModeReview,
+				tui.NewReviewInput(m.turns, m.game.GetSkyline(), m.leaderboardInput()),
 			)
 		}
 	}
@@ -253,6 +799,50 @@ func (m *SingleModel) gameOverUpdate(msg tea.Msg) (*SingleModel, tea.Cmd) {
 	return m, nil
 }
 
+// leaderboardInput returns the input of the leaderboard to show after the game, with the new entry to save if the game
+// is ranked.
+func (m *SingleModel) leaderboardInput() *tui.LeaderboardInput {
+	modeStr := m.modeName
+
+	// Modes ranked by fastest time only save completed games, and unranked games and games with hints are never saved.
+	if !m.isCompleted() || m.hints > 0 || m.unranked {
+		return tui.NewLeaderboardInput(modeStr)
+	}
+
+	newEntry := &data.Score{
+		GameMode: modeStr,
+		Name:     m.username,
+		Score:    m.game.GetTotalScore(),
+		Lines:    m.game.GetLinesCleared(),
+		Level:    m.game.GetLevel(),
+		Grade:    m.game.GetGrade(),
+		MaxCombo: m.game.GetMaxCombo(),
+	}
+	if m.gameStopwatch != nil {
+		newEntry.Time = m.playTime
+	}
+	return tui.NewLeaderboardInput(modeStr, tui.WithNewEntry(newEntry))
+}
+
+// isCompleted returns false if the game mode is ranked by fastest time but the game ended before its goal was reached.
+func (m *SingleModel) isCompleted() bool {
+	if m.mode == tui.ModeDig {
+		return m.game.IsGarbageCleared()
+	}
+	if m.customMode == nil {
+		return true
+	}
+
+	cm := m.customMode
+	ranking, err := data.ParseRanking(cm.Ranking)
+	if err != nil || ranking != data.RankByFastestTime {
+		return true
+	}
+	return (cm.EndOnMaxLines && m.game.GetLinesCleared() >= cm.MaxLines) ||
+		(cm.EndOnMaxLevel && m.game.GetLevel() >= cm.MaxLevel) ||
+		(cm.EndOnGarbageCleared && m.game.IsGarbageCleared())
+}
+
 func (m *SingleModel) pausedUpdate(msg tea.Msg) (*SingleModel, tea.Cmd) {
 	if msg, ok := msg.(tea.KeyMsg); ok {
 		switch {
@@ -282,6 +872,13 @@ func (m *SingleModel) playingUpdate(msg tea.Msg) (*SingleModel, tea.Cmd) {
 			break
 		}
 		return m, m.triggerGameOver()
+
+	case components.GarbageMsg:
+		if msg.ID != m.garbageTimer.ID() {
+			break
+		}
+		m.game.QueueGarbage(msg.Rows)
+		return m, nil
 	}
 
 	return m, nil
@@ -320,11 +917,16 @@ func (m *SingleModel) playingKeyMsgUpdate(msg tea.KeyMsg) (*SingleModel, tea.Cmd
 		if gameOver {
 			cmds = append(cmds, m.triggerGameOver())
 		}
+		m.fallStopwatch.SetInterval(m.game.GetFallInterval())
 		cmds = append(cmds, m.fallStopwatch.Reset())
 		return m, tea.Batch(cmds...)
 
 	case key.Matches(msg, m.keys.SoftDrop):
 		m.game.ToggleSoftDrop()
+		if m.game.IsInEntryDelay() {
+			// The next Tetrimino spawns when the fall stopwatch ticks after the entry delay.
+			return m, nil
+		}
 		return m, m.fallStopwatchTick()
 
 	case key.Matches(msg, m.keys.Hold):
@@ -338,6 +940,9 @@ func (m *SingleModel) playingKeyMsgUpdate(msg tea.KeyMsg) (*SingleModel, tea.Cmd
 		}
 		return m, tea.Batch(cmds...)
 
+	case key.Matches(msg, m.keys.Hint):
+		return m, m.requestHint()
+
 	case key.Matches(msg, m.keys.Exit):
 		return m, m.togglePause()
 	}
@@ -362,14 +967,27 @@ func (m *SingleModel) View() string {
 		return "** FAILED TO BUILD MATRIX VIEW **"
 	}
 
-	var output = lipgloss.JoinHorizontal(lipgloss.Top,
-		lipgloss.JoinVertical(lipgloss.Right, m.holdView(), m.informationView()),
-		matrixView,
-		m.bagView(),
-	)
+	sidebar := []string{m.holdView(), m.informationView()}
+	if callout := m.calloutView(); callout != "" {
+		sidebar = append(sidebar, callout)
+	}
 
-	if m.game.IsGameOver() {
-		output, err = charmutils.OverlayCenter(output, gameOverMessage, true)
+	views := []string{lipgloss.JoinVertical(lipgloss.Right, sidebar...)}
+	if m.garbageTimer != nil || m.mode == tui.ModeVersus {
+		views = append(views, m.garbageMeterView())
+	}
+	views = append(views, matrixView, m.bagView())
+
+	var output = lipgloss.JoinHorizontal(lipgloss.Top, views...)
+
+	// The outcome of a puzzle, perfect clear practice or versus is shown by the PuzzleModel, PerfectClearModel or
+	// VersusModel instead.
+	if m.game.IsGameOver() && m.puzzle == nil && m.mode != tui.ModePerfectClear && m.mode != tui.ModeVersus {
+		message := gameOverMessage
+		if len(m.turns) > 0 {
+			message = reviewGameOverMessage
+		}
+		output, err = charmutils.OverlayCenter(output, message, true)
 		if err != nil {
 			return "** FAILED TO OVERLAY GAME OVER MESSAGE **"
 		}
@@ -390,18 +1008,32 @@ func (m *SingleModel) matrixView() (string, error) {
 		return "", fmt.Errorf("getting visible matrix: %w", err)
 	}
 
-	var output string
+	if m.target != nil {
+		matrix = m.withPlacementCells(matrix, m.target, targetCell)
+	}
+	if m.hint != nil && !m.game.IsGameOver() {
+		matrix = m.withPlacementCells(matrix, m.hint.move.Placement, hintCell)
+	}
+	// The stack is revealed at game over.
+	if m.invisible && !m.game.IsGameOver() {
+		matrix = m.withInvisibleCells(matrix, time.Now())
+	}
+
+	scale := m.scale()
+	lines := make([]string, 0, len(matrix)*scale)
 	for row := range matrix {
+		var line string
 		for col := range matrix[row] {
-			output += m.renderCell(matrix[row][col])
+			line += strings.Repeat(m.renderCell(matrix[row][col]), scale)
 		}
-		if row < len(matrix)-1 {
-			output += "\n"
+		for range scale {
+			lines = append(lines, line)
 		}
 	}
+	output := strings.Join(lines, "\n")
 
 	var rowIndicator string
-	for i := 1; i <= 20; i++ {
+	for i := 1; i <= len(lines); i++ {
 		rowIndicator += fmt.Sprintf("%d\n", i)
 	}
 	return lipgloss.JoinHorizontal(lipgloss.Center,
@@ -410,6 +1042,55 @@ func (m *SingleModel) matrixView() (string, error) {
 	), nil
 }
 
+// withPlacementCells returns a copy of the visible matrix with the empty and ghost cells covered by the placement
+// replaced by the given cell.
+func (m *SingleModel) withPlacementCells(visible tetris.Matrix, placement *tetris.Tetrimino, cell byte) tetris.Matrix {
+	matrix := *visible.DeepCopy()
+	skyline := m.game.GetSkyline()
+	for row := range placement.Cells {
+		for col := range placement.Cells[row] {
+			r, c := placement.Position.Y+row-skyline, placement.Position.X+col
+			if !placement.Cells[row][col] || r < 0 || r >= len(matrix) || c < 0 || c >= len(matrix[r]) {
+				continue
+			}
+			if matrix[r][c] == 0 || matrix[r][c] == 'G' {
+				matrix[r][c] = cell
+			}
+		}
+	}
+	return matrix
+}
+
+// scale returns the number of rows and columns each cell of the matrix is shown as.
+// When playing big, each cell is shown as a 2x2 block.
+func (m *SingleModel) scale() int {
+	if m.big {
+		return 2
+	}
+	return 1
+}
+
+// withInvisibleCells returns a copy of the visible matrix with the minos which locked down at least the invisible delay
+// before now removed, and those which are about to be removed replaced by fadingCell. Garbage is never hidden.
+func (m *SingleModel) withInvisibleCells(visible tetris.Matrix, now time.Time) tetris.Matrix {
+	matrix := *visible.DeepCopy()
+	for row, ids := range m.game.GetVisiblePieceIDs() {
+		for col, id := range ids {
+			if id == 0 || id > len(m.lockTimes) {
+				continue
+			}
+			age := now.Sub(m.lockTimes[id-1])
+			switch {
+			case age >= m.invisibleDelay:
+				matrix[row][col] = 0
+			case age >= m.invisibleDelay-invisibleFadeTime:
+				matrix[row][col] = fadingCell
+			}
+		}
+	}
+	return matrix
+}
+
 func (m *SingleModel) informationView() string {
 	width := m.styles.Information.GetWidth()
 
@@ -422,7 +1103,7 @@ func (m *SingleModel) informationView() string {
 	case m.isPaused:
 		header = headerStyle.Render("PAUSED")
 	default:
-		header = headerStyle.Render("MARATHON")
+		header = headerStyle.Render(strings.ToUpper(m.title()))
 	}
 
 	toFixedWidth := func(title, value string) string {
@@ -430,9 +1111,12 @@ func (m *SingleModel) informationView() string {
 	}
 
 	var gameTime float64
-	if m.gameTimer != nil {
+	switch {
+	case m.gameTimer != nil:
 		gameTime = m.gameTimer.GetTimeout().Seconds()
-	} else {
+	case m.game.IsGameOver():
+		gameTime = m.playTime.Seconds()
+	default:
 		gameTime = m.gameStopwatch.Elapsed().Seconds()
 	}
 
@@ -453,10 +1137,86 @@ func (m *SingleModel) informationView() string {
 	output += fmt.Sprintf("%*s\n", width-1, timeStr)
 	output += toFixedWidth("Lines:", strconv.Itoa(m.game.GetLinesCleared()))
 	output += toFixedWidth("Level:", strconv.Itoa(m.game.GetLevel()))
+	if grade := m.game.GetGrade(); grade != "" {
+		output += toFixedWidth("Grade:", grade)
+	}
+	if m.mode == tui.ModeDig {
+		output += toFixedWidth("Garbage:", strconv.Itoa(m.game.GetGarbageRemaining()))
+	}
+	if m.mode == tui.ModeCombo {
+		output += toFixedWidth("Combo:", strconv.Itoa(m.game.GetCombo()))
+		output += toFixedWidth("Best:", strconv.Itoa(m.game.GetMaxCombo()))
+	}
+	if m.puzzle != nil {
+		output += toFixedWidth("Pieces:", strconv.Itoa(m.puzzle.PiecesLeft(m.game)))
+	}
+	if faults := m.game.GetFinesseFaults(); faults > 0 {
+		output += toFixedWidth("Faults:", strconv.Itoa(faults))
+	}
+	if m.hints > 0 {
+		output += toFixedWidth("Hints:", strconv.Itoa(m.hints))
+	}
+	if m.hints > 0 || m.unranked {
+		output += fmt.Sprintln("Unranked")
+	}
 
 	return m.styles.Information.Render(lipgloss.JoinVertical(lipgloss.Left, header, output))
 }
 
+// title returns the name of the game mode to show in the header.
+func (m *SingleModel) title() string {
+	if m.customMode != nil {
+		return m.customMode.Name
+	}
+	if m.puzzle != nil && m.puzzle.Name != "" {
+		return m.puzzle.Name
+	}
+	return m.mode.String()
+}
+
+// calloutView announces the lines cleared, combo and Perfect Clear of the last Tetrimino to lock down, and whether the
+// hint is to hold first.
+// An empty string is returned if there is nothing to announce.
+func (m *SingleModel) calloutView() string {
+	var callouts []string
+	if m.game.IsPerfectClear() {
+		callouts = append(callouts, "PERFECT CLEAR")
+	}
+	if action := m.game.GetLastAction(); action.IsValid() && action != tetris.Actions.None {
+		callouts = append(callouts, strings.ToUpper(action.String()))
+	}
+	if combo := m.game.GetCombo(); combo > 0 {
+		callouts = append(callouts, fmt.Sprintf("%d COMBO", combo))
+	}
+	if m.game.GetLastFinesse().IsFault() {
+		callouts = append(callouts, "FINESSE FAULT")
+	}
+	if m.hint != nil && m.hint.move.Hold && !m.game.IsGameOver() {
+		callouts = append(callouts, "HINT: HOLD")
+	}
+
+	if len(callouts) == 0 {
+		return ""
+	}
+	return m.styles.Callout.Render(strings.Join(callouts, "\n"))
+}
+
+// garbageMeterView shows the pending garbage as a bar which fills from the bottom of the matrix.
+func (m *SingleModel) garbageMeterView() string {
+	height := m.game.GetDimensions().Height * m.scale()
+	pending := min(m.game.GetPendingGarbage()*m.scale(), height)
+
+	rows := make([]string, height)
+	for i := range rows {
+		if i >= height-pending {
+			rows[i] = "█"
+		} else {
+			rows[i] = " "
+		}
+	}
+	return m.styles.GarbageMeter.Render(strings.Join(rows, "\n"))
+}
+
 func (m *SingleModel) holdView() string {
 	label := m.styles.Hold.Label.Render("Hold:")
 	item := m.styles.Hold.Item.Render(m.renderTetrimino(m.game.GetHoldTetrimino(), 1))
@@ -498,6 +1258,14 @@ func (m *SingleModel) renderCell(cell byte) string {
 		return "  "
 	case 'G':
 		return m.styles.GhostCell.Render(m.styles.CellChar.Ghost)
+	case targetCell:
+		return m.styles.TetriminoCellStyles[m.target.Value].Render(m.styles.CellChar.Ghost)
+	case hintCell:
+		return m.styles.HintCell.Render(m.styles.CellChar.Ghost)
+	case fadingCell:
+		return m.styles.GhostCell.Render(m.styles.CellChar.Tetriminos)
+	case tetris.GarbageCell:
+		return m.styles.GarbageCell.Render(m.styles.CellChar.Tetriminos)
 	default:
 		cellStyle, ok := m.styles.TetriminoCellStyles[cell]
 		if ok {
@@ -516,7 +1284,12 @@ func (m *SingleModel) triggerGameOver() tea.Cmd {
 		m.gameTimer.SetTimeout(0)
 		cmds = append(cmds, m.gameTimer.Stop())
 	} else {
-		cmds = append(cmds, m.fallStopwatch.Stop())
+		// The time is recorded now, since the stopwatch may tick again before it stops.
+		m.playTime = m.gameStopwatch.Elapsed()
+		cmds = append(cmds, m.fallStopwatch.Stop(), m.gameStopwatch.Stop())
+	}
+	if m.garbageTimer != nil {
+		cmds = append(cmds, m.garbageTimer.Stop())
 	}
 
 	return tea.Batch(cmds...)
@@ -532,8 +1305,9 @@ func (m *SingleModel) togglePause() tea.Cmd {
 		cmd = m.gameStopwatch.Toggle()
 	}
 
-	return tea.Batch(
-		m.fallStopwatch.Toggle(),
-		cmd,
-	)
+	cmds := []tea.Cmd{m.fallStopwatch.Toggle(), cmd}
+	if m.garbageTimer != nil {
+		cmds = append(cmds, m.garbageTimer.Toggle())
+	}
+	return tea.Batch(cmds...)
 }
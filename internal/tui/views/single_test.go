@@ -1,15 +1,23 @@
 package views
 
 import (
+	"fmt"
 	"math/rand/v2"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/Broderick-Westrope/tetrigo/internal/config"
+	"github.com/Broderick-Westrope/tetrigo/internal/daily"
 	"github.com/Broderick-Westrope/tetrigo/internal/data"
 	"github.com/Broderick-Westrope/tetrigo/internal/tui"
 	"github.com/Broderick-Westrope/tetrigo/internal/tui/components"
 	"github.com/Broderick-Westrope/tetrigo/internal/tui/testutils"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
 	"github.com/Broderick-Westrope/x/exp/teatest"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
@@ -123,6 +131,7 @@ func TestSingle_GameOverOutput(t *testing.T) {
 	mockGameStopwatch.EXPECT().Init().Return(nil)
 	mockGameStopwatch.EXPECT().Update(mock.Anything).Return(mockGameStopwatch, nil)
 	mockGameStopwatch.EXPECT().Elapsed().Return(time.Duration(0))
+	mockGameStopwatch.EXPECT().Stop().Return(nil)
 
 	m, err := NewSingleModel(
 		&tui.SingleInput{
@@ -193,6 +202,12 @@ func TestSingle_PausedOutput(t *testing.T) {
 }
 
 func TestSingle_GameOverSwitchModeMsg(t *testing.T) {
+	mockGameStopwatch := components.NewMockStopwatch(t)
+	mockGameStopwatch.EXPECT().Init().Return(nil)
+	mockGameStopwatch.EXPECT().Update(mock.Anything).Return(mockGameStopwatch, nil)
+	mockGameStopwatch.EXPECT().Elapsed().Return(time.Minute)
+	mockGameStopwatch.EXPECT().Stop().Return(nil)
+
 	m, err := NewSingleModel(
 		&tui.SingleInput{
 			Mode:     tui.ModeMarathon,
@@ -211,6 +226,7 @@ func TestSingle_GameOverSwitchModeMsg(t *testing.T) {
 		WithRandSource(rand.New(rand.NewPCG(0, 0))),
 	)
 	require.NoError(t, err)
+	m.gameStopwatch = mockGameStopwatch
 	tm := teatest.NewTestModel(t, m)
 
 	switchModeMsgCh := make(chan tui.SwitchModeMsg, 1)
@@ -240,7 +256,7 @@ func TestSingle_GameOverSwitchModeMsg(t *testing.T) {
 			Rank:     0,
 			GameMode: tui.ModeMarathon.String(),
 			Name:     "testuser",
-			Time:     time.Duration(0),
+			Time:     time.Minute,
 			Score:    230,
 			Lines:    0,
 			Level:    1,
@@ -250,3 +266,625 @@ func TestSingle_GameOverSwitchModeMsg(t *testing.T) {
 		t.Fatal("Timeout waiting for switch mode message")
 	}
 }
+
+func TestSingle_CustomMode(t *testing.T) {
+	lockDelay := 300 * time.Millisecond
+	modes := []config.CustomMode{
+		{
+			Name:          "Sprint 20",
+			MaxLines:      20,
+			EndOnMaxLines: true,
+			Randomizer:    "14-Bag",
+			LockDelay:     &lockDelay,
+			Ranking:       "fastest_time",
+		},
+		{
+			Name:        "Blitz",
+			Level:       5,
+			TimeLimit:   time.Minute,
+			GarbageRows: 4,
+		},
+	}
+
+	tt := map[string]struct {
+		customMode    string
+		wantErr       bool
+		wantModeName  string
+		wantLevel     int
+		wantGarbage   int
+		wantGameTimer bool
+	}{
+		"stopwatch": {
+			customMode:   "Sprint 20",
+			wantModeName: "Sprint 20",
+			wantLevel:    3,
+		},
+		"time limit, level and garbage": {
+			customMode:    "blitz",
+			wantModeName:  "Blitz",
+			wantLevel:     5,
+			wantGarbage:   4,
+			wantGameTimer: true,
+		},
+		"unknown": {
+			customMode: "nope",
+			wantErr:    true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			m, err := NewSingleModel(
+				tui.NewSingleInput(tui.ModeCustom, 3, "testuser", tui.WithCustomMode(tc.customMode)),
+				&config.Config{
+					NextQueueLength: 5,
+					GhostEnabled:    true,
+					Modes:           modes,
+					Theme:           config.DefaultTheme(),
+					Keys:            config.DefaultKeys(),
+				},
+				WithRandSource(rand.New(rand.NewPCG(0, 0))),
+			)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wantModeName, m.modeName)
+			assert.Equal(t, tc.wantLevel, m.game.GetLevel())
+			assert.Equal(t, tc.wantGarbage, m.game.GetGarbageRemaining())
+			assert.Equal(t, tc.wantGameTimer, m.gameTimer != nil)
+			assert.Equal(t, !tc.wantGameTimer, m.gameStopwatch != nil)
+		})
+	}
+}
+
+func TestSingle_DailyGameInput(t *testing.T) {
+	tt := map[string]struct {
+		challenge     daily.Challenge
+		wantGameTimer bool
+	}{
+		"no time limit": {
+			challenge: daily.Challenge{Date: "2024-03-01", Seed: 1, Randomizer: "TGM", GarbageRows: 4},
+		},
+		"time limit": {
+			challenge:     daily.Challenge{Date: "2024-03-02", Seed: 2, TimeLimit: 2 * time.Minute},
+			wantGameTimer: true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			m := &SingleModel{}
+			gameIn, err := m.dailyGameInput(tc.challenge)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.challenge.GameMode(), m.modeName)
+			assert.Equal(t, tc.challenge.GarbageRows, gameIn.GarbageRows)
+			assert.Equal(t, 1, gameIn.Level)
+			assert.Equal(t, tc.wantGameTimer, m.gameTimer != nil)
+			assert.Equal(t, !tc.wantGameTimer, m.gameStopwatch != nil)
+
+			// The same challenge always deals the same Tetriminos.
+			other := &SingleModel{}
+			_, err = other.dailyGameInput(tc.challenge)
+			require.NoError(t, err)
+			assert.Equal(t, m.rand.Uint64(), other.rand.Uint64())
+		})
+	}
+}
+
+func TestSingle_DailyRules(t *testing.T) {
+	defaults, err := config.GetConfig(filepath.Join(t.TempDir(), "config.toml"))
+	require.NoError(t, err)
+
+	custom := *defaults
+	custom.GhostEnabled = false
+	custom.RotationSystem = "ARS"
+	custom.ScoringRules = "NES"
+	custom.GravityCurve = "NES"
+	custom.Randomizer = "Random"
+	custom.ARE = 100 * time.Millisecond
+	custom.LineClearDelay = 400 * time.Millisecond
+	custom.LockDelay = 500 * time.Millisecond
+	custom.Matrix = config.Matrix{Width: 12, Height: 24, BufferHeight: 24}
+	custom.Modifiers = config.Modifiers{Invisible: true, Big: true}
+
+	dailyInput := func(cfg *config.Config) *single.Input {
+		m := &SingleModel{}
+		gameIn, err := m.newGameInput(tui.NewSingleInput(tui.ModeDaily, 5, "testuser"), cfg)
+		require.NoError(t, err)
+		return gameIn
+	}
+
+	// Every player gets the same game, whatever their config.
+	assert.Equal(t, dailyInput(defaults), dailyInput(&custom))
+}
+
+func TestSingle_DailyAttempts(t *testing.T) {
+	cfg, err := config.GetConfig(filepath.Join(t.TempDir(), "config.toml"))
+	require.NoError(t, err)
+	repo := data.NewLeaderboardRepository(testutils.SetupInMemoryDB(t))
+
+	newModel := func(username string) *SingleModel {
+		m, err := NewSingleModel(tui.NewSingleInput(tui.ModeDaily, 1, username), cfg, WithAttempts(repo))
+		require.NoError(t, err)
+		return m
+	}
+
+	// The first attempt is ranked, even though it is abandoned before it is saved.
+	first := newModel("testuser")
+	assert.False(t, first.unranked)
+	assert.NotContains(t, first.informationView(), "Unranked")
+
+	// Every later attempt that day is unranked, and is not saved at game over.
+	later := newModel("testuser")
+	assert.True(t, later.unranked)
+	assert.Contains(t, later.informationView(), "Unranked")
+	later.game.EndGame()
+	assert.Nil(t, later.leaderboardInput().NewEntry)
+
+	// Other players' attempts are ranked.
+	assert.False(t, newModel("otheruser").unranked)
+}
+
+func TestSingle_SolverWeights(t *testing.T) {
+	tuned := eval.Weights{Holes: -1, LinesCleared: 1}
+	path := filepath.Join(t.TempDir(), "weights.json")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, tuned.Save(f))
+	require.NoError(t, f.Close())
+
+	tt := map[string]struct {
+		solverWeights string
+		want          eval.Weights
+		wantErr       bool
+	}{
+		"unset": {
+			solverWeights: "",
+			want:          eval.ElTetris(),
+		},
+		"named": {
+			solverWeights: "dellacherie",
+			want:          eval.Dellacherie(),
+		},
+		"weights file": {
+			solverWeights: path,
+			want:          tuned,
+		},
+		"missing weights file": {
+			solverWeights: filepath.Join(t.TempDir(), "missing.json"),
+			wantErr:       true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			m, err := NewSingleModel(tui.NewSingleInput(tui.ModeMarathon, 1, "testuser"), &config.Config{
+				SolverWeights: tc.solverWeights,
+				Theme:         config.DefaultTheme(),
+				Keys:          config.DefaultKeys(),
+			})
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, m.weights)
+		})
+	}
+}
+
+func TestSingle_ComboGameInput(t *testing.T) {
+	m := &SingleModel{}
+	gameIn := m.comboGameInput(&tui.SingleInput{Mode: tui.ModeCombo, Level: 3}, &config.Config{MaxLevel: 15})
+
+	assert.Equal(t, 3, gameIn.Level)
+	assert.True(t, gameIn.EndOnComboBreak)
+	require.NotNil(t, gameIn.Prefill)
+	assert.Equal(t, 4, gameIn.Prefill.WellWidth)
+	assert.NotNil(t, m.gameStopwatch)
+	assert.Nil(t, m.gameTimer)
+}
+
+func TestSingle_Modifiers(t *testing.T) {
+	enabled, disabled := true, false
+	modes := []config.CustomMode{
+		{Name: "Big Sprint", Big: &enabled},
+		{Name: "Visible", Invisible: &disabled},
+	}
+
+	tt := map[string]struct {
+		in            *tui.SingleInput
+		modifiers     config.Modifiers
+		wantInvisible bool
+		wantBig       bool
+	}{
+		"none": {
+			in: tui.NewSingleInput(tui.ModeMarathon, 1, "testuser"),
+		},
+		"config": {
+			in:            tui.NewSingleInput(tui.ModeMarathon, 1, "testuser"),
+			modifiers:     config.Modifiers{Invisible: true, Big: true},
+			wantInvisible: true,
+			wantBig:       true,
+		},
+		"play command": {
+			in:            tui.NewSingleInput(tui.ModeSprint, 1, "testuser", tui.WithModifiers(true, false)),
+			wantInvisible: true,
+		},
+		"custom mode": {
+			in:        tui.NewSingleInput(tui.ModeCustom, 1, "testuser", tui.WithCustomMode("Big Sprint")),
+			modifiers: config.Modifiers{Invisible: true},
+			// The custom mode only overrides the Big modifier.
+			wantInvisible: true,
+			wantBig:       true,
+		},
+		"custom mode disables": {
+			in:        tui.NewSingleInput(tui.ModeCustom, 1, "testuser", tui.WithCustomMode("Visible")),
+			modifiers: config.Modifiers{Invisible: true},
+		},
+		"daily challenge": {
+			in:        tui.NewSingleInput(tui.ModeDaily, 1, "testuser", tui.WithModifiers(true, true)),
+			modifiers: config.Modifiers{Invisible: true, Big: true},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			m, err := NewSingleModel(tc.in,
+				&config.Config{
+					GhostEnabled: true,
+					Modifiers:    tc.modifiers,
+					Modes:        modes,
+					Theme:        config.DefaultTheme(),
+					Keys:         config.DefaultKeys(),
+				},
+				WithRandSource(rand.New(rand.NewPCG(0, 0))),
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wantInvisible, m.invisible)
+			assert.Equal(t, tc.wantBig, m.big)
+
+			visible, err := m.game.GetVisibleMatrix()
+			require.NoError(t, err)
+			if tc.wantBig {
+				assert.Len(t, visible[0], 5)
+			} else {
+				assert.Len(t, visible[0], 10)
+			}
+		})
+	}
+}
+
+func TestSingle_InvisibleCells(t *testing.T) {
+	m, err := NewSingleModel(
+		tui.NewSingleInput(tui.ModeMarathon, 1, "testuser", tui.WithModifiers(true, false)),
+		&config.Config{
+			Modifiers: config.Modifiers{InvisibleDelay: 2 * time.Second},
+			Theme:     config.DefaultTheme(),
+			Keys:      config.DefaultKeys(),
+		},
+		WithRandSource(rand.New(rand.NewPCG(0, 0))),
+	)
+	require.NoError(t, err)
+
+	_, err = m.game.HardDrop()
+	require.NoError(t, err)
+	m.recordLockTimes()
+	require.Len(t, m.lockTimes, 1)
+	lockTime := m.lockTimes[0]
+
+	visible, err := m.game.GetVisibleMatrix()
+	require.NoError(t, err)
+	bottom := visible[len(visible)-1]
+	col := -1
+	for i, cell := range bottom {
+		if cell != 0 {
+			col = i
+			break
+		}
+	}
+	require.NotEqual(t, -1, col)
+
+	tt := map[string]struct {
+		age  time.Duration
+		want byte
+	}{
+		"visible": {
+			age:  time.Second,
+			want: bottom[col],
+		},
+		"fading": {
+			age:  2*time.Second - invisibleFadeTime,
+			want: fadingCell,
+		},
+		"hidden": {
+			age:  2 * time.Second,
+			want: 0,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got := m.withInvisibleCells(visible, lockTime.Add(tc.age))
+			assert.Equal(t, tc.want, got[len(got)-1][col])
+			// The visible matrix is not modified.
+			assert.NotZero(t, bottom[col])
+		})
+	}
+}
+
+func TestSingle_MatrixDimensions(t *testing.T) {
+	modes := []config.CustomMode{
+		{Name: "Wide", Width: 12},
+	}
+
+	tt := map[string]struct {
+		in   *tui.SingleInput
+		want tetris.Dimensions
+	}{
+		"config": {
+			in:   tui.NewSingleInput(tui.ModeMarathon, 1, "testuser"),
+			want: tetris.Dimensions{Width: 4, Height: 8, Buffer: 2},
+		},
+		"custom mode": {
+			in:   tui.NewSingleInput(tui.ModeCustom, 1, "testuser", tui.WithCustomMode("Wide")),
+			want: tetris.Dimensions{Width: 12, Height: 8, Buffer: 2},
+		},
+		"daily challenge": {
+			in:   tui.NewSingleInput(tui.ModeDaily, 1, "testuser"),
+			want: tetris.DefaultDimensions,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			m, err := NewSingleModel(tc.in,
+				&config.Config{
+					Matrix: config.Matrix{Width: 4, Height: 8, BufferHeight: 2},
+					Modes:  modes,
+					Theme:  config.DefaultTheme(),
+					Keys:   config.DefaultKeys(),
+				},
+				WithRandSource(rand.New(rand.NewPCG(0, 0))),
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, m.game.GetDimensions())
+
+			// There is a row indicator for each visible row.
+			view, err := m.matrixView()
+			require.NoError(t, err)
+			assert.Contains(t, view, fmt.Sprintf(" %d ", tc.want.Height))
+			assert.NotContains(t, view, fmt.Sprintf(" %d ", tc.want.Height+1))
+		})
+	}
+}
+
+func TestSingle_SoftDropEntryDelay(t *testing.T) {
+	m, err := NewSingleModel(
+		tui.NewSingleInput(tui.ModeMarathon, 1, "testuser"),
+		&config.Config{
+			GhostEnabled: true,
+			ARE:          time.Second,
+			Theme:        config.DefaultTheme(),
+			Keys:         config.DefaultKeys(),
+		},
+		WithRandSource(rand.New(rand.NewPCG(0, 0))),
+	)
+	require.NoError(t, err)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	require.True(t, m.game.IsInEntryDelay())
+
+	// Toggling soft drop does not skip the entry delay.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	assert.True(t, m.game.IsInEntryDelay())
+	assert.Equal(t, time.Second, m.game.GetFallInterval())
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	assert.True(t, m.game.IsInEntryDelay())
+	assert.Equal(t, 1, m.game.GetPiecesPlaced())
+}
+
+func TestSingle_GameOverTime(t *testing.T) {
+	tt := map[string]struct {
+		mode tui.Mode
+	}{
+		"sprint": {
+			mode: tui.ModeSprint,
+		},
+		"survival": {
+			mode: tui.ModeSurvival,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			m, err := NewSingleModel(
+				tui.NewSingleInput(tc.mode, 1, "testuser"),
+				&config.Config{
+					GhostEnabled: true,
+					Survival: config.Survival{
+						GarbageInterval:     10 * time.Second,
+						MinGarbageInterval:  time.Second,
+						GarbageAcceleration: 0.9,
+					},
+					Theme: config.DefaultTheme(),
+					Keys:  config.DefaultKeys(),
+				},
+				WithRandSource(rand.New(rand.NewPCG(0, 0))),
+			)
+			require.NoError(t, err)
+
+			elapsed := time.Minute
+			mockGameStopwatch := components.NewMockStopwatch(t)
+			mockGameStopwatch.EXPECT().Update(mock.Anything).Return(mockGameStopwatch, nil)
+			mockGameStopwatch.EXPECT().Elapsed().RunAndReturn(func() time.Duration { return elapsed })
+			mockGameStopwatch.EXPECT().Stop().Return(nil)
+			m.gameStopwatch = mockGameStopwatch
+
+			m.triggerGameOver()
+
+			// Time spent on the game-over screen is not saved.
+			elapsed += time.Minute
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+
+			in := m.leaderboardInput()
+			require.NotNil(t, in.NewEntry)
+			assert.Equal(t, time.Minute, in.NewEntry.Time)
+		})
+	}
+}
+
+func TestSingle_Hint(t *testing.T) {
+	m, err := NewSingleModel(
+		tui.NewSingleInput(tui.ModeMarathon, 1, "testuser"),
+		&config.Config{
+			GhostEnabled: true,
+			Theme:        config.DefaultTheme(),
+			Keys:         config.DefaultKeys(),
+		},
+		WithRandSource(rand.New(rand.NewPCG(0, 0))),
+	)
+	require.NoError(t, err)
+
+	_, cmd := m.playingKeyMsgUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	require.NotNil(t, cmd)
+	assert.True(t, m.hinting)
+	assert.Equal(t, 1, m.hints)
+
+	msg := cmd()
+	hint, ok := msg.(singleHint)
+	require.True(t, ok)
+	require.NoError(t, hint.err)
+	m.Update(msg)
+	assert.False(t, m.hinting)
+	require.NotNil(t, m.hint)
+
+	// The hinted placement rests on the floor of the empty matrix.
+	visible, err := m.game.GetVisibleMatrix()
+	require.NoError(t, err)
+	matrix := m.withPlacementCells(visible, m.hint.move.Placement, hintCell)
+	var hinted int
+	for _, row := range matrix {
+		for _, cell := range row {
+			if cell == hintCell {
+				hinted++
+			}
+		}
+	}
+	assert.Equal(t, 4, hinted)
+	assert.Contains(t, matrix[len(matrix)-1], hintCell)
+	assert.Contains(t, m.informationView(), "Unranked")
+
+	// The hint is removed once a Tetrimino is placed.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	assert.Nil(t, m.hint)
+
+	// Games with hints are not saved to the leaderboard.
+	m.triggerGameOver()
+	_, cmd = m.gameOverUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	switchModeMsg, ok := cmd().(tui.SwitchModeMsg)
+	require.True(t, ok)
+	leaderboardInput, ok := switchModeMsg.Input.(*tui.LeaderboardInput)
+	require.True(t, ok)
+	assert.Nil(t, leaderboardInput.NewEntry)
+}
+
+func TestSingle_HintUnavailable(t *testing.T) {
+	tt := map[string]struct {
+		in   *tui.SingleInput
+		opts []func(*SingleModel)
+	}{
+		"big": {
+			in: tui.NewSingleInput(tui.ModeMarathon, 1, "testuser", tui.WithModifiers(false, true)),
+		},
+		"finesse target": {
+			in:   tui.NewSingleInput(tui.ModeFinesse, 1, "testuser"),
+			opts: []func(*SingleModel){withTarget(&tetris.GetValidTetriminos()[0])},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			m, err := NewSingleModel(tc.in, &config.Config{
+				Theme: config.DefaultTheme(),
+				Keys:  config.DefaultKeys(),
+			}, tc.opts...)
+			require.NoError(t, err)
+
+			_, cmd := m.playingKeyMsgUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+			assert.Nil(t, cmd)
+			assert.Zero(t, m.hints)
+		})
+	}
+}
+
+func TestSingle_RecordTurns(t *testing.T) {
+	m, err := NewSingleModel(
+		tui.NewSingleInput(tui.ModeMarathon, 1, "testuser"),
+		&config.Config{
+			GhostEnabled: true,
+			Theme:        config.DefaultTheme(),
+			Keys:         config.DefaultKeys(),
+		},
+		WithRandSource(rand.New(rand.NewPCG(0, 0))),
+	)
+	require.NoError(t, err)
+
+	first := m.game.GetTetInPlay().Value
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	// Hold, then place the Tetrimino which was swapped in.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(" ")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+
+	require.Len(t, m.turns, 2)
+	assert.Equal(t, first, m.turns[0].Position.Current.Value)
+	assert.Equal(t, first, m.turns[0].Played.Placement.Value)
+	assert.False(t, m.turns[0].Played.Hold)
+	assert.True(t, m.turns[1].Played.Hold)
+	assert.NotEqual(t, m.turns[1].Position.Current.Value, m.turns[1].Played.Placement.Value)
+	// Each position is taken before its Tetrimino is placed.
+	assert.False(t, m.turns[1].Position.Matrix.IsEmpty())
+
+	m.triggerGameOver()
+	assert.Contains(t, m.View(), "or REVIEW to review.")
+	_, cmd := m.gameOverUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	assert.Nil(t, cmd)
+	_, cmd = m.gameOverUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	require.NotNil(t, cmd)
+	switchModeMsg, ok := cmd().(tui.SwitchModeMsg)
+	require.True(t, ok)
+	assert.Equal(t, tui.ModeReview, switchModeMsg.Target)
+	reviewIn, ok := switchModeMsg.Input.(*tui.ReviewInput)
+	require.True(t, ok)
+	assert.Equal(t, m.turns, reviewIn.Turns)
+	assert.Equal(t, m.game.GetSkyline(), reviewIn.Skyline)
+	// The score is saved once the review is exited.
+	require.NotNil(t, reviewIn.Leaderboard)
+	assert.NotNil(t, reviewIn.Leaderboard.NewEntry)
+}
+
+func TestSingle_RecordTurnsSameTypeHold(t *testing.T) {
+	m, err := NewSingleModel(
+		tui.NewSingleInput(tui.ModeMarathon, 1, "testuser"),
+		&config.Config{Theme: config.DefaultTheme(), Keys: config.DefaultKeys()},
+	)
+	require.NoError(t, err)
+	m.game, err = single.NewGame(&single.Input{Level: 1, Sequence: []byte("TTTT")})
+	require.NoError(t, err)
+	m.turnPos = solver.NewPosition(m.game)
+
+	// Hold, which swaps in another T, then place it.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(" ")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+
+	require.Len(t, m.turns, 2)
+	assert.True(t, m.turns[0].Played.Hold)
+	assert.False(t, m.turns[1].Played.Hold)
+}
@@ -0,0 +1,308 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Broderick-Westrope/tetrigo/internal/config"
+	"github.com/Broderick-Westrope/tetrigo/internal/tui"
+	"github.com/Broderick-Westrope/tetrigo/internal/tui/components"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/versus"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+// versusOpponentWidth is the width of the space beside the game for the opponent's matrix.
+const versusOpponentWidth = 26
+
+var _ tea.Model = &VersusModel{}
+
+// VersusModel plays a game against the solver. Both games are dealt Tetriminos from the same seed, and each player
+// sends garbage to the other by clearing lines (see versus.Attack). The solver places Tetriminos at the pace chosen in
+// config, and the game ends when either player tops out or the solver cannot move.
+type VersusModel struct {
+	child    *SingleModel
+	opponent *single.Game
+	solver   solver.Solver
+	interval time.Duration // The time between the solver's placements.
+	keys     *components.GameKeyMap
+
+	playerAttacker   versus.Attacker
+	opponentAttacker versus.Attacker
+	playerPlaced     int // The number of Tetriminos the player has placed and attacked with.
+	playerSent       int // The garbage rows the player has sent.
+	opponentSent     int // The garbage rows the solver has sent.
+
+	finished bool   // Whether a player has won.
+	won      bool   // Whether the player won.
+	notice   string // The outcome of the game.
+
+	width  int
+	height int
+}
+
+// versusTickMsg is sent when it is time for the solver to place its next Tetrimino in the game.
+type versusTickMsg struct {
+	game *single.Game
+}
+
+// versusMoveMsg is the move the solver chose in the game.
+type versusMoveMsg struct {
+	game *single.Game
+	move solver.Move
+	err  error
+}
+
+func NewVersusModel(in *tui.SingleInput, cfg *config.Config) (*VersusModel, error) {
+	seed := in.Seed
+	if seed == 0 {
+		seed = rand.Uint64() //nolint:gosec // This random source is not for any security-related tasks.
+	}
+
+	//nolint:gosec // This random source is not for any security-related tasks.
+	child, err := NewSingleModel(in, cfg, WithRandSource(rand.New(rand.NewPCG(seed, seed))))
+	if err != nil {
+		return nil, fmt.Errorf("creating single model for versus: %w", err)
+	}
+
+	gameIn := versusGameInput(in, cfg)
+	//nolint:gosec // This random source is not for any security-related tasks.
+	gameIn.Rand = rand.New(rand.NewPCG(seed, seed))
+	err = applyConfigRules(gameIn, cfg)
+	if err != nil {
+		return nil, err
+	}
+	opponent, err := single.NewGame(gameIn)
+	if err != nil {
+		return nil, fmt.Errorf("creating opponent's game: %w", err)
+	}
+	weights, err := cfg.GetSolverWeights()
+	if err != nil {
+		return nil, fmt.Errorf("getting solver weights: %w", err)
+	}
+
+	return &VersusModel{
+		child:    child,
+		opponent: opponent,
+		solver:   solver.NewBeamSearch(weights, solver.WithBeamDepth(cfg.Versus.Depth)),
+		interval: time.Duration(float64(time.Second) / cfg.Versus.PiecesPerSecond),
+		keys:     components.ConstructGameKeyMap(cfg.Keys),
+	}, nil
+}
+
+// versusGameInput returns the input for the games of both players in versus, before the rules chosen in config.
+func versusGameInput(in *tui.SingleInput, cfg *config.Config) *single.Input {
+	return &single.Input{
+		Level:         in.Level,
+		MaxLevel:      cfg.MaxLevel,
+		IncreaseLevel: true,
+
+		GhostEnabled: cfg.GhostEnabled,
+	}
+}
+
+func (m *VersusModel) Init() tea.Cmd {
+	return tea.Batch(m.child.Init(), m.tick())
+}
+
+func (m *VersusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		_, cmd := m.child.Update(m.childSizeMsg())
+		return m, cmd
+
+	case versusTickMsg:
+		if msg.game != m.opponent || m.finished {
+			return m, nil
+		}
+		// The solver waits while the game is paused.
+		if m.child.isPaused {
+			return m, m.tick()
+		}
+		return m, m.solve()
+
+	case versusMoveMsg:
+		if msg.game != m.opponent || m.finished {
+			return m, nil
+		}
+		return m, m.opponentMoveUpdate(msg)
+
+	case tea.KeyMsg:
+		if m.finished {
+			return m.finishedKeyMsgUpdate(msg)
+		}
+	}
+
+	_, cmd := m.child.Update(msg)
+	if m.finished {
+		return m, cmd
+	}
+	return m, tea.Batch(cmd, m.playerUpdate())
+}
+
+// tick returns a command which asks the solver for its next move once the interval has passed.
+func (m *VersusModel) tick() tea.Cmd {
+	game := m.opponent
+	return tea.Tick(m.interval, func(time.Time) tea.Msg {
+		return versusTickMsg{game: game}
+	})
+}
+
+// solve returns a command which searches for the solver's next move. The position is taken now, after the entry
+// delay of the opponent's game is ended, and the game only changes once the move is played.
+func (m *VersusModel) solve() tea.Cmd {
+	if m.opponent.IsInEntryDelay() {
+		gameOver, err := m.opponent.TickLower()
+		if err != nil {
+			return tui.FatalErrorCmd(fmt.Errorf("ending the opponent's entry delay: %w", err))
+		}
+		if gameOver {
+			return m.finish(true)
+		}
+	}
+
+	game := m.opponent
+	s := m.solver
+	pos := solver.NewPosition(game)
+	return func() tea.Msg {
+		move, err := s.Solve(context.Background(), pos)
+		return versusMoveMsg{game: game, move: move, err: err}
+	}
+}
+
+// opponentMoveUpdate plays the solver's move and sends the garbage it attacks with to the player. The player wins if
+// the solver tops out or cannot move.
+func (m *VersusModel) opponentMoveUpdate(msg versusMoveMsg) tea.Cmd {
+	if errors.Is(msg.err, solver.ErrNoMoves) {
+		return m.finish(true)
+	} else if msg.err != nil {
+		return tui.FatalErrorCmd(fmt.Errorf("choosing the opponent's move: %w", msg.err))
+	}
+
+	gameOver, err := solver.Play(m.opponent, msg.move)
+	if err != nil {
+		return tui.FatalErrorCmd(fmt.Errorf("playing the opponent's move: %w", err))
+	}
+	if gameOver {
+		return m.finish(true)
+	}
+
+	attack, err := m.opponentAttacker.Placed(m.opponent)
+	if err != nil {
+		return tui.FatalErrorCmd(fmt.Errorf("working out the opponent's attack: %w", err))
+	}
+	m.child.game.QueueGarbage(attack)
+	m.opponentSent += attack
+	return m.tick()
+}
+
+// playerUpdate sends the garbage the player's last Tetrimino attacks with to the solver. The solver wins if the player
+// has topped out.
+func (m *VersusModel) playerUpdate() tea.Cmd {
+	game := m.child.game
+	if game.GetPiecesPlaced() > m.playerPlaced {
+		m.playerPlaced = game.GetPiecesPlaced()
+		attack, err := m.playerAttacker.Placed(game)
+		if err != nil {
+			return tui.FatalErrorCmd(fmt.Errorf("working out the player's attack: %w", err))
+		}
+		m.opponent.QueueGarbage(attack)
+		m.playerSent += attack
+	}
+
+	if game.IsGameOver() {
+		return m.finish(false)
+	}
+	return nil
+}
+
+// finish ends the game, and stops the player's game if it is still being played.
+func (m *VersusModel) finish(won bool) tea.Cmd {
+	m.finished = true
+	m.won = won
+
+	m.notice = "The solver won."
+	if won {
+		m.notice = "You won!"
+	}
+	m.notice += " Press HOLD or EXIT to return to the menu"
+	if len(m.child.turns) > 0 {
+		m.notice += ", or REVIEW to review"
+	}
+	m.notice += "."
+
+	if m.child.game.IsGameOver() {
+		return nil
+	}
+	return m.child.triggerGameOver()
+}
+
+// finishedKeyMsgUpdate handles key presses once the game has ended.
+// Hold and Exit return to the menu, and Review reviews the player's placements.
+func (m *VersusModel) finishedKeyMsgUpdate(msg tea.KeyMsg) (*VersusModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Exit, m.keys.Hold):
+		return m, tui.SwitchModeCmd(tui.ModeMenu, tui.NewMenuInput())
+
+	case key.Matches(msg, m.keys.Review) && len(m.child.turns) > 0:
+		return m, tui.SwitchModeCmd(tui.ModeReview,
+			tui.NewReviewInput(m.child.turns, m.child.game.GetSkyline(), nil),
+		)
+	}
+	return m, nil
+}
+
+// childSizeMsg returns the size of the space left for the game by the header, notice and opponent's matrix.
+func (m *VersusModel) childSizeMsg() tea.WindowSizeMsg {
+	return tea.WindowSizeMsg{Width: max(m.width-versusOpponentWidth, 0), Height: max(m.height-2, 0)}
+}
+
+func (m *VersusModel) View() string {
+	header := fmt.Sprintf("Versus (vs Solver) - Sent: %d, Received: %d", m.playerSent, m.opponentSent)
+
+	output := lipgloss.JoinVertical(lipgloss.Center,
+		lipgloss.NewStyle().Bold(true).Render(header),
+		m.notice,
+		lipgloss.JoinHorizontal(lipgloss.Center,
+			m.child.View(),
+			lipgloss.NewStyle().Width(versusOpponentWidth).Render(m.opponentView()),
+		),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, output)
+}
+
+// opponentView returns the visible matrix of the solver's game, with its lines cleared and pending garbage.
+func (m *VersusModel) opponentView() string {
+	matrix, err := m.opponent.GetVisibleMatrix()
+	if err != nil {
+		return "** FAILED TO BUILD OPPONENT MATRIX VIEW **"
+	}
+
+	lines := make([]string, 0, len(matrix))
+	for row := range matrix {
+		var line string
+		for _, cell := range matrix[row] {
+			line += m.child.renderCell(cell)
+		}
+		lines = append(lines, line)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Center,
+		"Solver",
+		m.child.styles.Playfield.Render(strings.Join(lines, "\n")),
+		fmt.Sprintf("Lines: %d  Pending: %d", m.opponent.GetLinesCleared(), m.opponent.GetPendingGarbage()),
+	)
+}
@@ -0,0 +1,134 @@
+package views
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/internal/config"
+	"github.com/Broderick-Westrope/tetrigo/internal/tui"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+func newTestVersusModel(t *testing.T) *VersusModel {
+	m, err := NewVersusModel(
+		tui.NewSingleInput(tui.ModeVersus, 1, "testuser", tui.WithSeed(1)),
+		&config.Config{
+			NextQueueLength: 5,
+			GhostEnabled:    true,
+			RotationSystem:  "SRS",
+			Versus:          config.Versus{PiecesPerSecond: 1, Depth: 2},
+			Theme:           config.DefaultTheme(),
+			Keys:            config.DefaultKeys(),
+		},
+	)
+	require.NoError(t, err)
+	return m
+}
+
+// newTestWellGame returns a game of I Tetriminos whose bottom 8 rows are full except for the rightmost column, so each
+// I Tetrimino dropped there clears 4 lines.
+func newTestWellGame(t *testing.T) *single.Game {
+	matrix := tetris.DefaultMatrix()
+	for row := len(matrix) - 8; row < len(matrix); row++ {
+		for col := range len(matrix[row]) - 1 {
+			matrix[row][col] = tetris.GarbageCell
+		}
+	}
+	game, err := single.NewGame(&single.Input{Level: 1, Matrix: matrix, Sequence: []byte{'I', 'I', 'I', 'I'}})
+	require.NoError(t, err)
+	return game
+}
+
+func TestVersus_Garbage(t *testing.T) {
+	m := newTestVersusModel(t)
+	m.child.game = newTestWellGame(t)
+	m.opponent = newTestWellGame(t)
+	press := func(keys ...string) {
+		for _, k := range keys {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		}
+	}
+
+	// The player's Tetris is sent to the solver.
+	press("e", "d", "d", "d", "d", "d", "w")
+	assert.Equal(t, 4, m.playerSent)
+	assert.Equal(t, 4, m.opponent.GetPendingGarbage())
+
+	// The solver's Tetris cancels the garbage it was sent, and is sent to the player.
+	cmd := m.solve()
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(versusMoveMsg)
+	require.True(t, ok)
+	_, cmd = m.Update(msg)
+	assert.NotNil(t, cmd, "the next move should be scheduled")
+	assert.Equal(t, 1, m.opponent.GetPiecesPlaced())
+	assert.Equal(t, 0, m.opponent.GetPendingGarbage())
+	assert.Equal(t, 4, m.opponentSent)
+	assert.Equal(t, 4, m.child.game.GetPendingGarbage())
+	assert.Contains(t, m.View(), "Sent: 4, Received: 4")
+	assert.False(t, m.finished)
+}
+
+func TestVersus_Finish(t *testing.T) {
+	tt := map[string]struct {
+		finish  func(m *VersusModel)
+		wantWon bool
+	}{
+		"the player tops out": {
+			finish: func(m *VersusModel) {
+				m.child.game.EndGame()
+				m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+			},
+			wantWon: false,
+		},
+		"the solver cannot move": {
+			finish: func(m *VersusModel) {
+				m.Update(versusMoveMsg{game: m.opponent, err: solver.ErrNoMoves})
+			},
+			wantWon: true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			m := newTestVersusModel(t)
+			tc.finish(m)
+			require.True(t, m.finished)
+			assert.Equal(t, tc.wantWon, m.won)
+			assert.True(t, m.child.game.IsGameOver())
+			if tc.wantWon {
+				assert.Contains(t, m.View(), "You won!")
+			} else {
+				assert.Contains(t, m.View(), "The solver won.")
+			}
+
+			// The solver stops once the game has ended.
+			_, cmd := m.Update(versusTickMsg{game: m.opponent})
+			assert.Nil(t, cmd)
+
+			// Hold returns to the menu.
+			_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			require.NotNil(t, cmd)
+			switchModeMsg, ok := cmd().(tui.SwitchModeMsg)
+			require.True(t, ok)
+			assert.Equal(t, tui.ModeMenu, switchModeMsg.Target)
+		})
+	}
+}
+
+func TestVersus_StaleMsg(t *testing.T) {
+	m := newTestVersusModel(t)
+	other := newTestVersusModel(t)
+
+	// Messages from the games of other models are ignored.
+	_, cmd := m.Update(versusTickMsg{game: other.opponent})
+	assert.Nil(t, cmd)
+	_, cmd = m.Update(versusMoveMsg{game: other.opponent, err: solver.ErrNoMoves})
+	assert.Nil(t, cmd)
+	assert.False(t, m.finished)
+}
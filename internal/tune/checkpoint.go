@@ -0,0 +1,126 @@
+package tune
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+)
+
+// dimensions is the number of weights in a weight vector.
+var dimensions = len(eval.Weights{}.Vector())
+
+// checkpoint is the state of a tuning run after a generation.
+type checkpoint struct {
+	Algorithm    string `json:"algorithm"`
+	Population   int    `json:"population"`
+	GamesPerEval int    `json:"games_per_eval"`
+	MaxPieces    int    `json:"max_pieces"`
+	Seed         uint64 `json:"seed"`
+
+	Generation  int       `json:"generation"`   // The number of generations evolved.
+	Best        []float64 `json:"best"`         // The fittest weight vector found.
+	BestFitness float64   `json:"best_fitness"` // The fitness of the best weight vector.
+	Rand        []byte    `json:"rand"`         // The state of the random source of the algorithm.
+
+	GA    *geneticAlgorithm `json:"ga,omitempty"`
+	CMAES *cmaes            `json:"cmaes,omitempty"`
+}
+
+// newState returns the state of a new tuning run.
+func newState(in *Input) (*checkpoint, error) {
+	state := &checkpoint{
+		Algorithm:    in.Algorithm,
+		Population:   in.Population,
+		GamesPerEval: in.GamesPerEval,
+		MaxPieces:    in.MaxPieces,
+		Seed:         in.Seed,
+	}
+	switch in.Algorithm {
+	case AlgorithmGA:
+		state.GA = newGeneticAlgorithm(in.Population)
+	case AlgorithmCMAES:
+		state.CMAES = newCMAES(in.Population)
+	default:
+		return nil, fmt.Errorf("unknown algorithm %q", in.Algorithm)
+	}
+	return state, nil
+}
+
+// loadCheckpoint reads the checkpoint at the given path.
+func loadCheckpoint(path string) (*checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading checkpoint: %w", err)
+	}
+	var state checkpoint
+	if err = json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("decoding checkpoint: %w", err)
+	}
+	if (state.GA == nil) == (state.CMAES == nil) {
+		return nil, fmt.Errorf("checkpoint %q must have the state of exactly one algorithm", path)
+	}
+	return &state, nil
+}
+
+// compatible returns an error if the checkpoint cannot be resumed with the input. The games which measure fitness
+// must be the same, otherwise the fitness of the best weights could not be compared with those found after resuming.
+func (c *checkpoint) compatible(in *Input) error {
+	switch {
+	case c.Algorithm != in.Algorithm:
+		return fmt.Errorf("checkpoint uses algorithm %q, not %q", c.Algorithm, in.Algorithm)
+	case c.Population != in.Population:
+		return fmt.Errorf("checkpoint has a population of %d, not %d", c.Population, in.Population)
+	case c.GamesPerEval != in.GamesPerEval:
+		return fmt.Errorf("checkpoint plays %d games per evaluation, not %d", c.GamesPerEval, in.GamesPerEval)
+	case c.MaxPieces != in.MaxPieces:
+		return fmt.Errorf("checkpoint plays games of at most %d pieces, not %d", c.MaxPieces, in.MaxPieces)
+	case c.Seed != in.Seed:
+		return fmt.Errorf("checkpoint has seed %d, not %d", c.Seed, in.Seed)
+	}
+	return nil
+}
+
+// source returns the random source of the algorithm, continuing from the checkpoint if it has one.
+func (c *checkpoint) source() (*rand.PCG, error) {
+	src := rand.NewPCG(c.Seed, c.Seed)
+	if c.Rand != nil {
+		if err := src.UnmarshalBinary(c.Rand); err != nil {
+			return nil, fmt.Errorf("restoring random source: %w", err)
+		}
+	}
+	return src, nil
+}
+
+// optimiser returns the algorithm of the checkpoint.
+func (c *checkpoint) optimiser() optimiser {
+	if c.GA != nil {
+		return c.GA
+	}
+	return c.CMAES
+}
+
+// save writes the checkpoint to the given path, with the state of the random source. The file is replaced
+// atomically, so an interrupted save does not lose the last checkpoint.
+func (c *checkpoint) save(path string, src *rand.PCG) error {
+	var err error
+	c.Rand, err = src.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("saving random source: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding checkpoint: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("replacing checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package tune
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+)
+
+// cmaesInitialStepSize is the standard deviation of the first generation.
+const cmaesInitialStepSize = 0.5
+
+var _ optimiser = &cmaes{}
+
+// cmaes is a sep-CMA-ES: an evolution strategy which samples each generation from a normal distribution whose mean,
+// step size and (diagonal) covariance are adapted towards the fittest half of the last generation.
+// See Hansen, "The CMA Evolution Strategy: A Tutorial" (2016), and Ros & Hansen, "A Simple Modification in CMA-ES
+// Achieving Linear Time and Space Complexity" (2008). The search starts at the El-Tetris weights.
+type cmaes struct {
+	Size       int       `json:"size"`
+	Generation int       `json:"generation"`
+	Mean       []float64 `json:"mean"`
+	StepSize   float64   `json:"step_size"`
+	Covariance []float64 `json:"covariance"` // The diagonal of the covariance matrix.
+	PathC      []float64 `json:"path_c"`     // The evolution path of the covariance.
+	PathSigma  []float64 `json:"path_sigma"` // The evolution path of the step size.
+}
+
+func newCMAES(size int) *cmaes {
+	c := &cmaes{
+		Size:       size,
+		Mean:       normalise(eval.ElTetris().Vector()),
+		StepSize:   cmaesInitialStepSize,
+		Covariance: make([]float64, dimensions),
+		PathC:      make([]float64, dimensions),
+		PathSigma:  make([]float64, dimensions),
+	}
+	for i := range c.Covariance {
+		c.Covariance[i] = 1
+	}
+	return c
+}
+
+func (c *cmaes) ask(r *rand.Rand) [][]float64 {
+	population := make([][]float64, c.Size)
+	for k := range population {
+		x := make([]float64, len(c.Mean))
+		for i := range x {
+			x[i] = c.Mean[i] + c.StepSize*math.Sqrt(c.Covariance[i])*r.NormFloat64()
+		}
+		population[k] = x
+	}
+	return population
+}
+
+func (c *cmaes) tell(_ *rand.Rand, population [][]float64, fitness []float64) {
+	n := float64(len(c.Mean))
+
+	// The fittest half are recombined with weights decreasing by rank.
+	mu := len(population) / 2
+	weights := make([]float64, mu)
+	total := 0.0
+	for i := range weights {
+		weights[i] = math.Log(float64(mu)+0.5) - math.Log(float64(i+1))
+		total += weights[i]
+	}
+	sumSquares := 0.0
+	for i := range weights {
+		weights[i] /= total
+		sumSquares += weights[i] * weights[i]
+	}
+	muEff := 1 / sumSquares
+
+	cSigma := (muEff + 2) / (n + muEff + 5)
+	dSigma := 1 + 2*math.Max(0, math.Sqrt((muEff-1)/(n+1))-1) + cSigma
+	cc := (4 + muEff/n) / (n + 4 + 2*muEff/n)
+	// The learning rates of a diagonal covariance can be larger, since it has fewer parameters.
+	c1 := 2 / ((n+1.3)*(n+1.3) + muEff) * (n + 2) / 3
+	cMu := math.Min(1-c1, 2*(muEff-2+1/muEff)/((n+2)*(n+2)+muEff)*(n+2)/3)
+	chiN := math.Sqrt(n) * (1 - 1/(4*n) + 1/(21*n*n))
+
+	// The steps of the fittest, relative to the mean and scaled by the step size.
+	order := rankByFitness(fitness)
+	steps := make([][]float64, mu)
+	meanStep := make([]float64, len(c.Mean))
+	for i := range steps {
+		steps[i] = make([]float64, len(c.Mean))
+		for j := range steps[i] {
+			steps[i][j] = (population[order[i]][j] - c.Mean[j]) / c.StepSize
+			meanStep[j] += weights[i] * steps[i][j]
+		}
+	}
+
+	pathSigmaLength := 0.0
+	for j := range c.Mean {
+		c.Mean[j] += c.StepSize * meanStep[j]
+		c.PathSigma[j] = (1-cSigma)*c.PathSigma[j] +
+			math.Sqrt(cSigma*(2-cSigma)*muEff)*meanStep[j]/math.Sqrt(c.Covariance[j])
+		pathSigmaLength += c.PathSigma[j] * c.PathSigma[j]
+	}
+	pathSigmaLength = math.Sqrt(pathSigmaLength)
+
+	// The covariance path is stalled while the step size is growing quickly, to stop the covariance growing too fast.
+	c.Generation++
+	hSigma := 0.0
+	if pathSigmaLength/math.Sqrt(1-math.Pow(1-cSigma, 2*float64(c.Generation))) < (1.4+2/(n+1))*chiN {
+		hSigma = 1
+	}
+
+	for j := range c.Mean {
+		c.PathC[j] = (1-cc)*c.PathC[j] + hSigma*math.Sqrt(cc*(2-cc)*muEff)*meanStep[j]
+		rankMu := 0.0
+		for i := range steps {
+			rankMu += weights[i] * steps[i][j] * steps[i][j]
+		}
+		c.Covariance[j] = (1-c1-cMu)*c.Covariance[j] +
+			c1*(c.PathC[j]*c.PathC[j]+(1-hSigma)*cc*(2-cc)*c.Covariance[j]) +
+			cMu*rankMu
+	}
+	c.StepSize *= math.Exp((cSigma / dSigma) * (pathSigmaLength/chiN - 1))
+}
@@ -0,0 +1,122 @@
+package tune
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+// evaluate returns the fitness of each weight vector of the population, which is the mean lines it clears per game.
+// The games are played in parallel. Every weight vector of a generation plays the same games, which are seeded by the
+// seed of the input and the generation so the results are the same however many workers there are.
+func evaluate(ctx context.Context, population [][]float64, in *Input, generation int) ([]float64, error) {
+	type job struct {
+		individual int
+		game       int
+	}
+	jobs := make(chan job)
+	lines := make([][]int, len(population))
+	for i := range lines {
+		lines[i] = make([]int, in.GamesPerEval)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	for range in.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				seed := in.Seed + uint64(generation*in.GamesPerEval+j.game) //nolint:gosec // Both are non-negative.
+				n, err := playGame(ctx, population[j.individual], seed, in.MaxPieces)
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				lines[j.individual][j.game] = n
+			}
+		}()
+	}
+
+feed:
+	for i := range population {
+		for g := range in.GamesPerEval {
+			select {
+			case jobs <- job{individual: i, game: g}:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	fitness := make([]float64, len(population))
+	for i := range lines {
+		total := 0
+		for _, n := range lines[i] {
+			total += n
+		}
+		fitness[i] = float64(total) / float64(in.GamesPerEval)
+	}
+	return fitness, nil
+}
+
+// playGame plays a game with the greedy solver using the weights, until it is over or the most Tetriminos have been
+// placed, and returns the lines cleared.
+func playGame(ctx context.Context, vector []float64, seed uint64, maxPieces int) (int, error) {
+	weights, err := eval.WeightsFromVector(vector)
+	if err != nil {
+		return 0, err
+	}
+	game, err := single.NewGame(&single.Input{
+		Level: 1,
+		//nolint:gosec // This random source is not for any security-related tasks.
+		Rand: rand.New(rand.NewPCG(seed, seed)),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("creating game: %w", err)
+	}
+
+	for game.GetPiecesPlaced() < maxPieces {
+		if err = ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		move, err := solver.Greedy(solver.NewPosition(game), weights)
+		if errors.Is(err, solver.ErrNoMoves) {
+			break
+		} else if err != nil {
+			return 0, fmt.Errorf("choosing move: %w", err)
+		}
+
+		gameOver, err := solver.Play(game, move)
+		if err != nil {
+			return 0, fmt.Errorf("playing move: %w", err)
+		}
+		if gameOver {
+			break
+		}
+	}
+	return game.GetLinesCleared(), nil
+}
@@ -0,0 +1,117 @@
+package tune
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+)
+
+const (
+	// gaTournamentSize is the number of weight vectors which compete to be chosen as a parent.
+	gaTournamentSize = 3
+	// gaMutationRate is the chance each weight of a child is mutated.
+	gaMutationRate = 0.1
+	// gaMutationSize is the standard deviation of a mutation.
+	gaMutationSize = 0.2
+)
+
+var _ optimiser = &geneticAlgorithm{}
+
+// geneticAlgorithm breeds each generation from the last. The fittest tenth of a generation survives unchanged, and the
+// rest are children of parents chosen by tournament selection, with uniform crossover and gaussian mutation.
+// Weight vectors are kept at unit length, since scaling the weights does not change which placement is best. The first
+// generation is random, apart from the El-Tetris weights so the search starts from at least one capable player.
+type geneticAlgorithm struct {
+	Size       int         `json:"size"`
+	Population [][]float64 `json:"population"`
+}
+
+func newGeneticAlgorithm(size int) *geneticAlgorithm {
+	return &geneticAlgorithm{Size: size}
+}
+
+func (ga *geneticAlgorithm) ask(r *rand.Rand) [][]float64 {
+	if ga.Population == nil {
+		ga.Population = make([][]float64, ga.Size)
+		ga.Population[0] = normalise(eval.ElTetris().Vector())
+		for i := 1; i < len(ga.Population); i++ {
+			v := make([]float64, dimensions)
+			for j := range v {
+				v[j] = r.NormFloat64()
+			}
+			ga.Population[i] = normalise(v)
+		}
+	}
+	return ga.Population
+}
+
+func (ga *geneticAlgorithm) tell(r *rand.Rand, population [][]float64, fitness []float64) {
+	order := rankByFitness(fitness)
+	elites := max(1, len(population)/10)
+
+	next := make([][]float64, 0, len(population))
+	for _, i := range order[:elites] {
+		next = append(next, slices.Clone(population[i]))
+	}
+
+	tournament := func() []float64 {
+		best := r.IntN(len(population))
+		for range gaTournamentSize - 1 {
+			if i := r.IntN(len(population)); fitness[i] > fitness[best] {
+				best = i
+			}
+		}
+		return population[best]
+	}
+	for len(next) < len(population) {
+		a, b := tournament(), tournament()
+		child := make([]float64, len(a))
+		for j := range child {
+			child[j] = a[j]
+			if r.IntN(2) == 0 {
+				child[j] = b[j]
+			}
+			if r.Float64() < gaMutationRate {
+				child[j] += r.NormFloat64() * gaMutationSize
+			}
+		}
+		next = append(next, normalise(child))
+	}
+	ga.Population = next
+}
+
+// rankByFitness returns the indices of the fitness values from the fittest to the least fit. Ties keep their order.
+func rankByFitness(fitness []float64) []int {
+	order := make([]int, len(fitness))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		switch {
+		case fitness[a] > fitness[b]:
+			return -1
+		case fitness[a] < fitness[b]:
+			return 1
+		}
+		return 0
+	})
+	return order
+}
+
+// normalise scales the vector to unit length. The zero vector is returned unchanged.
+func normalise(v []float64) []float64 {
+	length := 0.0
+	for _, x := range v {
+		length += x * x
+	}
+	length = math.Sqrt(length)
+	if length == 0 {
+		return v
+	}
+	for i := range v {
+		v[i] /= length
+	}
+	return v
+}
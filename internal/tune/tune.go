@@ -0,0 +1,147 @@
+// Package tune evolves the weights of the solver's evaluator by playing headless games with them.
+package tune
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"runtime"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+)
+
+const (
+	// AlgorithmGA is a genetic algorithm, which breeds the fittest weight vectors of each generation.
+	AlgorithmGA = "ga"
+	// AlgorithmCMAES is a covariance matrix adaptation evolution strategy, which samples each generation from a normal
+	// distribution adapted towards the fittest weight vectors. Only the diagonal of the covariance matrix is adapted
+	// (sep-CMA-ES), which suits the small number of weights.
+	AlgorithmCMAES = "cmaes"
+)
+
+// Input configures a tuning run.
+type Input struct {
+	Algorithm    string // AlgorithmGA or AlgorithmCMAES.
+	Population   int    // The number of weight vectors in each generation.
+	Generations  int    // The number of generations to evolve, including those of a resumed checkpoint.
+	GamesPerEval int    // The number of games each weight vector plays to measure its fitness.
+	MaxPieces    int    // The most Tetriminos a game is played for. 0 means 1000.
+	Seed         uint64 // The seed of the games and the algorithm. Runs with the same input give the same weights.
+	Workers      int    // The number of games played at once. 0 means the number of CPUs.
+
+	Checkpoint string // The path the state is saved to after each generation. Empty means no checkpoints are saved.
+	Resume     bool   // Whether to continue from the checkpoint, if it exists.
+}
+
+// Result is the outcome of a tuning run.
+type Result struct {
+	Weights     eval.Weights // The fittest weights found.
+	Fitness     float64      // The mean lines cleared per game by the weights, in the generation they were found.
+	Generations int          // The number of generations evolved.
+}
+
+// optimiser proposes a generation of weight vectors and learns from their fitness.
+type optimiser interface {
+	// ask returns the weight vectors of the next generation.
+	ask(r *rand.Rand) [][]float64
+	// tell updates the optimiser with the fitness of each weight vector of the generation.
+	tell(r *rand.Rand, population [][]float64, fitness []float64)
+}
+
+// Run evolves weights, logging the progress of each generation. The state is saved to the checkpoint after each
+// generation, so an interrupted run can be resumed.
+func Run(ctx context.Context, in *Input, log io.Writer) (*Result, error) {
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+
+	state, err := newState(in)
+	if err != nil {
+		return nil, err
+	}
+	if in.Resume && in.Checkpoint != "" {
+		loaded, err := loadCheckpoint(in.Checkpoint)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return nil, err
+		default:
+			if err = loaded.compatible(in); err != nil {
+				return nil, fmt.Errorf("resuming from checkpoint: %w", err)
+			}
+			state = loaded
+			_, _ = fmt.Fprintf(log, "Resuming from generation %d.\n", state.Generation)
+		}
+	}
+
+	src, err := state.source()
+	if err != nil {
+		return nil, err
+	}
+	//nolint:gosec // This random source is not for any security-related tasks.
+	r := rand.New(src)
+	opt := state.optimiser()
+
+	for state.Generation < in.Generations {
+		population := opt.ask(r)
+		fitness, err := evaluate(ctx, population, in, state.Generation)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating generation %d: %w", state.Generation+1, err)
+		}
+		opt.tell(r, population, fitness)
+
+		best, mean := 0, 0.0
+		for i, f := range fitness {
+			mean += f / float64(len(fitness))
+			if f > fitness[best] {
+				best = i
+			}
+		}
+		if state.Best == nil || fitness[best] > state.BestFitness {
+			state.Best, state.BestFitness = population[best], fitness[best]
+		}
+		state.Generation++
+		_, _ = fmt.Fprintf(log, "Generation %d: best %.1f, mean %.1f lines (best so far %.1f).\n",
+			state.Generation, fitness[best], mean, state.BestFitness)
+
+		if in.Checkpoint != "" {
+			if err = state.save(in.Checkpoint, src); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	weights, err := eval.WeightsFromVector(state.Best)
+	if err != nil {
+		return nil, fmt.Errorf("converting best weights: %w", err)
+	}
+	return &Result{Weights: weights, Fitness: state.BestFitness, Generations: state.Generation}, nil
+}
+
+// validate returns an error if the input is invalid, and sets the defaults of zero values.
+func (in *Input) validate() error {
+	switch {
+	case in.Algorithm != AlgorithmGA && in.Algorithm != AlgorithmCMAES:
+		return fmt.Errorf("unknown algorithm %q", in.Algorithm)
+	case in.Population < 2:
+		return errors.New("population must be at least 2")
+	case in.Generations < 1:
+		return errors.New("generations must be at least 1")
+	case in.GamesPerEval < 1:
+		return errors.New("games per evaluation must be at least 1")
+	case in.MaxPieces < 0:
+		return errors.New("max pieces must not be negative")
+	case in.Workers < 0:
+		return errors.New("workers must not be negative")
+	}
+	if in.MaxPieces == 0 {
+		in.MaxPieces = 1000
+	}
+	if in.Workers == 0 {
+		in.Workers = runtime.NumCPU()
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package tune
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	tt := map[string]struct {
+		algorithm string
+	}{
+		"genetic algorithm": {algorithm: AlgorithmGA},
+		"cma-es":            {algorithm: AlgorithmCMAES},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			newInput := func(generations, workers int) *Input {
+				return &Input{
+					Algorithm:    tc.algorithm,
+					Population:   4,
+					Generations:  generations,
+					GamesPerEval: 2,
+					MaxPieces:    20,
+					Seed:         1,
+					Workers:      workers,
+				}
+			}
+
+			want, err := Run(context.Background(), newInput(3, 1), io.Discard)
+			require.NoError(t, err)
+			assert.Equal(t, 3, want.Generations)
+			assert.Positive(t, want.Fitness)
+
+			// The result does not depend on the number of workers.
+			got, err := Run(context.Background(), newInput(3, 4), io.Discard)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+
+			// Resuming from a checkpoint gives the same result as an uninterrupted run.
+			checkpoint := filepath.Join(t.TempDir(), "checkpoint.json")
+			in := newInput(2, 2)
+			in.Checkpoint = checkpoint
+			_, err = Run(context.Background(), in, io.Discard)
+			require.NoError(t, err)
+
+			in = newInput(3, 2)
+			in.Checkpoint, in.Resume = checkpoint, true
+			got, err = Run(context.Background(), in, io.Discard)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestRun_IncompatibleCheckpoint(t *testing.T) {
+	tt := map[string]struct {
+		change  func(in *Input)
+		wantErr string
+	}{
+		"algorithm": {
+			change:  func(in *Input) { in.Algorithm = AlgorithmCMAES },
+			wantErr: "checkpoint uses algorithm",
+		},
+		"population": {
+			change:  func(in *Input) { in.Population = 3 },
+			wantErr: "checkpoint has a population",
+		},
+		"games per evaluation": {
+			change:  func(in *Input) { in.GamesPerEval = 2 },
+			wantErr: "games per evaluation",
+		},
+		"max pieces": {
+			change:  func(in *Input) { in.MaxPieces = 6 },
+			wantErr: "at most 5 pieces",
+		},
+		"seed": {
+			change:  func(in *Input) { in.Seed = 2 },
+			wantErr: "checkpoint has seed 1",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			checkpoint := filepath.Join(t.TempDir(), "checkpoint.json")
+			in := &Input{
+				Algorithm:    AlgorithmGA,
+				Population:   2,
+				Generations:  1,
+				GamesPerEval: 1,
+				MaxPieces:    5,
+				Seed:         1,
+				Checkpoint:   checkpoint,
+			}
+			_, err := Run(context.Background(), in, io.Discard)
+			require.NoError(t, err)
+
+			in.Generations, in.Resume = 2, true
+			tc.change(in)
+			_, err = Run(context.Background(), in, io.Discard)
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Run(ctx, &Input{Algorithm: AlgorithmGA, Population: 2, Generations: 1, GamesPerEval: 1}, io.Discard)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,302 @@
+package tbp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+// Bot plays games for a frontend by asking a solver where to place each Tetrimino. It is configured with options
+// passed to NewBot.
+type Bot struct {
+	solver    solver.Solver
+	info      Info
+	thinkTime time.Duration // The most time spent choosing each move. 0 means no limit.
+}
+
+// NewBot creates a Bot which chooses moves with the solver.
+func NewBot(s solver.Solver, opts ...func(*Bot)) *Bot {
+	b := &Bot{
+		solver: s,
+		info: Info{
+			Type:     TypeInfo,
+			Name:     "tetrigo",
+			Version:  "dev",
+			Author:   "Broderick Westrope",
+			Features: []string{},
+		},
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// WithInfo sets the name, version and author the bot sends to the frontend. The default name is "tetrigo".
+func WithInfo(name, version, author string) func(*Bot) {
+	return func(b *Bot) {
+		b.info.Name, b.info.Version, b.info.Author = name, version, author
+	}
+}
+
+// WithThinkTime sets the most time spent choosing each move, after which the solver's context is done. The default of
+// 0 lets the solver finish its search.
+func WithThinkTime(d time.Duration) func(*Bot) {
+	return func(b *Bot) {
+		b.thinkTime = d
+	}
+}
+
+// Serve plays games for the frontend, reading its messages from r and writing replies to w, until the frontend quits,
+// r ends or the context is done. Messages of unknown types are ignored, as the protocol requires.
+func (b *Bot) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	c := newConn(r, w)
+	if err := c.write(b.info); err != nil {
+		return err
+	}
+
+	var state *botState
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		msgType, data, err := c.read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch msgType {
+		case TypeRules:
+			err = c.write(Ready{Type: TypeReady})
+		case TypeStart:
+			var msg Start
+			if err = json.Unmarshal(data, &msg); err != nil {
+				return fmt.Errorf("decoding start: %w", err)
+			}
+			if state, err = newBotState(&msg); err != nil {
+				return fmt.Errorf("starting game: %w", err)
+			}
+		case TypeSuggest:
+			if state == nil {
+				return errors.New("suggestion requested before the game started")
+			}
+			var moves []Move
+			if moves, err = b.suggest(ctx, state); err != nil {
+				return err
+			}
+			err = c.write(Suggestion{Type: TypeSuggestion, Moves: moves})
+		case TypePlay:
+			var msg Play
+			if err = json.Unmarshal(data, &msg); err != nil {
+				return fmt.Errorf("decoding play: %w", err)
+			}
+			if state == nil {
+				return errors.New("move played before the game started")
+			}
+			if err = state.play(msg.Move); err != nil {
+				return fmt.Errorf("playing move: %w", err)
+			}
+		case TypeNewPiece:
+			var msg NewPiece
+			if err = json.Unmarshal(data, &msg); err != nil {
+				return fmt.Errorf("decoding new piece: %w", err)
+			}
+			if state == nil {
+				return errors.New("piece added before the game started")
+			}
+			if err = state.newPiece(msg.Piece); err != nil {
+				return fmt.Errorf("adding piece: %w", err)
+			}
+		case TypeStop:
+			state = nil
+		case TypeQuit:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// suggest returns the move the solver chooses, or none if it cannot move.
+func (b *Bot) suggest(ctx context.Context, state *botState) ([]Move, error) {
+	pos, err := state.position()
+	if errors.Is(err, solver.ErrNoMoves) {
+		return []Move{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if b.thinkTime > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.thinkTime)
+		defer cancel()
+	}
+	move, err := b.solver.Solve(ctx, pos)
+	if errors.Is(err, solver.ErrNoMoves) {
+		return []Move{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("solving: %w", err)
+	}
+
+	loc, err := LocationOf(move.Placement, len(state.matrix))
+	if err != nil {
+		return nil, fmt.Errorf("locating move: %w", err)
+	}
+	return []Move{{Location: loc, Spin: "none"}}, nil
+}
+
+// botState is the game as the bot knows it.
+type botState struct {
+	matrix tetris.Matrix
+	hold   byte   // The value of the held Tetrimino, or 0 if there is none.
+	queue  []byte // The values of the Tetrimino in play and then the Next Queue.
+	bag    []byte // The values of the Tetriminos left in the 7-bag after the queue. Nil means they are unknown.
+}
+
+func newBotState(msg *Start) (*botState, error) {
+	matrix, err := matrixOf(msg.Board)
+	if err != nil {
+		return nil, err
+	}
+	state := &botState{matrix: matrix}
+	if msg.Hold != nil {
+		if state.hold, err = pieceValue(*msg.Hold); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range msg.Queue {
+		v, err := pieceValue(p)
+		if err != nil {
+			return nil, err
+		}
+		state.queue = append(state.queue, v)
+	}
+	if msg.Randomizer != nil && msg.Randomizer.Type == "seven_bag" {
+		state.bag = []byte{}
+		for _, p := range msg.Randomizer.BagState {
+			v, err := pieceValue(p)
+			if err != nil {
+				return nil, err
+			}
+			state.bag = append(state.bag, v)
+		}
+	}
+	return state, nil
+}
+
+// position returns the position to solve. If no Tetrimino can enter play, solver.ErrNoMoves is returned.
+func (s *botState) position() (*solver.Position, error) {
+	if len(s.queue) == 0 {
+		return nil, solver.ErrNoMoves
+	}
+	current, err := s.spawn(s.queue[0])
+	if err != nil {
+		return nil, err
+	}
+	if !current.IsValid(s.matrix, true) {
+		return nil, solver.ErrNoMoves
+	}
+	// Tetriminos move down one row as they enter play, as in single.Game.
+	current.MoveDown(s.matrix)
+
+	pos := &solver.Position{
+		Matrix:  *s.matrix.DeepCopy(),
+		Current: current,
+		CanHold: true,
+		Bag:     slices.Clone(s.bag),
+	}
+	for _, v := range s.queue[1:] {
+		t, err := s.spawn(v)
+		if err != nil {
+			return nil, err
+		}
+		pos.Queue = append(pos.Queue, *t)
+	}
+	if s.hold != 0 {
+		if pos.Hold, err = s.spawn(s.hold); err != nil {
+			return nil, err
+		}
+	}
+	return pos, nil
+}
+
+// spawn returns the Tetrimino where it spawns in the Matrix.
+func (s *botState) spawn(value byte) (*tetris.Tetrimino, error) {
+	t, err := tetris.GetTetrimino(value)
+	if err != nil {
+		return nil, fmt.Errorf("getting tetrimino %q: %w", value, err)
+	}
+	t.Position.Y += len(s.matrix) - tetris.DefaultDimensions.Height
+	t.Position.X = tetris.SpawnColumn(t.Position.X, len(s.matrix[0]))
+	return t, nil
+}
+
+// play locks down the Tetrimino of the move, holding first if it is not the Tetrimino in play.
+func (s *botState) play(move Move) error {
+	placement, err := Placement(move.Location, len(s.matrix))
+	if err != nil {
+		return err
+	}
+	if len(s.queue) == 0 {
+		return errors.New("no tetrimino is in play")
+	}
+	if placement.Value != s.queue[0] {
+		if s.hold == 0 {
+			s.hold, s.queue = s.queue[0], s.queue[1:]
+		} else {
+			s.hold, s.queue[0] = s.queue[0], s.hold
+		}
+		if len(s.queue) == 0 || placement.Value != s.queue[0] {
+			return fmt.Errorf("tetrimino %q is neither in play nor held", placement.Value)
+		}
+	}
+
+	if err = s.matrix.AddTetrimino(placement); err != nil {
+		return fmt.Errorf("locking down tetrimino: %w", err)
+	}
+	s.matrix.RemoveCompletedLines(placement)
+	s.queue = s.queue[1:]
+	return nil
+}
+
+// newPiece adds the Tetrimino to the end of the queue, taking it from the 7-bag if that is known.
+func (s *botState) newPiece(piece Piece) error {
+	v, err := pieceValue(piece)
+	if err != nil {
+		return err
+	}
+	s.queue = append(s.queue, v)
+
+	if s.bag == nil {
+		return nil
+	}
+	if len(s.bag) == 0 {
+		s.bag = []byte("IJLOSTZ")
+	}
+	i := slices.Index(s.bag, v)
+	if i < 0 {
+		// The Tetriminos are not dealt from a 7-bag after all.
+		s.bag = nil
+		return nil
+	}
+	s.bag = slices.Delete(s.bag, i, i+1)
+	return nil
+}
+
+// pieceValue returns the value of the Tetrimino.
+func pieceValue(p Piece) (byte, error) {
+	if _, ok := northCells[p]; !ok {
+		return 0, fmt.Errorf("invalid piece %q", p)
+	}
+	return p[0], nil
+}
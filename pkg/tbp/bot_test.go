@@ -0,0 +1,162 @@
+package tbp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+func TestBot_Serve(t *testing.T) {
+	emptyBoard, err := json.Marshal(Board(tetris.DefaultMatrix()))
+	require.NoError(t, err)
+	start := `{"type":"start","hold":null,"queue":["I","O"],"combo":0,"back_to_back":false,"board":` +
+		string(emptyBoard) + `}`
+
+	tt := map[string]struct {
+		messages  []string
+		wantTypes []string
+		wantMoves []Location
+	}{
+		"ready for the rules": {
+			messages:  []string{`{"type":"rules"}`, `{"type":"quit"}`, `{"type":"rules"}`},
+			wantTypes: []string{TypeInfo, TypeReady},
+		},
+		"unknown messages are ignored": {
+			messages:  []string{`{"type":"rules"}`, `{"type":"hello","x":1}`, `{"type":"rules"}`},
+			wantTypes: []string{TypeInfo, TypeReady, TypeReady},
+		},
+		"suggests a move for the tetrimino in play": {
+			messages:  []string{`{"type":"rules"}`, start, `{"type":"suggest"}`},
+			wantTypes: []string{TypeInfo, TypeReady, TypeSuggestion},
+			wantMoves: []Location{{Type: "I", Orientation: "north", X: 1, Y: 0}},
+		},
+		"plays moves and new pieces": {
+			messages: []string{
+				`{"type":"rules"}`, start,
+				`{"type":"play","move":{"location":{"type":"I","orientation":"north","x":1,"y":0},"spin":"none"}}`,
+				`{"type":"new_piece","piece":"I"}`,
+				`{"type":"suggest"}`,
+			},
+			wantTypes: []string{TypeInfo, TypeReady, TypeSuggestion},
+			// Holding the O swaps in the new I, which fills the rest of the bottom row.
+			wantMoves: []Location{{Type: "I", Orientation: "north", X: 5, Y: 0}},
+		},
+		"holds when the held tetrimino is placed": {
+			messages: []string{
+				`{"type":"rules"}`, start,
+				`{"type":"play","move":{"location":{"type":"O","orientation":"north","x":0,"y":0},"spin":"none"}}`,
+				`{"type":"new_piece","piece":"T"}`,
+				`{"type":"play","move":{"location":{"type":"I","orientation":"east","x":9,"y":2},"spin":"none"}}`,
+				`{"type":"new_piece","piece":"S"}`,
+				`{"type":"suggest"}`,
+			},
+			wantTypes: []string{TypeInfo, TypeReady, TypeSuggestion},
+			// Placing the O holds the I, and placing the I holds the T, so the T is placed by holding the S.
+			wantMoves: []Location{{Type: "T", Orientation: "north", X: 3, Y: 0}},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			bot := NewBot(solver.NewGreedySearch(eval.ElTetris()), WithInfo("test", "1.0", "tetrigo"))
+			err := bot.Serve(context.Background(), strings.NewReader(strings.Join(tc.messages, "\n")), &out)
+			require.NoError(t, err)
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			var types []string
+			var moves []Location
+			for _, line := range lines {
+				var msg struct {
+					Type  string `json:"type"`
+					Name  string `json:"name"`
+					Moves []Move `json:"moves"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(line), &msg))
+				types = append(types, msg.Type)
+				if msg.Type == TypeInfo {
+					assert.Equal(t, "test", msg.Name)
+				}
+				for _, m := range msg.Moves {
+					moves = append(moves, m.Location)
+				}
+			}
+			assert.Equal(t, tc.wantTypes, types)
+			assert.Equal(t, tc.wantMoves, moves)
+		})
+	}
+}
+
+func TestPlayGame(t *testing.T) {
+	tt := map[string]struct {
+		previews int
+	}{
+		"no previews": {
+			previews: 0,
+		},
+		"one preview": {
+			previews: 1,
+		},
+		"five previews": {
+			previews: 5,
+		},
+		"more previews than the next queue": {
+			previews: 20,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			game, err := single.NewGame(&single.Input{Level: 1, Rand: rand.New(rand.NewPCG(1, 1))})
+			require.NoError(t, err)
+
+			// The bot and the frontend talk over pipes, in both directions.
+			toBot, fromFrontend := io.Pipe()
+			toFrontend, fromBot := io.Pipe()
+			served := make(chan error, 1)
+			go func() {
+				bot := NewBot(solver.NewGreedySearch(eval.ElTetris()))
+				served <- bot.Serve(context.Background(), toBot, fromBot)
+				fromBot.Close()
+			}()
+
+			client, err := NewClient(toFrontend, fromFrontend)
+			require.NoError(t, err)
+			assert.Equal(t, "tetrigo", client.Info().Name)
+
+			require.NoError(t, client.Rules())
+			// Any disagreement between the bot's game and the frontend's makes a move miss its placement.
+			err = PlayGame(context.Background(), client, game, 60, tc.previews)
+			require.NoError(t, err)
+			assert.Equal(t, 60, game.GetPiecesPlaced())
+			assert.Positive(t, game.GetLinesCleared())
+
+			require.NoError(t, client.Close())
+			fromFrontend.Close()
+			require.NoError(t, <-served)
+		})
+	}
+}
+
+func TestPlayGame_UnsupportedGame(t *testing.T) {
+	game, err := single.NewGame(&single.Input{
+		Level:      1,
+		Dimensions: tetris.Dimensions{Width: 8, Height: 20, Buffer: 20},
+	})
+	require.NoError(t, err)
+
+	err = PlayGame(context.Background(), &Client{}, game, 0, 5)
+	assert.ErrorIs(t, err, ErrUnsupportedGame)
+}
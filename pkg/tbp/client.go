@@ -0,0 +1,283 @@
+package tbp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+var (
+	// ErrRulesRejected is returned when the bot cannot play with the rules of the game.
+	ErrRulesRejected = errors.New("bot rejected the rules")
+	// ErrUnsupportedGame is returned by PlayGame when the game cannot be described to a bot, since the protocol only
+	// supports a Matrix 10 wide and 40 high.
+	ErrUnsupportedGame = errors.New("game is not supported by the protocol")
+)
+
+// Client is the frontend's connection to a bot.
+type Client struct {
+	conn  *conn
+	info  Info
+	stdin io.Closer // The standard input of the bot's process, if Launch started it.
+	cmd   *exec.Cmd
+}
+
+// Launch starts the bot's process and connects to it over its standard input and output. The process is killed if
+// the context is done before Close is called.
+func Launch(ctx context.Context, name string, args ...string) (*Client, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("creating stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("creating stdout pipe: %w", err)
+	}
+	if err = cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting bot: %w", err)
+	}
+
+	c, err := NewClient(stdout, stdin)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, err
+	}
+	c.stdin, c.cmd = stdin, cmd
+	return c, nil
+}
+
+// NewClient connects to a bot which writes its messages to r and reads the frontend's from w. It waits for the bot's
+// Info.
+func NewClient(r io.Reader, w io.Writer) (*Client, error) {
+	c := &Client{conn: newConn(r, w)}
+	data, err := c.expect(TypeInfo)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &c.info); err != nil {
+		return nil, fmt.Errorf("decoding info: %w", err)
+	}
+	return c, nil
+}
+
+// Info returns the bot's name, version, author and features.
+func (c *Client) Info() Info {
+	return c.info
+}
+
+// Rules tells the bot the rules of the game, and waits until it is ready. If the bot cannot play with the rules
+// ErrRulesRejected is returned.
+func (c *Client) Rules() error {
+	if err := c.conn.write(Rules{Type: TypeRules}); err != nil {
+		return err
+	}
+	msgType, data, err := c.expectOneOf(TypeReady, TypeError)
+	if err != nil {
+		return err
+	}
+	if msgType == TypeError {
+		var msg Error
+		if err = json.Unmarshal(data, &msg); err != nil {
+			return fmt.Errorf("decoding error: %w", err)
+		}
+		return fmt.Errorf("%w: %s", ErrRulesRejected, msg.Reason)
+	}
+	return nil
+}
+
+// Start starts a game from the position.
+func (c *Client) Start(msg *Start) error {
+	msg.Type = TypeStart
+	return c.conn.write(msg)
+}
+
+// Suggest asks the bot for moves, and waits for them. No moves means the bot cannot move.
+func (c *Client) Suggest() ([]Move, error) {
+	if err := c.conn.write(Suggest{Type: TypeSuggest}); err != nil {
+		return nil, err
+	}
+	data, err := c.expect(TypeSuggestion)
+	if err != nil {
+		return nil, err
+	}
+	var msg Suggestion
+	if err = json.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("decoding suggestion: %w", err)
+	}
+	return msg.Moves, nil
+}
+
+// Play tells the bot the move which was made.
+func (c *Client) Play(move Move) error {
+	return c.conn.write(Play{Type: TypePlay, Move: move})
+}
+
+// NewPiece tells the bot the Tetrimino added to the end of the queue.
+func (c *Client) NewPiece(piece Piece) error {
+	return c.conn.write(NewPiece{Type: TypeNewPiece, Piece: piece})
+}
+
+// Stop ends the game.
+func (c *Client) Stop() error {
+	return c.conn.write(Stop{Type: TypeStop})
+}
+
+// Close tells the bot to quit. If Launch started the bot, Close waits for its process to exit.
+func (c *Client) Close() error {
+	err := c.conn.write(Quit{Type: TypeQuit})
+	if c.cmd == nil {
+		return err
+	}
+	_ = c.stdin.Close()
+	if waitErr := c.cmd.Wait(); waitErr != nil {
+		return fmt.Errorf("waiting for bot to exit: %w", waitErr)
+	}
+	return err
+}
+
+// expect returns the next message of the type. Messages of other types are ignored, as the protocol requires.
+func (c *Client) expect(msgType string) ([]byte, error) {
+	_, data, err := c.expectOneOf(msgType)
+	return data, err
+}
+
+// expectOneOf returns the next message of any of the types, and its type. Messages of other types are ignored.
+func (c *Client) expectOneOf(msgTypes ...string) (string, []byte, error) {
+	for {
+		msgType, data, err := c.conn.read()
+		if errors.Is(err, io.EOF) {
+			return "", nil, fmt.Errorf("bot closed the connection: %w", io.ErrUnexpectedEOF)
+		} else if err != nil {
+			return "", nil, err
+		}
+		for _, t := range msgTypes {
+			if t == msgType {
+				return msgType, data, nil
+			}
+		}
+	}
+}
+
+// PlayGame lets the bot play the game until it is over, the bot cannot move, the most Tetriminos have been placed (0
+// means no limit) or the context is done. The bot must be ready to play (see Rules), and can play more games after. The
+// bot is shown the given number of Tetriminos of the Next Queue, and is
+// told about each one as it is revealed. Entry delays are skipped, and the game's clock is not advanced.
+//
+// Locations are those of the Super Rotation System, which the protocol uses. The bot's moves are made with the fewest
+// inputs which reach them (see solver.Path), so a move which cannot be reached in the game returns an error.
+func PlayGame(ctx context.Context, c *Client, g *single.Game, maxPieces, previews int) error {
+	dims := g.GetDimensions()
+	if dims.Width != 10 || dims.Height+dims.Buffer != 40 {
+		return fmt.Errorf("%w: matrix is %d by %d", ErrUnsupportedGame, dims.Width, dims.Height+dims.Buffer)
+	}
+	gameOver, err := skipEntryDelay(g)
+	if err != nil || gameOver {
+		return err
+	}
+
+	queue := g.GetBagTetriminos()
+	previews = max(previews, 0)
+	start := &Start{
+		Queue: []Piece{Piece(g.GetTetInPlay().Value)},
+		Combo: g.GetCombo(),
+		Board: Board(g.GetMatrix()),
+	}
+	if hold := g.GetHoldTetrimino(); hold.Value != 0 {
+		piece := Piece(hold.Value)
+		start.Hold = &piece
+	}
+	for _, t := range queue[:min(previews, len(queue))] {
+		start.Queue = append(start.Queue, Piece(t.Value))
+	}
+	if err = c.Start(start); err != nil {
+		return err
+	}
+
+	// revealed is the number of Tetriminos at the front of the Next Queue which the bot knows about.
+	revealed := min(previews, len(queue))
+	for maxPieces == 0 || g.GetPiecesPlaced() < maxPieces {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		moves, err := c.Suggest()
+		if err != nil {
+			return err
+		}
+		if len(moves) == 0 {
+			break
+		}
+
+		placement, err := Placement(moves[0].Location, len(g.GetMatrix()))
+		if err != nil {
+			return fmt.Errorf("reading the bot's move: %w", err)
+		}
+		hold := placement.Value != g.GetTetInPlay().Value
+		// The first hold draws the held Tetrimino's replacement from the Next Queue, as does each Tetrimino entering
+		// play.
+		drawn := 1
+		if hold && g.GetHoldTetrimino().Value == 0 {
+			drawn++
+		}
+
+		gameOver, err = solver.Play(g, solver.Move{Hold: hold, Placement: placement})
+		if err != nil {
+			return fmt.Errorf("playing the bot's move: %w", err)
+		}
+		if err = c.Play(moves[0]); err != nil {
+			return err
+		}
+		if !gameOver {
+			gameOver, err = skipEntryDelay(g)
+			if err != nil {
+				return err
+			}
+		}
+		if gameOver {
+			break
+		}
+
+		if revealed, err = revealPieces(c, g, revealed-drawn, previews); err != nil {
+			return err
+		}
+	}
+	return c.Stop()
+}
+
+// skipEntryDelay spawns the next Tetrimino if the game is in an entry delay. If true is returned the game is over.
+func skipEntryDelay(g *single.Game) (bool, error) {
+	if !g.IsInEntryDelay() {
+		return false, nil
+	}
+	gameOver, err := g.TickLower()
+	if err != nil {
+		return false, fmt.Errorf("ending entry delay: %w", err)
+	}
+	return gameOver, nil
+}
+
+// revealPieces tells the bot about the Tetriminos of the Next Queue after the number it knows about, until it knows
+// about the previews, and returns the number it knows about. If the number is negative, the bot does not know about
+// the Tetrimino in play either, so it is told about that first.
+func revealPieces(c *Client, g *single.Game, known, previews int) (int, error) {
+	if known < 0 {
+		if err := c.NewPiece(Piece(g.GetTetInPlay().Value)); err != nil {
+			return 0, err
+		}
+		known = 0
+	}
+	queue := g.GetBagTetriminos()
+	for ; known < min(previews, len(queue)); known++ {
+		if err := c.NewPiece(Piece(queue[known].Value)); err != nil {
+			return 0, err
+		}
+	}
+	return known, nil
+}
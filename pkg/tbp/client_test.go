@@ -0,0 +1,93 @@
+package tbp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+// fakeBotEnv is set to make the test binary run as a fake bot instead of running tests, so the tests can launch it.
+const fakeBotEnv = "TETRIGO_FAKE_BOT"
+
+func TestMain(m *testing.M) {
+	if kind := os.Getenv(fakeBotEnv); kind != "" {
+		os.Exit(runFakeBot(kind))
+	}
+	os.Exit(m.Run())
+}
+
+// runFakeBot runs a bot over the standard input and output. A "greedy" bot plays with the greedy solver, and a
+// "picky" bot rejects every rules message.
+func runFakeBot(kind string) int {
+	if kind == "greedy" {
+		bot := NewBot(solver.NewGreedySearch(eval.ElTetris()), WithInfo("fake", "1.0", "tetrigo"))
+		if err := bot.Serve(context.Background(), os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Println(`{"type":"info","name":"picky","version":"1.0","author":"tetrigo","features":[]}`)
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		fmt.Println(`{"type":"error","reason":"unsupported_rules"}`)
+	}
+	return 0
+}
+
+func TestLaunch(t *testing.T) {
+	tt := map[string]struct {
+		kind     string
+		wantName string
+		wantErr  error
+	}{
+		"plays the game": {
+			kind:     "greedy",
+			wantName: "fake",
+		},
+		"rejects the rules": {
+			kind:     "picky",
+			wantName: "picky",
+			wantErr:  ErrRulesRejected,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(fakeBotEnv, tc.kind)
+			client, err := Launch(context.Background(), os.Args[0])
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantName, client.Info().Name)
+
+			defer func() {
+				require.NoError(t, client.Close())
+			}()
+
+			err = client.Rules()
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			// The bot can play more than one game.
+			for seed := range uint64(2) {
+				game, err := single.NewGame(&single.Input{Level: 1, Rand: rand.New(rand.NewPCG(seed, seed))})
+				require.NoError(t, err)
+				require.NoError(t, PlayGame(context.Background(), client, game, 20, 5))
+				assert.Equal(t, 20, game.GetPiecesPlaced())
+			}
+		})
+	}
+}
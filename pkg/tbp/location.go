@@ -0,0 +1,202 @@
+package tbp
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+)
+
+// garbagePiece is the Piece of a garbage cell of a board.
+const garbagePiece Piece = "G"
+
+// orientations are the orientations of a Location, indexed by the compass direction of a Tetrimino.
+var orientations = []string{"north", "east", "south", "west"}
+
+// Location is where a Tetrimino is: its type, orientation, and the column (x) and row (y, from the bottom of the
+// board) of its centre of rotation.
+type Location struct {
+	Type        Piece  `json:"type"`
+	Orientation string `json:"orientation"` // "north", "east", "south" or "west".
+	X           int    `json:"x"`
+	Y           int    `json:"y"`
+}
+
+// cell is a cell of a board, as its column and its row from the bottom.
+type cell struct {
+	x, y int
+}
+
+// northCells are the cells of each Tetrimino relative to its centre of rotation when it is facing north, which is how
+// it spawns. The cells of the other orientations are these rotated clockwise about the centre.
+var northCells = map[Piece][4]cell{
+	"I": {{-1, 0}, {0, 0}, {1, 0}, {2, 0}},
+	"O": {{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+	"T": {{-1, 0}, {0, 0}, {1, 0}, {0, 1}},
+	"L": {{-1, 0}, {0, 0}, {1, 0}, {1, 1}},
+	"J": {{-1, 0}, {0, 0}, {1, 0}, {-1, 1}},
+	"S": {{-1, 0}, {0, 0}, {0, 1}, {1, 1}},
+	"Z": {{-1, 1}, {0, 1}, {0, 0}, {1, 0}},
+}
+
+// relativeCells returns the cells of the Tetrimino relative to its centre of rotation.
+func relativeCells(piece Piece, direction int) ([4]cell, error) {
+	cells, ok := northCells[piece]
+	if !ok {
+		return cells, fmt.Errorf("invalid piece %q", piece)
+	}
+	for range direction {
+		for i, c := range cells {
+			cells[i] = cell{x: c.y, y: -c.x}
+		}
+	}
+	return cells, nil
+}
+
+// cells returns the cells of the board filled by the Tetrimino at the location.
+func (l Location) cells() ([4]cell, error) {
+	direction := slices.Index(orientations, l.Orientation)
+	if direction < 0 {
+		return [4]cell{}, fmt.Errorf("invalid orientation %q", l.Orientation)
+	}
+
+	cells, err := relativeCells(l.Type, direction)
+	if err != nil {
+		return cells, err
+	}
+	for i := range cells {
+		cells[i].x += l.X
+		cells[i].y += l.Y
+	}
+	return cells, nil
+}
+
+// Placement returns the Tetrimino at the location in a Matrix of the given height. It has no rotation compasses, so it
+// is only suitable as the target of a move (see solver.Move).
+func Placement(loc Location, height int) (*tetris.Tetrimino, error) {
+	cells, err := loc.cells()
+	if err != nil {
+		return nil, err
+	}
+
+	// The Tetrimino's cells are the smallest box which holds its minos.
+	left, top, right, bottom := cells[0].x, cells[0].y, cells[0].x, cells[0].y
+	for _, c := range cells[1:] {
+		left, right = min(left, c.x), max(right, c.x)
+		top, bottom = max(top, c.y), min(bottom, c.y)
+	}
+	grid := make([][]bool, top-bottom+1)
+	for row := range grid {
+		grid[row] = make([]bool, right-left+1)
+	}
+	for _, c := range cells {
+		grid[top-c.y][c.x-left] = true
+	}
+
+	return &tetris.Tetrimino{
+		Value:            loc.Type[0],
+		Cells:            grid,
+		Position:         tetris.Coordinate{X: left, Y: height - 1 - top},
+		CompassDirection: slices.Index(orientations, loc.Orientation),
+	}, nil
+}
+
+// LocationOf returns the location of the Tetrimino in a Matrix of the given height. The I, S, Z and O Tetriminos fill
+// the same cells in more than one orientation, in which case the Tetrimino's own orientation is preferred.
+func LocationOf(tet *tetris.Tetrimino, height int) (Location, error) {
+	var filled []cell
+	for row := range tet.Cells {
+		for col, mino := range tet.Cells[row] {
+			if mino {
+				filled = append(filled, cell{x: tet.Position.X + col, y: height - 1 - (tet.Position.Y + row)})
+			}
+		}
+	}
+	if len(filled) != 4 {
+		return Location{}, fmt.Errorf("tetrimino has %d minos, want 4", len(filled))
+	}
+
+	piece := Piece(tet.Value)
+	for i := range orientations {
+		direction := (tet.CompassDirection + i) % len(orientations)
+		relative, err := relativeCells(piece, direction)
+		if err != nil {
+			return Location{}, err
+		}
+		// The centre is where the first relative cell is moved onto a filled cell, if that fills them all.
+		for _, f := range filled {
+			centre := cell{x: f.x - relative[0].x, y: f.y - relative[0].y}
+			if sameCells(filled, relative, centre) {
+				return Location{Type: piece, Orientation: orientations[direction], X: centre.x, Y: centre.y}, nil
+			}
+		}
+	}
+	return Location{}, errors.New("tetrimino does not fill the cells of any orientation")
+}
+
+// sameCells returns true if the relative cells moved to the centre are the filled cells, in any order.
+func sameCells(filled []cell, relative [4]cell, centre cell) bool {
+	for _, r := range relative {
+		found := false
+		for _, f := range filled {
+			if f.x == r.x+centre.x && f.y == r.y+centre.y {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Board returns the cells of the Matrix as a board: its rows from the bottom up, each from left to right. Empty cells
+// are nil.
+func Board(matrix tetris.Matrix) [][]*Piece {
+	board := make([][]*Piece, len(matrix))
+	for y := range board {
+		row := matrix[len(matrix)-1-y]
+		board[y] = make([]*Piece, len(row))
+		for x, c := range row {
+			if c == 0 {
+				continue
+			}
+			piece := Piece(c)
+			if tetris.IsGarbageCell(c) {
+				piece = garbagePiece
+			}
+			board[y][x] = &piece
+		}
+	}
+	return board
+}
+
+// matrixOf returns the Matrix of the board (see Board). Garbage cells are garbage minos.
+func matrixOf(board [][]*Piece) (tetris.Matrix, error) {
+	if len(board) == 0 {
+		return nil, errors.New("board has no rows")
+	}
+	width := len(board[0])
+	matrix, err := tetris.NewMatrix(len(board), width)
+	if err != nil {
+		return nil, fmt.Errorf("creating matrix: %w", err)
+	}
+	for y, row := range board {
+		if len(row) != width {
+			return nil, fmt.Errorf("board row %d has %d cells, want %d", y, len(row), width)
+		}
+		for x, piece := range row {
+			switch {
+			case piece == nil:
+			case *piece == garbagePiece:
+				matrix[len(board)-1-y][x] = tetris.GarbageCell
+			case len(*piece) == 1:
+				matrix[len(board)-1-y][x] = (*piece)[0]
+			default:
+				return nil, fmt.Errorf("invalid cell %q", *piece)
+			}
+		}
+	}
+	return matrix, nil
+}
@@ -0,0 +1,100 @@
+package tbp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+)
+
+func TestPlacement(t *testing.T) {
+	tt := map[string]struct {
+		loc  Location
+		want [][]bool
+		pos  tetris.Coordinate
+	}{
+		"T north on the floor": {
+			loc:  Location{Type: "T", Orientation: "north", X: 1, Y: 0},
+			want: [][]bool{{false, true, false}, {true, true, true}},
+			pos:  tetris.Coordinate{X: 0, Y: 38},
+		},
+		"I east against the right wall": {
+			loc:  Location{Type: "I", Orientation: "east", X: 9, Y: 2},
+			want: [][]bool{{true}, {true}, {true}, {true}},
+			pos:  tetris.Coordinate{X: 9, Y: 36},
+		},
+		"S west": {
+			loc:  Location{Type: "S", Orientation: "west", X: 5, Y: 1},
+			want: [][]bool{{true, false}, {true, true}, {false, true}},
+			pos:  tetris.Coordinate{X: 4, Y: 37},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			placement, err := Placement(tc.loc, 40)
+			require.NoError(t, err)
+			assert.Equal(t, tc.loc.Type[0], placement.Value)
+			assert.Equal(t, tc.want, placement.Cells)
+			assert.Equal(t, tc.pos, placement.Position)
+		})
+	}
+}
+
+func TestLocationOf(t *testing.T) {
+	matrix := tetris.DefaultMatrix()
+
+	// Every orientation of every Tetrimino on the floor has a location which fills the same cells.
+	for _, tet := range tetris.GetValidTetriminos() {
+		tet.Position.Y += 20
+		for range orientations {
+			landed := tet.DeepCopy()
+			for landed.MoveDown(matrix) {
+				continue
+			}
+
+			loc, err := LocationOf(landed, len(matrix))
+			require.NoError(t, err)
+			assert.Equal(t, orientations[landed.CompassDirection], loc.Orientation, string(tet.Value))
+
+			placement, err := Placement(loc, len(matrix))
+			require.NoError(t, err)
+			assert.Equal(t, minos(landed), minos(placement), string(tet.Value))
+
+			require.NoError(t, tet.Rotate(matrix, true))
+		}
+	}
+}
+
+func TestBoard(t *testing.T) {
+	matrix := tetris.DefaultMatrix()
+	matrix[39] = []byte{'X', 'X', 'X', 'X', 0, 'X', 'X', 'X', 'X', 'X'}
+	matrix[38] = []byte{'T', 'T', 'T', 0, 0, 0, 0, 0, 0, 'I'}
+
+	board := Board(matrix)
+	require.Len(t, board, 40)
+	require.NotNil(t, board[0][0])
+	assert.Equal(t, garbagePiece, *board[0][0])
+	assert.Nil(t, board[0][4])
+	require.NotNil(t, board[1][9])
+	assert.Equal(t, Piece("I"), *board[1][9])
+
+	got, err := matrixOf(board)
+	require.NoError(t, err)
+	assert.Equal(t, matrix, got)
+}
+
+// minos returns the cells of the Matrix filled by the Tetrimino, in any order.
+func minos(t *tetris.Tetrimino) map[tetris.Coordinate]bool {
+	cells := make(map[tetris.Coordinate]bool)
+	for row := range t.Cells {
+		for col, filled := range t.Cells[row] {
+			if filled {
+				cells[tetris.Coordinate{X: t.Position.X + col, Y: t.Position.Y + row}] = true
+			}
+		}
+	}
+	return cells
+}
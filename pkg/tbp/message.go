@@ -0,0 +1,164 @@
+// Package tbp implements the Tetris Bot Protocol (https://github.com/tetris-bot-protocol/tbp-spec), which lets a
+// frontend and a bot play Tetris together by exchanging JSON messages, one per line. The frontend runs the game and
+// the bot suggests where to place each Tetrimino.
+package tbp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The types of messages sent by the frontend.
+const (
+	TypeRules    = "rules"
+	TypeStart    = "start"
+	TypeSuggest  = "suggest"
+	TypePlay     = "play"
+	TypeNewPiece = "new_piece"
+	TypeStop     = "stop"
+	TypeQuit     = "quit"
+)
+
+// The types of messages sent by the bot.
+const (
+	TypeInfo       = "info"
+	TypeReady      = "ready"
+	TypeError      = "error"
+	TypeSuggestion = "suggestion"
+)
+
+// ReasonUnsupportedRules is the reason of an Error sent by a bot which cannot play with the rules.
+const ReasonUnsupportedRules = "unsupported_rules"
+
+// Piece is the type of a Tetrimino ("I", "O", "T", "L", "J", "S" or "Z"), or "G" for a garbage cell of a board.
+type Piece string
+
+// Info is sent by the bot when it starts.
+type Info struct {
+	Type     string   `json:"type"`
+	Name     string   `json:"name"`
+	Version  string   `json:"version"`
+	Author   string   `json:"author"`
+	Features []string `json:"features"`
+}
+
+// Ready is sent by the bot when it can play with the rules.
+type Ready struct {
+	Type string `json:"type"`
+}
+
+// Error is sent by the bot instead of Ready when it cannot play with the rules.
+type Error struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// Rules is sent by the frontend to say which rules the game is played with. The bot replies with Ready or Error.
+type Rules struct {
+	Type string `json:"type"`
+}
+
+// Randomizer is the state of the randomizer which deals the Tetriminos, sent with Start.
+type Randomizer struct {
+	Type     string  `json:"type"`                // "seven_bag" or "unknown".
+	BagState []Piece `json:"bag_state,omitempty"` // The Tetriminos left in the bag after the queue.
+}
+
+// Start is sent by the frontend to start a game from a position.
+type Start struct {
+	Type       string      `json:"type"`
+	Hold       *Piece      `json:"hold"`
+	Queue      []Piece     `json:"queue"` // The Tetrimino in play, then the Next Queue.
+	Combo      int         `json:"combo"`
+	BackToBack bool        `json:"back_to_back"`
+	Board      [][]*Piece  `json:"board"` // The rows of the board from the bottom up, each from left to right.
+	Randomizer *Randomizer `json:"randomizer,omitempty"`
+}
+
+// Suggest is sent by the frontend to ask the bot for moves. The bot replies with Suggestion.
+type Suggest struct {
+	Type string `json:"type"`
+}
+
+// Suggestion is sent by the bot with the moves it suggests, best first. No moves means the bot cannot move.
+type Suggestion struct {
+	Type  string `json:"type"`
+	Moves []Move `json:"moves"`
+}
+
+// Play is sent by the frontend with the move which was made.
+type Play struct {
+	Type string `json:"type"`
+	Move Move   `json:"move"`
+}
+
+// NewPiece is sent by the frontend when a Tetrimino is added to the end of the queue.
+type NewPiece struct {
+	Type  string `json:"type"`
+	Piece Piece  `json:"piece"`
+}
+
+// Stop is sent by the frontend to end the game.
+type Stop struct {
+	Type string `json:"type"`
+}
+
+// Quit is sent by the frontend to stop the bot.
+type Quit struct {
+	Type string `json:"type"`
+}
+
+// Move is where a Tetrimino locks down. If it is not the Tetrimino in play, it is held first.
+type Move struct {
+	Location Location `json:"location"`
+	Spin     string   `json:"spin"` // "none", "mini" or "full".
+}
+
+// conn reads and writes messages, one per line.
+type conn struct {
+	scanner *bufio.Scanner
+	w       io.Writer
+}
+
+func newConn(r io.Reader, w io.Writer) *conn {
+	scanner := bufio.NewScanner(r)
+	// Start messages hold a whole board, so lines can be longer than the default limit.
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	return &conn{scanner: scanner, w: w}
+}
+
+// read returns the next message, and its type.
+func (c *conn) read() (string, []byte, error) {
+	if !c.scanner.Scan() {
+		if err := c.scanner.Err(); err != nil {
+			return "", nil, fmt.Errorf("reading message: %w", err)
+		}
+		return "", nil, io.EOF
+	}
+	line := c.scanner.Bytes()
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(line, &header); err != nil {
+		return "", nil, fmt.Errorf("decoding message: %w", err)
+	}
+	if header.Type == "" {
+		return "", nil, errors.New("message has no type")
+	}
+	return header.Type, line, nil
+}
+
+// write sends the message.
+func (c *conn) write(msg any) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("encoding message: %w", err)
+	}
+	if _, err = c.w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("writing message: %w", err)
+	}
+	return nil
+}
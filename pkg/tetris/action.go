@@ -113,6 +113,24 @@ func (a action) GetPoints() int {
 	return actionToPointsMap[a]
 }
 
+// LinesCleared returns the number of lines cleared by the action.
+func (a action) LinesCleared() int {
+	switch a {
+	case actionSingle, actionMiniTSpinSingle, actionTSpinSingle:
+		return 1
+	case actionDouble, actionTSpinDouble:
+		return 2
+	case actionTriple, actionTSpinTriple:
+		return 3
+	case actionTetris:
+		return 4
+	case actionUnknown, actionNone, actionMiniTSpin, actionTSpin:
+		return 0
+	default:
+		return 0
+	}
+}
+
 func (a action) EndsBackToBack() (bool, error) {
 	switch a {
 	case actionSingle, actionDouble, actionTriple:
@@ -0,0 +1,138 @@
+// Package analysis reviews the placements of a game by comparing each with the placement a solver chooses from the same
+// position, much like the blunder checks of chess engines.
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+const (
+	// DefaultDepth is the number of moves searched ahead of each position when Input.Depth is 0.
+	DefaultDepth = 2
+	// DefaultBlunderLoss is the loss at which a placement is a blunder when Input.BlunderLoss is 0. It suits the scores
+	// of eval.ElTetris, where it is roughly the cost of leaving a hole.
+	DefaultBlunderLoss = 25.0
+)
+
+// Turn is a placement made in a game, and the position it was made from.
+type Turn struct {
+	Position *solver.Position // The position when the Tetrimino entered play, before it could be held.
+	Played   solver.Move      // The move which was made. Inputs are not needed.
+}
+
+// Review is the analysis of a turn.
+type Review struct {
+	Turn
+	Best        solver.Move // The first move of the best plan found. It is the played move if that scored higher.
+	PlayedScore float64     // The total score of the best plan which starts with the played move.
+	BestScore   float64     // The total score of the best plan found.
+	Loss        float64     // How much lower the played move scored than the best, which is never negative.
+	Blunder     bool        // Whether the loss is at least the blunder loss.
+}
+
+// Input configures an analysis.
+type Input struct {
+	Turns       []Turn
+	Evaluator   eval.Evaluator // The evaluator which scores placements. Nil means eval.ElTetris.
+	Depth       int            // The number of moves searched ahead of each position. 0 means DefaultDepth.
+	BlunderLoss float64        // The loss at which a placement is a blunder. 0 means DefaultBlunderLoss.
+	Workers     int            // The number of turns analysed at once. 0 means 1.
+}
+
+// Analyze reviews each turn. A beam search finds the best plan from the turn's position, and the best plan which
+// starts with the played move is scored the same way, so the loss is how much the played move gave up. Turns are
+// analysed in parallel, and the reviews are in the same order as the turns.
+func Analyze(ctx context.Context, in *Input) ([]Review, error) {
+	ev := in.Evaluator
+	if ev == nil {
+		ev = eval.ElTetris()
+	}
+	depth := in.Depth
+	if depth <= 0 {
+		depth = DefaultDepth
+	}
+	blunderLoss := in.BlunderLoss
+	if blunderLoss <= 0 {
+		blunderLoss = DefaultBlunderLoss
+	}
+	search := solver.NewBeamSearch(ev, solver.WithBeamDepth(depth))
+
+	reviews := make([]Review, len(in.Turns))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	var nextTurn atomic.Int64
+
+	for range max(in.Workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(nextTurn.Add(1) - 1)
+				if i >= len(in.Turns) || ctx.Err() != nil {
+					return
+				}
+				review, err := reviewTurn(ctx, search, in.Turns[i], blunderLoss)
+				if err != nil {
+					once.Do(func() {
+						firstErr = fmt.Errorf("turn %d: %w", i+1, err)
+						cancel()
+					})
+					return
+				}
+				reviews[i] = review
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// reviewTurn compares the played move of the turn with the best plan the search finds from its position.
+func reviewTurn(ctx context.Context, search *solver.BeamSearch, turn Turn, blunderLoss float64) (Review, error) {
+	if turn.Position == nil || turn.Played.Placement == nil {
+		return Review{}, errors.New("turn has no position or played move")
+	}
+	_, playedScore, err := search.PlanWith(ctx, turn.Position, turn.Played)
+	if err != nil {
+		return Review{}, fmt.Errorf("scoring played move: %w", err)
+	}
+	plan, err := search.Plan(ctx, turn.Position)
+	if err != nil {
+		return Review{}, fmt.Errorf("searching for best move: %w", err)
+	}
+	_, bestScore, err := search.PlanWith(ctx, turn.Position, plan[0])
+	if err != nil {
+		return Review{}, fmt.Errorf("scoring best move: %w", err)
+	}
+
+	review := Review{
+		Turn:        turn,
+		Best:        plan[0],
+		PlayedScore: playedScore,
+		BestScore:   bestScore,
+	}
+	if playedScore >= bestScore {
+		review.Best, review.BestScore = turn.Played, playedScore
+		return review, nil
+	}
+	review.Loss = bestScore - playedScore
+	review.Blunder = review.Loss >= blunderLoss
+	return review, nil
+}
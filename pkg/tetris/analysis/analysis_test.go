@@ -0,0 +1,145 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+func TestAnalyze(t *testing.T) {
+	// Only a vertical I in the well clears the lines, so placing it on the stack instead is a blunder.
+	pos := newPosition(t, "IOT",
+		"XXXXXXXXX.",
+		"XXXXXXXXX.",
+		"XXXXXXXXX.",
+		"XXXXXXXXX.",
+	)
+	filled, stacked := findDrop(t, pos, 9, 4), findDrop(t, pos, 0, 1)
+
+	tt := map[string]struct {
+		played      solver.Move
+		wantBlunder bool
+	}{
+		"fills the well": {
+			played: filled,
+		},
+		"stacked on top": {
+			played:      stacked,
+			wantBlunder: true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			reviews, err := Analyze(context.Background(), &Input{Turns: []Turn{{Position: pos, Played: tc.played}}})
+			require.NoError(t, err)
+			require.Len(t, reviews, 1)
+
+			review := reviews[0]
+			assert.Equal(t, tc.wantBlunder, review.Blunder)
+			assert.Equal(t, filled.Placement, review.Best.Placement)
+			assert.GreaterOrEqual(t, review.BestScore, review.PlayedScore)
+			assert.InDelta(t, review.BestScore-review.PlayedScore, review.Loss, 1e-9)
+			if !tc.wantBlunder {
+				assert.Zero(t, review.Loss)
+			}
+		})
+	}
+}
+
+func TestAnalyze_Workers(t *testing.T) {
+	pos := newPosition(t, "IOT", "XXXXXXXXX.", "XXXXXXXXX.")
+	var turns []Turn
+	for _, move := range solver.Drops(pos.Current, pos.Matrix) {
+		turns = append(turns, Turn{Position: pos, Played: move})
+	}
+
+	// The reviews do not depend on the number of workers.
+	want, err := Analyze(context.Background(), &Input{Turns: turns})
+	require.NoError(t, err)
+	got, err := Analyze(context.Background(), &Input{Turns: turns, Workers: 4})
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	require.Len(t, got, len(turns))
+}
+
+func TestAnalyze_Errors(t *testing.T) {
+	pos := newPosition(t, "IOT")
+	turn := Turn{Position: pos, Played: solver.Drops(pos.Current, pos.Matrix)[0]}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tt := map[string]struct {
+		ctx  context.Context
+		turn Turn
+	}{
+		"no position": {
+			ctx:  context.Background(),
+			turn: Turn{Played: turn.Played},
+		},
+		"no played move": {
+			ctx:  context.Background(),
+			turn: Turn{Position: pos},
+		},
+		"cancelled": {
+			ctx:  cancelled,
+			turn: turn,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			_, err := Analyze(tc.ctx, &Input{Turns: []Turn{tc.turn}})
+			assert.Error(t, err)
+		})
+	}
+}
+
+// newPosition returns the position of a new game with the given Tetriminos in order, and the given rows at the bottom
+// of the Matrix, where '.' is an empty cell.
+func newPosition(t *testing.T, sequence string, rows ...string) *solver.Position {
+	t.Helper()
+	matrix := tetris.DefaultMatrix()
+	offset := len(matrix) - len(rows)
+	for i, row := range rows {
+		require.Len(t, row, len(matrix[0]))
+		for col := range len(row) {
+			if row[col] != '.' {
+				matrix[offset+i][col] = row[col]
+			}
+		}
+	}
+
+	game, err := single.NewGame(&single.Input{Level: 1, Matrix: matrix, Sequence: []byte(sequence)})
+	require.NoError(t, err)
+	return solver.NewPosition(game)
+}
+
+// findDrop returns the drop of the Tetrimino in play whose leftmost mino is in the column, and which is the given
+// number of cells tall.
+func findDrop(t *testing.T, pos *solver.Position, col, height int) solver.Move {
+	t.Helper()
+	for _, move := range solver.Drops(pos.Current, pos.Matrix) {
+		left, top, bottom := len(pos.Matrix[0]), len(pos.Matrix), -1
+		for row := range move.Placement.Cells {
+			for c, filled := range move.Placement.Cells[row] {
+				if filled {
+					left = min(left, move.Placement.Position.X+c)
+					top = min(top, row)
+					bottom = max(bottom, row)
+				}
+			}
+		}
+		if left == col && bottom-top+1 == height {
+			return move
+		}
+	}
+	require.Fail(t, "no drop found")
+	return solver.Move{}
+}
@@ -0,0 +1,161 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+)
+
+// Evaluator scores placements. Higher scores are better.
+type Evaluator interface {
+	// Evaluate returns the score of the placement locking down in the Matrix, and the resulting Matrix.
+	Evaluate(matrix tetris.Matrix, placement *tetris.Tetrimino) (float64, tetris.Matrix, error)
+}
+
+var _ Evaluator = Weights{}
+
+// Weights is an Evaluator which scores a placement as the sum of its features, each multiplied by its weight.
+type Weights struct {
+	AggregateHeight   float64 `json:"aggregate_height"`
+	Bumpiness         float64 `json:"bumpiness"`
+	Holes             float64 `json:"holes"`
+	CoveredHoles      float64 `json:"covered_holes"`
+	RowTransitions    float64 `json:"row_transitions"`
+	ColumnTransitions float64 `json:"column_transitions"`
+	Wells             float64 `json:"wells"`
+	CumulativeWells   float64 `json:"cumulative_wells"`
+	TSlots            float64 `json:"t_slots"`
+	LandingHeight     float64 `json:"landing_height"`
+	LinesCleared      float64 `json:"lines_cleared"`
+	ErodedPieceCells  float64 `json:"eroded_piece_cells"`
+}
+
+// Dellacherie returns the weights of Pierre Dellacherie's hand-tuned evaluator.
+func Dellacherie() Weights {
+	return Weights{
+		LandingHeight:     -1,
+		ErodedPieceCells:  1,
+		RowTransitions:    -1,
+		ColumnTransitions: -1,
+		Holes:             -4,
+		CumulativeWells:   -1,
+	}
+}
+
+// ElTetris returns the weights of Yiyuan Lee's El-Tetris evaluator, which were tuned by particle swarm optimisation.
+func ElTetris() Weights {
+	return Weights{
+		LandingHeight:     -4.500158825082766,
+		LinesCleared:      3.4181268101392694,
+		RowTransitions:    -3.2178882868487753,
+		ColumnTransitions: -9.348695305445199,
+		Holes:             -7.899265427351652,
+		CumulativeWells:   -3.3855972247263626,
+	}
+}
+
+// GetWeights returns the weights of the evaluator with the given name (dellacherie or el-tetris), or loads them from
+// the JSON file at the given path.
+func GetWeights(nameOrPath string) (Weights, error) {
+	switch nameOrPath {
+	case "dellacherie":
+		return Dellacherie(), nil
+	case "el-tetris":
+		return ElTetris(), nil
+	}
+	return LoadWeightsFile(nameOrPath)
+}
+
+// LoadWeightsFile loads weights from the JSON file at the given path.
+func LoadWeightsFile(path string) (Weights, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Weights{}, fmt.Errorf("opening weights file: %w", err)
+	}
+	defer f.Close()
+
+	w, err := LoadWeights(f)
+	if err != nil {
+		return Weights{}, fmt.Errorf("loading weights from %q: %w", path, err)
+	}
+	return w, nil
+}
+
+// LoadWeights decodes weights from JSON. Features which are not given have a weight of zero, and unknown features
+// are an error so that misspelt weights are not silently ignored.
+func LoadWeights(r io.Reader) (Weights, error) {
+	var w Weights
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&w); err != nil {
+		return Weights{}, fmt.Errorf("decoding weights: %w", err)
+	}
+	return w, nil
+}
+
+// Save encodes the weights as indented JSON.
+func (w Weights) Save(wr io.Writer) error {
+	enc := json.NewEncoder(wr)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(w); err != nil {
+		return fmt.Errorf("encoding weights: %w", err)
+	}
+	return nil
+}
+
+// Vector returns the weights in the order of the fields of Weights, for use by optimisers.
+func (w Weights) Vector() []float64 {
+	return []float64{
+		w.AggregateHeight, w.Bumpiness, w.Holes, w.CoveredHoles, w.RowTransitions, w.ColumnTransitions,
+		w.Wells, w.CumulativeWells, w.TSlots, w.LandingHeight, w.LinesCleared, w.ErodedPieceCells,
+	}
+}
+
+// WeightsFromVector returns the weights of a vector in the order of the fields of Weights (see Weights.Vector).
+func WeightsFromVector(v []float64) (Weights, error) {
+	if len(v) != len(Weights{}.Vector()) {
+		return Weights{}, fmt.Errorf("vector has %d weights, but must have %d", len(v), len(Weights{}.Vector()))
+	}
+	return Weights{
+		AggregateHeight:   v[0],
+		Bumpiness:         v[1],
+		Holes:             v[2],
+		CoveredHoles:      v[3],
+		RowTransitions:    v[4],
+		ColumnTransitions: v[5],
+		Wells:             v[6],
+		CumulativeWells:   v[7],
+		TSlots:            v[8],
+		LandingHeight:     v[9],
+		LinesCleared:      v[10],
+		ErodedPieceCells:  v[11],
+	}, nil
+}
+
+// Score returns the sum of the features, each multiplied by its weight.
+func (w Weights) Score(f Features) float64 {
+	return w.AggregateHeight*float64(f.AggregateHeight) +
+		w.Bumpiness*float64(f.Bumpiness) +
+		w.Holes*float64(f.Holes) +
+		w.CoveredHoles*float64(f.CoveredHoles) +
+		w.RowTransitions*float64(f.RowTransitions) +
+		w.ColumnTransitions*float64(f.ColumnTransitions) +
+		w.Wells*float64(f.Wells) +
+		w.CumulativeWells*float64(f.CumulativeWells) +
+		w.TSlots*float64(f.TSlots) +
+		w.LandingHeight*f.LandingHeight +
+		w.LinesCleared*float64(f.LinesCleared) +
+		w.ErodedPieceCells*float64(f.ErodedPieceCells)
+}
+
+// Evaluate returns the score of the features of the placement locking down in the Matrix, and the resulting Matrix.
+func (w Weights) Evaluate(matrix tetris.Matrix, placement *tetris.Tetrimino) (float64, tetris.Matrix, error) {
+	f, locked, err := PlacementFeatures(matrix, placement)
+	if err != nil {
+		return 0, nil, err
+	}
+	return w.Score(f), locked, nil
+}
@@ -0,0 +1,183 @@
+package eval
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+)
+
+func TestWeights_Evaluate(t *testing.T) {
+	matrix := newMatrix(
+		"....",
+		"....",
+		"....",
+		"XXX.",
+		"XXX.",
+	)
+	vertical := &tetris.Tetrimino{
+		Value:    'I',
+		Cells:    [][]bool{{true}, {true}, {true}, {true}},
+		Position: tetris.Coordinate{X: 3, Y: 1},
+	}
+	flat := &tetris.Tetrimino{
+		Value:    'I',
+		Cells:    [][]bool{{true, true, true, true}},
+		Position: tetris.Coordinate{X: 0, Y: 2},
+	}
+
+	tt := map[string]struct {
+		weights      Weights
+		wantVertical bool // Whether the vertical placement, which clears two lines, scores higher than the flat one.
+	}{
+		"dellacherie": {
+			weights:      Dellacherie(),
+			wantVertical: true,
+		},
+		"el-tetris": {
+			weights:      ElTetris(),
+			wantVertical: true,
+		},
+		"rewards height": {
+			weights:      Weights{AggregateHeight: 1},
+			wantVertical: false,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			verticalScore, _, err := tc.weights.Evaluate(matrix, vertical)
+			require.NoError(t, err)
+			flatScore, _, err := tc.weights.Evaluate(matrix, flat)
+			require.NoError(t, err)
+
+			if tc.wantVertical {
+				assert.Greater(t, verticalScore, flatScore)
+			} else {
+				assert.Greater(t, flatScore, verticalScore)
+			}
+		})
+	}
+}
+
+func TestWeights_Score(t *testing.T) {
+	// Landing height 2.5, eroded piece cells 4, row transitions 4 and column transitions 4.
+	f := Features{
+		AggregateHeight:   2,
+		Bumpiness:         2,
+		RowTransitions:    4,
+		ColumnTransitions: 4,
+		LandingHeight:     2.5,
+		LinesCleared:      2,
+		ErodedPieceCells:  4,
+	}
+	assert.InDelta(t, -6.5, Dellacherie().Score(f), 1e-9)
+	assert.InDelta(t, 4.5, Weights{AggregateHeight: 1, LandingHeight: 1}.Score(f), 1e-9)
+}
+
+func TestLoadWeights(t *testing.T) {
+	tt := map[string]struct {
+		json    string
+		want    Weights
+		wantErr bool
+	}{
+		"some features": {
+			json: `{"holes": -2.5, "bumpiness": -1}`,
+			want: Weights{Holes: -2.5, Bumpiness: -1},
+		},
+		"unknown feature": {
+			json:    `{"holse": -2.5}`,
+			wantErr: true,
+		},
+		"invalid json": {
+			json:    `{"holes": }`,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			w, err := LoadWeights(strings.NewReader(tc.json))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, w)
+		})
+	}
+}
+
+func TestGetWeights(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weights.json")
+	var buf bytes.Buffer
+	require.NoError(t, ElTetris().Save(&buf))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+
+	tt := map[string]struct {
+		nameOrPath string
+		want       Weights
+		wantErr    bool
+	}{
+		"dellacherie": {
+			nameOrPath: "dellacherie",
+			want:       Dellacherie(),
+		},
+		"el-tetris": {
+			nameOrPath: "el-tetris",
+			want:       ElTetris(),
+		},
+		"saved file": {
+			nameOrPath: path,
+			want:       ElTetris(),
+		},
+		"missing file": {
+			nameOrPath: filepath.Join(t.TempDir(), "missing.json"),
+			wantErr:    true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			w, err := GetWeights(tc.nameOrPath)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, w)
+		})
+	}
+}
+
+func TestWeightsFromVector(t *testing.T) {
+	w, err := WeightsFromVector(ElTetris().Vector())
+	require.NoError(t, err)
+	assert.Equal(t, ElTetris(), w)
+
+	_, err = WeightsFromVector([]float64{1, 2, 3})
+	require.Error(t, err)
+}
+
+func BenchmarkWeights_Evaluate(b *testing.B) {
+	matrix := benchmarkMatrix(b)
+	placement := &tetris.Tetrimino{
+		Value:    'I',
+		Cells:    [][]bool{{true}, {true}, {true}, {true}},
+		Position: tetris.Coordinate{X: 9, Y: len(matrix) - 4},
+	}
+	weights := ElTetris()
+	b.ResetTimer()
+	for range b.N {
+		_, _, err := weights.Evaluate(matrix, placement)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
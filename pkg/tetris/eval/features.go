@@ -0,0 +1,301 @@
+// Package eval scores Matrices and placements for the solver, using features of the stack weighted by evaluators.
+package eval
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+)
+
+// Features are the measures of a Matrix, and of the placement which led to it, which evaluators weigh.
+type Features struct {
+	AggregateHeight   int     // The sum of the heights of the columns.
+	Bumpiness         int     // The sum of the differences in height between adjacent columns.
+	Holes             int     // The number of empty cells below the top of their column.
+	CoveredHoles      int     // The sum, for each hole, of the number of minos above it in its column.
+	RowTransitions    int     // The number of changes between filled and empty cells along the rows of the stack.
+	ColumnTransitions int     // The number of changes between filled and empty cells down the columns.
+	Wells             int     // The number of empty cells above the stack with filled cells on both sides.
+	CumulativeWells   int     // The sum, for each well, of 1 + 2 + ... + its depth.
+	TSlots            int     // The number of places a T Tetrimino can be spun into to clear two lines.
+	LandingHeight     float64 // The height of the middle of the placement, where it locked down.
+	LinesCleared      int     // The number of lines cleared by the placement.
+	ErodedPieceCells  int     // The lines cleared by the placement, times the number of its minos cleared.
+}
+
+// MatrixFeatures returns the features of the Matrix. The placement features are zero.
+func MatrixFeatures(matrix tetris.Matrix) Features {
+	heights := ColumnHeights(matrix)
+	wells, cumulativeWells := Wells(matrix)
+	return Features{
+		AggregateHeight:   AggregateHeight(heights),
+		Bumpiness:         Bumpiness(heights),
+		Holes:             Holes(matrix),
+		CoveredHoles:      CoveredHoles(matrix),
+		RowTransitions:    RowTransitions(matrix),
+		ColumnTransitions: ColumnTransitions(matrix),
+		Wells:             wells,
+		CumulativeWells:   cumulativeWells,
+		TSlots:            TSlots(matrix),
+	}
+}
+
+// PlacementFeatures returns the features of the Matrix after the placement locks down in it and completed lines are
+// removed, along with the resulting Matrix. The given Matrix is not modified.
+func PlacementFeatures(matrix tetris.Matrix, placement *tetris.Tetrimino) (Features, tetris.Matrix, error) {
+	if len(matrix) == 0 {
+		return Features{}, nil, errors.New("matrix is empty")
+	}
+	locked := *matrix.DeepCopy()
+	if err := locked.AddTetrimino(placement); err != nil {
+		return Features{}, nil, fmt.Errorf("locking down placement: %w", err)
+	}
+
+	// The minos of the placement in each completed line are counted before the lines are removed.
+	lines, cleared := 0, 0
+	for row := range placement.Cells {
+		if !isRowFull(locked[placement.Position.Y+row]) {
+			continue
+		}
+		lines++
+		for _, filled := range placement.Cells[row] {
+			if filled {
+				cleared++
+			}
+		}
+	}
+	locked.RemoveCompletedLines(placement)
+
+	f := MatrixFeatures(locked)
+	f.LandingHeight = LandingHeight(matrix, placement)
+	f.LinesCleared = lines
+	f.ErodedPieceCells = lines * cleared
+	return f, locked, nil
+}
+
+// ColumnHeights returns the height of each column, which is the number of rows from the floor to its highest mino.
+func ColumnHeights(matrix tetris.Matrix) []int {
+	if len(matrix) == 0 {
+		return nil
+	}
+	heights := make([]int, len(matrix[0]))
+	for col := range heights {
+		for row := range matrix {
+			if !isEmpty(matrix[row][col]) {
+				heights[col] = len(matrix) - row
+				break
+			}
+		}
+	}
+	return heights
+}
+
+// AggregateHeight returns the sum of the column heights.
+func AggregateHeight(heights []int) int {
+	total := 0
+	for _, h := range heights {
+		total += h
+	}
+	return total
+}
+
+// Bumpiness returns the sum of the absolute differences in height between adjacent columns.
+func Bumpiness(heights []int) int {
+	total := 0
+	for col := 1; col < len(heights); col++ {
+		total += abs(heights[col] - heights[col-1])
+	}
+	return total
+}
+
+// Holes returns the number of empty cells which have a mino above them in their column.
+func Holes(matrix tetris.Matrix) int {
+	holes := 0
+	forEachHole(matrix, func(int) { holes++ })
+	return holes
+}
+
+// CoveredHoles returns the sum, for each hole, of the number of minos above it in its column.
+// Unlike Holes, this grows with how much of the stack must be cleared to uncover the holes.
+func CoveredHoles(matrix tetris.Matrix) int {
+	covered := 0
+	forEachHole(matrix, func(above int) { covered += above })
+	return covered
+}
+
+// forEachHole calls fn for each hole in the Matrix with the number of minos above the hole in its column.
+func forEachHole(matrix tetris.Matrix, fn func(above int)) {
+	if len(matrix) == 0 {
+		return
+	}
+	for col := range matrix[0] {
+		above := 0
+		for row := range matrix {
+			switch {
+			case !isEmpty(matrix[row][col]):
+				above++
+			case above > 0:
+				fn(above)
+			}
+		}
+	}
+}
+
+// RowTransitions returns the number of times adjacent cells of a row change between filled and empty, for each row
+// from the highest mino down. The walls count as filled.
+func RowTransitions(matrix tetris.Matrix) int {
+	transitions := 0
+	for row := highestRow(matrix); row < len(matrix); row++ {
+		previous := true
+		for _, cell := range matrix[row] {
+			if filled := !isEmpty(cell); filled != previous {
+				transitions++
+				previous = filled
+			}
+		}
+		if !previous {
+			transitions++
+		}
+	}
+	return transitions
+}
+
+// ColumnTransitions returns the number of times adjacent cells of a column change between filled and empty, from the
+// top of the Matrix down. The floor counts as filled.
+func ColumnTransitions(matrix tetris.Matrix) int {
+	if len(matrix) == 0 {
+		return 0
+	}
+	transitions := 0
+	for col := range matrix[0] {
+		previous := false
+		for row := range matrix {
+			if filled := !isEmpty(matrix[row][col]); filled != previous {
+				transitions++
+				previous = filled
+			}
+		}
+		if !previous {
+			transitions++
+		}
+	}
+	return transitions
+}
+
+// Wells returns the number of well cells, and the sum for each well of 1 + 2 + ... + its depth.
+// A well cell is an empty cell above the top of its column, with filled cells (or walls) to its left and right.
+// A well is a run of well cells in a column.
+func Wells(matrix tetris.Matrix) (cells, cumulative int) {
+	if len(matrix) == 0 {
+		return 0, 0
+	}
+	filled := func(row, col int) bool {
+		return col < 0 || col >= len(matrix[row]) || !isEmpty(matrix[row][col])
+	}
+	for col := range matrix[0] {
+		depth := 0
+		for row := range matrix {
+			if !isEmpty(matrix[row][col]) {
+				break
+			}
+			if filled(row, col-1) && filled(row, col+1) {
+				depth++
+				cells++
+				cumulative += depth
+			} else {
+				depth = 0
+			}
+		}
+	}
+	return cells, cumulative
+}
+
+// TSlots returns the number of places a T Tetrimino pointing down can be spun into to clear two lines, if the rest of
+// the two rows it fills are filled. The slot must satisfy the three corner rule, be supported below and be open
+// above its center so the T can be rotated in.
+func TSlots(matrix tetris.Matrix) int {
+	if len(matrix) == 0 {
+		return 0
+	}
+	filled := func(row, col int) bool {
+		if row >= len(matrix) || col < 0 || col >= len(matrix[0]) {
+			return true
+		}
+		return row >= 0 && !isEmpty(matrix[row][col])
+	}
+
+	slots := 0
+	for row := 1; row < len(matrix)-1; row++ {
+		for col := 1; col < len(matrix[row])-1; col++ {
+			// The cells the T fills, and the cell above its center which it is rotated through.
+			if filled(row, col-1) || filled(row, col) || filled(row, col+1) || filled(row+1, col) || filled(row-1, col) {
+				continue
+			}
+			if !filled(row+2, col) || !filled(row+1, col-1) || !filled(row+1, col+1) {
+				continue
+			}
+			if filled(row-1, col-1) || filled(row-1, col+1) {
+				slots++
+			}
+		}
+	}
+	return slots
+}
+
+// LandingHeight returns the height above the floor of the middle of the placement's minos, in the Matrix it locks
+// down in.
+func LandingHeight(matrix tetris.Matrix, placement *tetris.Tetrimino) float64 {
+	top, bottom := -1, -1
+	for row := range placement.Cells {
+		for _, filled := range placement.Cells[row] {
+			if filled {
+				if top < 0 {
+					top = row
+				}
+				bottom = row
+				break
+			}
+		}
+	}
+	if top < 0 {
+		return 0
+	}
+	// A mino in the bottom row of the Matrix has a height of 1.
+	lowest := len(matrix) - (placement.Position.Y + bottom)
+	highest := len(matrix) - (placement.Position.Y + top)
+	return float64(lowest+highest) / 2
+}
+
+// highestRow returns the highest row of the Matrix with a mino, or the height of the Matrix if it is empty.
+func highestRow(matrix tetris.Matrix) int {
+	for row := range matrix {
+		for _, cell := range matrix[row] {
+			if !isEmpty(cell) {
+				return row
+			}
+		}
+	}
+	return len(matrix)
+}
+
+func isRowFull(row []byte) bool {
+	for _, cell := range row {
+		if isEmpty(cell) {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmpty returns true if the cell has no mino. Ghost cells are empty.
+func isEmpty(cell byte) bool {
+	return cell == 0 || cell == 'G'
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,206 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+)
+
+func TestMatrixFeatures(t *testing.T) {
+	tt := map[string]struct {
+		matrix tetris.Matrix
+		want   Features
+	}{
+		"empty": {
+			matrix: newMatrix("....", "...."),
+			want:   Features{ColumnTransitions: 4},
+		},
+		"stack with a hole": {
+			matrix: newMatrix(
+				"....",
+				".X..",
+				"X.XX",
+			),
+			want: Features{
+				AggregateHeight:   5,
+				Bumpiness:         2,
+				Holes:             1,
+				CoveredHoles:      1,
+				RowTransitions:    6,
+				ColumnTransitions: 6,
+				Wells:             1,
+				CumulativeWells:   1,
+			},
+		},
+		"deep covered hole": {
+			matrix: newMatrix(
+				"X...",
+				"X...",
+				"....",
+				"XXXX",
+			),
+			want: Features{
+				AggregateHeight:   7,
+				Bumpiness:         3,
+				Holes:             1,
+				CoveredHoles:      2,
+				RowTransitions:    6,
+				ColumnTransitions: 6,
+			},
+		},
+		"well": {
+			matrix: newMatrix(
+				"....",
+				"XXX.",
+				"XXX.",
+				"XXX.",
+			),
+			want: Features{
+				AggregateHeight:   9,
+				Bumpiness:         3,
+				RowTransitions:    6,
+				ColumnTransitions: 4,
+				Wells:             3,
+				CumulativeWells:   6,
+			},
+		},
+		"t-slot": {
+			matrix: newMatrix(
+				"XX...",
+				"X...X",
+				"XX.XX",
+				"XXXXX",
+			),
+			want: Features{
+				AggregateHeight:   14,
+				Bumpiness:         5,
+				Holes:             1,
+				CoveredHoles:      1,
+				RowTransitions:    6,
+				ColumnTransitions: 7,
+				Wells:             1,
+				CumulativeWells:   1,
+				TSlots:            1,
+			},
+		},
+		"ghost cells are empty": {
+			matrix: newMatrix("GGGG", "X..."),
+			want: Features{
+				AggregateHeight:   1,
+				Bumpiness:         1,
+				RowTransitions:    2,
+				ColumnTransitions: 4,
+			},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, MatrixFeatures(tc.matrix))
+		})
+	}
+}
+
+func TestPlacementFeatures(t *testing.T) {
+	// Removing lines replaces the top row, so it must be empty (as it is in the buffer zone of a real Matrix).
+	matrix := newMatrix(
+		"....",
+		"....",
+		"....",
+		"XXX.",
+		"XXX.",
+	)
+	vertical := &tetris.Tetrimino{
+		Value:    'I',
+		Cells:    [][]bool{{true}, {true}, {true}, {true}},
+		Position: tetris.Coordinate{X: 3, Y: 1},
+	}
+
+	f, locked, err := PlacementFeatures(matrix, vertical)
+	require.NoError(t, err)
+	assert.Equal(t, Features{
+		AggregateHeight:   2,
+		Bumpiness:         2,
+		RowTransitions:    4,
+		ColumnTransitions: 4,
+		LandingHeight:     2.5,
+		LinesCleared:      2,
+		ErodedPieceCells:  4,
+	}, f)
+	assert.Equal(t, newMatrix("....", "....", "....", "...I", "...I"), locked)
+
+	// The given matrix is not modified.
+	assert.Equal(t, newMatrix("....", "....", "....", "XXX.", "XXX."), matrix)
+
+	// Placements which overlap the stack are an error.
+	vertical.Position.X = 0
+	_, _, err = PlacementFeatures(matrix, vertical)
+	require.Error(t, err)
+}
+
+func BenchmarkMatrixFeatures(b *testing.B) {
+	matrix := benchmarkMatrix(b)
+	b.ResetTimer()
+	for range b.N {
+		MatrixFeatures(matrix)
+	}
+}
+
+func BenchmarkPlacementFeatures(b *testing.B) {
+	matrix := benchmarkMatrix(b)
+	placement := &tetris.Tetrimino{
+		Value:    'I',
+		Cells:    [][]bool{{true}, {true}, {true}, {true}},
+		Position: tetris.Coordinate{X: 9, Y: len(matrix) - 4},
+	}
+	b.ResetTimer()
+	for range b.N {
+		_, _, err := PlacementFeatures(matrix, placement)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchmarkMatrix returns a Matrix of the default size with a typical mid-game stack.
+func benchmarkMatrix(b *testing.B) tetris.Matrix {
+	b.Helper()
+	matrix, err := tetris.NewMatrixWithDimensions(tetris.DefaultDimensions)
+	if err != nil {
+		b.Fatal(err)
+	}
+	rows := []string{
+		"...TT.....",
+		"..TTTL.OO.",
+		"X.XXXLLOO.",
+		"XXXX.XXXX.",
+		"XXXXXXX.X.",
+		"XX.XXXXXX.",
+	}
+	offset := len(matrix) - len(rows)
+	for i, row := range rows {
+		for col := range len(row) {
+			if row[col] != '.' {
+				matrix[offset+i][col] = row[col]
+			}
+		}
+	}
+	return matrix
+}
+
+// newMatrix returns a Matrix of the given rows, where '.' is an empty cell.
+func newMatrix(rows ...string) tetris.Matrix {
+	matrix := make(tetris.Matrix, len(rows))
+	for i, row := range rows {
+		matrix[i] = make([]byte, len(row))
+		for col := range len(row) {
+			if row[col] != '.' {
+				matrix[i][col] = row[col]
+			}
+		}
+	}
+	return matrix
+}
@@ -1,7 +1,6 @@
 package tetris
 
 import (
-	"math"
 	"time"
 )
 
@@ -9,23 +8,107 @@ type Fall struct {
 	DefaultInterval  time.Duration
 	SoftDropInterval time.Duration
 	IsSoftDrop       bool
+
+	// RowsPerTick is the number of rows to fall each DefaultInterval. Values below 1 are treated as 1.
+	RowsPerTick int
+
+	// ARE is the delay between a Tetrimino locking down and the next Tetrimino spawning.
+	ARE time.Duration
+
+	// LineClearDelay is added to ARE when the Tetrimino which locked down cleared lines.
+	LineClearDelay time.Duration
+
+	// LockDelay is the time a Tetrimino can rest on a surface before it locks down.
+	// When 0, it locks down on the next fall after landing.
+	LockDelay time.Duration
+
+	curve      GravityCurve
+	delayCurve DelayCurve
 }
 
-func NewFall(level int) *Fall {
-	f := Fall{}
+func NewFall(level int, opts ...func(*Fall)) *Fall {
+	f := Fall{
+		curve: GuidelineGravity,
+	}
+
+	for _, opt := range opts {
+		opt(&f)
+	}
+
 	f.CalculateFallSpeeds(level)
 	return &f
 }
 
+// WithGravityCurve sets the GravityCurve used to calculate fall speeds.
+func WithGravityCurve(curve GravityCurve) func(*Fall) {
+	return func(f *Fall) {
+		f.curve = curve
+	}
+}
+
+// WithARE sets the delay between a Tetrimino locking down and the next Tetrimino spawning.
+func WithARE(are time.Duration) func(*Fall) {
+	return func(f *Fall) {
+		f.ARE = are
+	}
+}
+
+// WithLineClearDelay sets the delay added to ARE when lines are cleared.
+func WithLineClearDelay(delay time.Duration) func(*Fall) {
+	return func(f *Fall) {
+		f.LineClearDelay = delay
+	}
+}
+
+// WithLockDelay sets the time a Tetrimino can rest on a surface before it locks down.
+func WithLockDelay(delay time.Duration) func(*Fall) {
+	return func(f *Fall) {
+		f.LockDelay = delay
+	}
+}
+
+// WithDelayCurve sets the DelayCurve used to calculate ARE, line clear delay and lock delay for each level.
+// This overrides any fixed delays.
+func WithDelayCurve(curve DelayCurve) func(*Fall) {
+	return func(f *Fall) {
+		f.delayCurve = curve
+	}
+}
+
 func (f *Fall) CalculateFallSpeeds(level int) {
-	decrementedLevel := float64(level - 1)
-	speed := math.Pow(0.8-(decrementedLevel*0.007), decrementedLevel)
-	speed *= float64(time.Second)
+	if f.curve == nil {
+		f.curve = GuidelineGravity
+	}
+	g := f.curve.Gravity(level)
 
-	f.DefaultInterval = time.Duration(speed)
-	f.SoftDropInterval = time.Duration(speed / 15)
+	f.DefaultInterval = g.Interval
+	// Soft drop is 15 times faster than gravity, but no faster than one frame (unless gravity already is), so falls are
+	// not ticked more often than they can be shown.
+	f.SoftDropInterval = min(max(g.Interval/15, FrameDuration), g.Interval)
+	f.RowsPerTick = g.Rows
+
+	if f.delayCurve != nil {
+		d := f.delayCurve.Delays(level)
+		f.ARE = d.ARE
+		f.LineClearDelay = d.LineClear
+		f.LockDelay = d.Lock
+	}
 }
 
 func (f *Fall) ToggleSoftDrop() {
 	f.IsSoftDrop = !f.IsSoftDrop
 }
+
+// IsInstant returns true if Tetriminos should fall to the bottom of the Matrix as soon as they spawn or move.
+func (f *Fall) IsInstant() bool {
+	return f.RowsPerTick >= InstantGravity
+}
+
+// EntryDelay returns the delay before the next Tetrimino spawns after a Tetrimino locks down
+// with the given number of lines cleared.
+func (f *Fall) EntryDelay(linesCleared int) time.Duration {
+	if linesCleared > 0 {
+		return f.ARE + f.LineClearDelay
+	}
+	return f.ARE
+}
@@ -30,7 +30,7 @@ func TestNewFall(t *testing.T) {
 			f := NewFall(tc.level)
 
 			expectedDefault := calculateExpectedSpeed(tc.level)
-			expectedSoftDrop := time.Duration(float64(expectedDefault) / 15)
+			expectedSoftDrop := min(max(time.Duration(float64(expectedDefault)/15), FrameDuration), expectedDefault)
 
 			assert.Equal(t, expectedDefault, f.DefaultInterval,
 				"default fall speed should be %v, got %v", expectedDefault, f.DefaultInterval)
@@ -62,15 +62,30 @@ func TestFall_CalculateFallSpeeds(t *testing.T) {
 			"soft drop at level 10 should be faster than level 1")
 	})
 
-	// Test that soft drop is always faster.
+	// Test that soft drop is faster until gravity is faster than one frame.
 	t.Run("soft drop faster than default", func(t *testing.T) {
-		levels := []int{1, 5, 10, 15, 20}
+		levels := []int{1, 5, 10}
 		for _, level := range levels {
 			f.CalculateFallSpeeds(level)
 			assert.Greater(t, f.DefaultInterval, f.SoftDropInterval,
 				"soft drop should be faster (smaller interval) than default at level %d", level)
 		}
 	})
+
+	// Test that soft drop is never faster than one frame, nor slower than default.
+	t.Run("soft drop at least one frame", func(t *testing.T) {
+		levels := []int{10, 15, 20, 30}
+		for _, level := range levels {
+			f.CalculateFallSpeeds(level)
+			assert.GreaterOrEqual(t, f.SoftDropInterval, min(FrameDuration, f.DefaultInterval),
+				"soft drop should be at least one frame at level %d", level)
+			assert.LessOrEqual(t, f.SoftDropInterval, f.DefaultInterval,
+				"soft drop should not be slower than default at level %d", level)
+		}
+
+		f := NewFall(500, WithGravityCurve(TGMGravity))
+		assert.Equal(t, FrameDuration, f.SoftDropInterval)
+	})
 }
 
 func TestFall_ToggleSoftDrop(t *testing.T) {
@@ -101,3 +116,22 @@ func TestFall_SpeedProgression(t *testing.T) {
 		prevInterval = f.DefaultInterval
 	}
 }
+
+func TestFall_GravityCurve(t *testing.T) {
+	f := NewFall(1, WithGravityCurve(TGMGravity))
+	assert.Equal(t, 1, f.RowsPerTick)
+	assert.False(t, f.IsInstant())
+
+	f.CalculateFallSpeeds(500)
+	assert.Equal(t, FrameDuration, f.DefaultInterval)
+	assert.Equal(t, InstantGravity, f.RowsPerTick)
+	assert.True(t, f.IsInstant())
+}
+
+func TestFall_EntryDelay(t *testing.T) {
+	f := NewFall(1, WithARE(100*time.Millisecond), WithLineClearDelay(400*time.Millisecond))
+
+	assert.Equal(t, 100*time.Millisecond, f.EntryDelay(0))
+	assert.Equal(t, 500*time.Millisecond, f.EntryDelay(2))
+	assert.Equal(t, time.Duration(0), NewFall(1).EntryDelay(4))
+}
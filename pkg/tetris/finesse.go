@@ -0,0 +1,142 @@
+package tetris
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// FinesseInput is an input counted by finesse.
+type FinesseInput int
+
+const (
+	FinesseLeft FinesseInput = iota
+	FinesseRight
+	FinesseDASLeft  // Holding left until the Tetrimino reaches the wall.
+	FinesseDASRight // Holding right until the Tetrimino reaches the wall.
+	FinesseRotateClockwise
+	FinesseRotateCounterClockwise
+)
+
+func (i FinesseInput) String() string {
+	switch i {
+	case FinesseLeft:
+		return "left"
+	case FinesseRight:
+		return "right"
+	case FinesseDASLeft:
+		return "DAS left"
+	case FinesseDASRight:
+		return "DAS right"
+	case FinesseRotateClockwise:
+		return "rotate clockwise"
+	case FinesseRotateCounterClockwise:
+		return "rotate counter-clockwise"
+	}
+	return fmt.Sprintf("FinesseInput(%d)", int(i))
+}
+
+// Placement is a column and orientation a Tetrimino can be hard dropped from, and the fewest inputs needed to move
+// the Tetrimino there from where it spawns.
+type Placement struct {
+	Tetrimino *Tetrimino
+	Inputs    int
+	Path      []FinesseInput // The inputs, in the order they are used.
+}
+
+// FinessePlacements returns every distinct placement of the spawned Tetrimino in the given Matrix, in the order they
+// are first reached. Placements are distinct if they cover different columns with a different shape, so the
+// orientations of the I, S, Z and O Tetriminos which look the same are the same placement.
+//
+// The inputs follow standard finesse rules: each move left or right and each rotation is one input, and so is
+// holding left or right to auto-shift (DAS) the Tetrimino to the wall. Finesse is measured on an empty Matrix, so the
+// given Matrix should usually be empty. Use solver.Path for the inputs which play a placement in a game.
+func FinessePlacements(spawn *Tetrimino, matrix Matrix) []Placement {
+	inputs := []FinesseInput{
+		FinesseLeft, FinesseRight, FinesseDASLeft, FinesseDASRight, FinesseRotateClockwise, FinesseRotateCounterClockwise,
+	}
+	moves := []func(t *Tetrimino){
+		func(t *Tetrimino) { t.MoveLeft(matrix) },
+		func(t *Tetrimino) { t.MoveRight(matrix) },
+		func(t *Tetrimino) {
+			for {
+				if !t.MoveLeft(matrix) {
+					break
+				}
+			}
+		},
+		func(t *Tetrimino) {
+			for {
+				if !t.MoveRight(matrix) {
+					break
+				}
+			}
+		},
+		// Rotation only fails for invalid rotation compasses, in which case the Tetrimino is not modified.
+		func(t *Tetrimino) { _ = t.Rotate(matrix, true) },
+		func(t *Tetrimino) { _ = t.Rotate(matrix, false) },
+	}
+
+	var placements []Placement
+	seenFootprints := make(map[string]bool)
+	SearchMoves(spawn, moves, func(t *Tetrimino, path func() []int) bool {
+		if fp := footprint(t); !seenFootprints[fp] {
+			seenFootprints[fp] = true
+			moved := path()
+			p := Placement{Tetrimino: t.DeepCopy(), Inputs: len(moved), Path: make([]FinesseInput, 0, len(moved))}
+			for _, m := range moved {
+				p.Path = append(p.Path, inputs[m])
+			}
+			placements = append(placements, p)
+		}
+		return false
+	})
+	return placements
+}
+
+// FinesseInputs returns the fewest inputs needed to move the spawned Tetrimino to the columns and orientation of the
+// given placement, following the rules of FinessePlacements. An error is returned if the placement cannot be reached.
+func FinesseInputs(spawn, placement *Tetrimino, matrix Matrix) (int, error) {
+	target := footprint(placement)
+	for _, p := range FinessePlacements(spawn, matrix) {
+		if footprint(p.Tetrimino) == target {
+			return p.Inputs, nil
+		}
+	}
+	return 0, fmt.Errorf("placement of Tetrimino %q cannot be reached", placement.Value)
+}
+
+// SamePlacement returns true if the Tetriminos cover the same columns with the same shape, regardless of their rows.
+func SamePlacement(a, b *Tetrimino) bool {
+	return footprint(a) == footprint(b)
+}
+
+// footprint returns a key of the columns covered by each row of the Tetrimino's minos, ignoring its vertical position.
+func footprint(t *Tetrimino) string {
+	var minos []string
+	topRow := -1
+	for row := range t.Cells {
+		for col := range t.Cells[row] {
+			if !t.Cells[row][col] {
+				continue
+			}
+			if topRow == -1 {
+				topRow = row
+			}
+			minos = append(minos, fmt.Sprintf("%d,%d", row-topRow, t.Position.X+col))
+		}
+	}
+	slices.Sort(minos)
+	return strings.Join(minos, ";")
+}
+
+// Finesse is the number of inputs used to place a Tetrimino, and the fewest inputs it could have been placed with.
+type Finesse struct {
+	Inputs  int
+	Minimum int
+}
+
+// IsFault returns true if more inputs were used than needed.
+func (f Finesse) IsFault() bool {
+	return f.Inputs > f.Minimum
+}
@@ -0,0 +1,104 @@
+package tetris
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinessePlacements(t *testing.T) {
+	tt := map[string]struct {
+		value byte
+		want  int
+	}{
+		"I has 7 flat and 10 upright placements":                            {value: 'I', want: 17},
+		"O has 9 placements":                                                {value: 'O', want: 9},
+		"T has 8 flat and 9 upright placements in each of two orientations": {value: 'T', want: 34},
+		"S has 8 flat and 9 upright placements":                             {value: 'S', want: 17},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			matrix, err := NewMatrix(40, 10)
+			require.NoError(t, err)
+			spawn, err := GetTetrimino(tc.value)
+			require.NoError(t, err)
+			spawn.Position.Y += DefaultDimensions.Buffer
+
+			placements := FinessePlacements(spawn, matrix)
+			assert.Len(t, placements, tc.want)
+			assert.Equal(t, 0, placements[0].Inputs)
+			for _, p := range placements {
+				assert.Len(t, p.Path, p.Inputs)
+			}
+		})
+	}
+}
+
+func TestFinesseInputs(t *testing.T) {
+	matrix, err := NewMatrix(40, 10)
+	require.NoError(t, err)
+
+	tt := map[string]struct {
+		value     byte
+		placement func(t *testing.T, tet *Tetrimino)
+		want      int
+	}{
+		"spawn": {
+			value:     'T',
+			placement: func(_ *testing.T, _ *Tetrimino) {},
+			want:      0,
+		},
+		"DAS to the left wall": {
+			value:     'T',
+			placement: func(_ *testing.T, tet *Tetrimino) { tet.Position.X = 0 },
+			want:      1,
+		},
+		"one column from the left wall": {
+			value:     'T',
+			placement: func(_ *testing.T, tet *Tetrimino) { tet.Position.X = 1 },
+			want:      2,
+		},
+		"rotate, DAS to the wall and tap back": {
+			value: 'J',
+			placement: func(t *testing.T, tet *Tetrimino) {
+				require.NoError(t, tet.Rotate(matrix, true))
+				tet.Position.X = 6
+			},
+			want: 3,
+		},
+		"rotate and DAS to the right wall": {
+			value: 'J',
+			placement: func(t *testing.T, tet *Tetrimino) {
+				require.NoError(t, tet.Rotate(matrix, true))
+				tet.Position.X = 8
+			},
+			want: 2,
+		},
+		"the upright I is reached from either rotation": {
+			value: 'I',
+			placement: func(t *testing.T, tet *Tetrimino) {
+				require.NoError(t, tet.Rotate(matrix, false))
+				require.NoError(t, tet.Rotate(matrix, false))
+				require.NoError(t, tet.Rotate(matrix, false))
+			},
+			want: 1,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			spawn, err := GetTetrimino(tc.value)
+			require.NoError(t, err)
+			spawn.Position.Y += DefaultDimensions.Buffer
+
+			placement := spawn.DeepCopy()
+			tc.placement(t, placement)
+
+			got, err := FinesseInputs(spawn, placement, matrix)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package tetris
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// GarbageCell is the value of the minos in garbage rows.
+// Garbage rows are added to the Matrix by the game, rather than by the player locking down Tetriminos.
+const GarbageCell byte = 'X'
+
+// IsGarbageCell returns true if the cell is a mino of a garbage row.
+func IsGarbageCell(cell byte) bool {
+	return cell == GarbageCell
+}
+
+// AddGarbageRows pushes the contents of the Matrix up and fills the bottom rows with garbage.
+// Each element of holes is the column of the only empty cell in a garbage row, ordered from top to bottom.
+// It returns true if any minos were pushed out of the top of the Matrix (ie. Top Out).
+func (m *Matrix) AddGarbageRows(holes []int) (bool, error) {
+	n := len(holes)
+	if n > len(*m) {
+		return false, fmt.Errorf("cannot add %d garbage rows to a matrix with %d rows", n, len(*m))
+	}
+	for _, hole := range holes {
+		if m.isOutOfBoundsHorizontally(hole) {
+			return false, fmt.Errorf("garbage hole at col %d is out of bounds", hole)
+		}
+	}
+
+	toppedOut := false
+	for row := range n {
+		for _, cell := range (*m)[row] {
+			if !isCellEmpty(cell) {
+				toppedOut = true
+			}
+		}
+	}
+
+	width := len((*m)[0])
+	copy(*m, (*m)[n:])
+	for i, hole := range holes {
+		garbage := make([]byte, width)
+		for col := range garbage {
+			if col != hole {
+				garbage[col] = GarbageCell
+			}
+		}
+		(*m)[len(*m)-n+i] = garbage
+	}
+
+	return toppedOut, nil
+}
+
+// FillWalls fills the empty cells outside of a well with garbage, in the rows from the given row to the bottom of the
+// Matrix. The well is the given number of columns, starting at the given column.
+// This creates the walls of a well for combo practice, and refills them after lines are cleared.
+func (m *Matrix) FillWalls(fromRow, wellCol, wellWidth int) error {
+	if wellWidth < 1 || m.isOutOfBoundsHorizontally(wellCol) || m.isOutOfBoundsHorizontally(wellCol+wellWidth-1) {
+		return fmt.Errorf("well of %d columns at col %d is out of bounds", wellWidth, wellCol)
+	}
+	if m.isOutOfBoundsVertically(fromRow) {
+		return fmt.Errorf("row %d is out of bounds", fromRow)
+	}
+
+	for row := fromRow; row < len(*m); row++ {
+		for col := range (*m)[row] {
+			if (col < wellCol || col >= wellCol+wellWidth) && isCellEmpty((*m)[row][col]) {
+				(*m)[row][col] = GarbageCell
+			}
+		}
+	}
+	return nil
+}
+
+// CountGarbageRows returns the number of rows which contain at least one garbage mino.
+func (m *Matrix) CountGarbageRows() int {
+	count := 0
+	for row := range *m {
+		for _, cell := range (*m)[row] {
+			if IsGarbageCell(cell) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// GenerateGarbageHoles returns the hole columns for the given number of garbage rows, ordered from top to bottom.
+// Messiness is the percentage chance (0-100) that the hole of each row moves to a different column than the hole
+// of the row below it; at 0 every hole is in the same column and at 100 every hole moves.
+func GenerateGarbageHoles(r *rand.Rand, width, rows, messiness int) []int {
+	holes := make([]int, rows)
+	if rows == 0 {
+		return holes
+	}
+
+	hole := r.IntN(width)
+	for i := rows - 1; i >= 0; i-- {
+		if i < rows-1 && width > 1 && r.IntN(100) < messiness {
+			// Move to any other column.
+			hole = (hole + 1 + r.IntN(width-1)) % width
+		}
+		holes[i] = hole
+	}
+	return holes
+}
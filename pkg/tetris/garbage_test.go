@@ -0,0 +1,172 @@
+package tetris
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrix_AddGarbageRows(t *testing.T) {
+	tt := map[string]struct {
+		matrix        Matrix
+		holes         []int
+		want          Matrix
+		wantToppedOut bool
+		wantErr       error
+	}{
+		"empty matrix": {
+			matrix: Matrix{
+				{0, 0, 0},
+				{0, 0, 0},
+				{0, 0, 0},
+			},
+			holes: []int{0, 2},
+			want: Matrix{
+				{0, 0, 0},
+				{0, 'X', 'X'},
+				{'X', 'X', 0},
+			},
+		},
+		"push stack up": {
+			matrix: Matrix{
+				{0, 0, 0},
+				{0, 0, 0},
+				{'T', 'T', 0},
+			},
+			holes: []int{1},
+			want: Matrix{
+				{0, 0, 0},
+				{'T', 'T', 0},
+				{'X', 0, 'X'},
+			},
+		},
+		"top out": {
+			matrix: Matrix{
+				{0, 0, 0},
+				{'I', 0, 0},
+				{'I', 0, 0},
+			},
+			holes: []int{1, 1},
+			want: Matrix{
+				{'I', 0, 0},
+				{'X', 0, 'X'},
+				{'X', 0, 'X'},
+			},
+			wantToppedOut: true,
+		},
+		"hole out of bounds": {
+			matrix: Matrix{
+				{0, 0, 0},
+			},
+			holes:   []int{3},
+			wantErr: errors.New("garbage hole at col 3 is out of bounds"),
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			toppedOut, err := tc.matrix.AddGarbageRows(tc.holes)
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantToppedOut, toppedOut)
+			assert.Equal(t, tc.want, tc.matrix)
+		})
+	}
+}
+
+func TestMatrix_CountGarbageRows(t *testing.T) {
+	m := Matrix{
+		{0, 0, 0},
+		{'T', 'T', 0},
+		{'T', 'X', 'X'},
+		{'X', 0, 'X'},
+	}
+	assert.Equal(t, 2, m.CountGarbageRows())
+	assert.True(t, IsGarbageCell(m[3][0]))
+	assert.False(t, IsGarbageCell(m[2][0]))
+}
+
+func TestMatrix_FillWalls(t *testing.T) {
+	tt := map[string]struct {
+		fromRow   int
+		wellCol   int
+		wellWidth int
+		want      Matrix
+		wantErr   bool
+	}{
+		"well in the middle": {
+			fromRow:   1,
+			wellCol:   1,
+			wellWidth: 2,
+			want: Matrix{
+				{0, 0, 0, 0},
+				{'X', 0, 0, 'X'},
+				{'T', 'T', 0, 'X'},
+			},
+		},
+		"well on the left": {
+			fromRow:   2,
+			wellCol:   0,
+			wellWidth: 3,
+			want: Matrix{
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+				{'T', 'T', 0, 'X'},
+			},
+		},
+		"well out of bounds": {
+			wellCol:   2,
+			wellWidth: 3,
+			wantErr:   true,
+		},
+		"row out of bounds": {
+			fromRow:   3,
+			wellCol:   1,
+			wellWidth: 2,
+			wantErr:   true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			m := Matrix{
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+				{'T', 'T', 0, 0},
+			}
+			err := m.FillWalls(tc.fromRow, tc.wellCol, tc.wellWidth)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, m)
+		})
+	}
+}
+
+func TestGenerateGarbageHoles(t *testing.T) {
+	r := rand.New(rand.NewPCG(0, 0))
+
+	holes := GenerateGarbageHoles(r, 10, 8, 0)
+	require.Len(t, holes, 8)
+	for _, hole := range holes {
+		assert.Equal(t, holes[0], hole, "holes should be aligned with no messiness")
+	}
+
+	holes = GenerateGarbageHoles(r, 10, 8, 100)
+	require.Len(t, holes, 8)
+	for i := 1; i < len(holes); i++ {
+		assert.NotEqual(t, holes[i-1], holes[i], "holes should always move with full messiness")
+		assert.GreaterOrEqual(t, holes[i], 0)
+		assert.Less(t, holes[i], 10)
+	}
+
+	assert.Empty(t, GenerateGarbageHoles(r, 10, 0, 50))
+}
@@ -0,0 +1,203 @@
+package tetris
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FrameDuration is the duration of one frame at 60 frames per second.
+// Gravity curves taken from frame-based games are converted to time using this.
+const FrameDuration = time.Second / 60
+
+// InstantGravity is the number of rows per frame (ie. 20G) at which a Tetrimino falls to the bottom of the
+// Matrix as soon as it spawns or moves.
+const InstantGravity = 20
+
+// Gravity describes how fast a Tetrimino falls.
+type Gravity struct {
+	// Interval is the time between each fall.
+	Interval time.Duration
+
+	// Rows is the number of rows fallen each Interval. It is greater than 1 when falling faster than 1G.
+	Rows int
+}
+
+// GravityCurve determines the Gravity for each level.
+type GravityCurve interface {
+	// Name returns the name used to select this curve (eg. in config).
+	Name() string
+
+	// Gravity returns the Gravity at the given level.
+	Gravity(level int) Gravity
+}
+
+// GetGravityCurve returns the gravity curve with the given name.
+// Names are case-insensitive. An empty name returns GuidelineGravity.
+func GetGravityCurve(name string) (GravityCurve, error) {
+	switch strings.ToLower(name) {
+	case "", "guideline":
+		return GuidelineGravity, nil
+	case "nes":
+		return NESGravity, nil
+	case "tgm":
+		return TGMGravity, nil
+	default:
+		return nil, fmt.Errorf("unknown gravity curve %q", name)
+	}
+}
+
+var (
+	// GuidelineGravity is the gravity curve of modern guideline games: (0.8 - ((level - 1) * 0.007))^(level - 1)
+	// seconds per row.
+	GuidelineGravity GravityCurve = guidelineGravity{}
+
+	// NESGravity is the gravity curve of the original NES Tetris, measured in frames per row.
+	// Levels start at 1 rather than 0, so level 1 is NES level 0.
+	NESGravity GravityCurve = &tableGravity{
+		name:     "NES",
+		unitsPer: 1,
+		perRow:   true,
+		table: []gravityStep{
+			{0, 48}, {1, 43}, {2, 38}, {3, 33}, {4, 28}, {5, 23}, {6, 18}, {7, 13}, {8, 8}, {9, 6},
+			{10, 5}, {13, 4}, {16, 3}, {19, 2}, {29, 1},
+		},
+		levelOffset: -1,
+	}
+
+	// TGMGravity is the internal gravity curve of Tetris The Grand Master, measured in 1/256 rows per frame.
+	// It reaches 20G at level 500 and is intended for modes with levels from 0 to 999.
+	TGMGravity GravityCurve = &tableGravity{
+		name:     "TGM",
+		unitsPer: 256,
+		table: []gravityStep{
+			{0, 4}, {30, 6}, {35, 8}, {40, 10}, {50, 12}, {60, 16}, {70, 32}, {80, 48}, {90, 64},
+			{100, 80}, {120, 96}, {140, 112}, {160, 128}, {170, 144}, {200, 4}, {220, 32}, {230, 64},
+			{233, 96}, {236, 128}, {239, 160}, {243, 192}, {247, 224}, {251, 256}, {300, 512},
+			{330, 768}, {360, 1024}, {400, 1280}, {420, 1024}, {450, 768}, {500, 5120},
+		},
+	}
+)
+
+type guidelineGravity struct{}
+
+func (guidelineGravity) Name() string {
+	return "Guideline"
+}
+
+func (guidelineGravity) Gravity(level int) Gravity {
+	decrementedLevel := float64(level - 1)
+	speed := math.Pow(0.8-(decrementedLevel*0.007), decrementedLevel)
+	speed *= float64(time.Second)
+
+	return Gravity{
+		Interval: time.Duration(speed),
+		Rows:     1,
+	}
+}
+
+// gravityStep is the gravity value used from a level until the next step.
+type gravityStep struct {
+	level int
+	value int
+}
+
+// tableGravity is a GravityCurve defined by a table of frame-based gravity values.
+type tableGravity struct {
+	name  string
+	table []gravityStep
+
+	// perRow is true if values are frames per row, otherwise they are rows per frame.
+	perRow bool
+
+	// unitsPer is the number of value units in one frame (if perRow) or one row (otherwise).
+	unitsPer int
+
+	// levelOffset is added to the level before looking up the table.
+	levelOffset int
+}
+
+func (t *tableGravity) Name() string {
+	return t.name
+}
+
+func (t *tableGravity) Gravity(level int) Gravity {
+	level += t.levelOffset
+	i := sort.Search(len(t.table), func(i int) bool {
+		return t.table[i].level > level
+	})
+	value := t.table[max(i-1, 0)].value
+
+	if t.perRow {
+		return Gravity{
+			Interval: FrameDuration * time.Duration(value) / time.Duration(t.unitsPer),
+			Rows:     1,
+		}
+	}
+
+	// Slower than 1G: fall one row every (unitsPer / value) frames.
+	if value < t.unitsPer {
+		return Gravity{
+			Interval: FrameDuration * time.Duration(t.unitsPer) / time.Duration(value),
+			Rows:     1,
+		}
+	}
+	return Gravity{
+		Interval: FrameDuration,
+		Rows:     min(value/t.unitsPer, InstantGravity),
+	}
+}
+
+// Delays are the timings around a Tetrimino locking down and the next Tetrimino spawning.
+type Delays struct {
+	// ARE is the delay between a Tetrimino locking down and the next Tetrimino spawning.
+	ARE time.Duration
+
+	// LineClear is added to ARE when lines are cleared.
+	LineClear time.Duration
+
+	// Lock is the time a Tetrimino can rest on a surface before it locks down.
+	Lock time.Duration
+}
+
+// DelayCurve determines the Delays for each level.
+type DelayCurve interface {
+	// Delays returns the Delays at the given level.
+	Delays(level int) Delays
+}
+
+// MasterDelays is the DelayCurve of a TGM-style Master mode, where the delays shrink from level 500.
+var MasterDelays DelayCurve = delayTable{
+	{level: 0, are: 25, lineClear: 40, lock: 30},
+	{level: 500, are: 25, lineClear: 25, lock: 30},
+	{level: 600, are: 25, lineClear: 16, lock: 30},
+	{level: 700, are: 16, lineClear: 12, lock: 30},
+	{level: 800, are: 12, lineClear: 6, lock: 30},
+	{level: 900, are: 12, lineClear: 6, lock: 17},
+}
+
+// delayStep contains the delays, in frames, used from a level until the next step.
+type delayStep struct {
+	level     int
+	are       int
+	lineClear int
+	lock      int
+}
+
+// delayTable is a DelayCurve defined by a table of frame-based delays.
+type delayTable []delayStep
+
+func (t delayTable) Delays(level int) Delays {
+	i := sort.Search(len(t), func(i int) bool {
+		return t[i].level > level
+	})
+	step := t[max(i-1, 0)]
+
+	return Delays{
+		ARE:       FrameDuration * time.Duration(step.are),
+		LineClear: FrameDuration * time.Duration(step.lineClear),
+		Lock:      FrameDuration * time.Duration(step.lock),
+	}
+}
@@ -0,0 +1,142 @@
+package tetris
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGravityCurve(t *testing.T) {
+	tt := map[string]struct {
+		name    string
+		want    GravityCurve
+		wantErr error
+	}{
+		"empty": {
+			name: "",
+			want: GuidelineGravity,
+		},
+		"guideline": {
+			name: "guideline",
+			want: GuidelineGravity,
+		},
+		"NES": {
+			name: "NES",
+			want: NESGravity,
+		},
+		"TGM": {
+			name: "TGM",
+			want: TGMGravity,
+		},
+		"unknown": {
+			name:    "TAP",
+			wantErr: errors.New("unknown gravity curve \"TAP\""),
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			result, err := GetGravityCurve(tc.name)
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, result)
+		})
+	}
+}
+
+func TestGravityCurve_Gravity(t *testing.T) {
+	tt := map[string]struct {
+		curve GravityCurve
+		level int
+		want  Gravity
+	}{
+		"guideline; level 1": {
+			curve: GuidelineGravity,
+			level: 1,
+			want:  Gravity{Interval: time.Second, Rows: 1},
+		},
+		"NES; level 1": {
+			curve: NESGravity,
+			level: 1,
+			want:  Gravity{Interval: 48 * FrameDuration, Rows: 1},
+		},
+		"NES; level 20": {
+			curve: NESGravity,
+			level: 20,
+			want:  Gravity{Interval: 2 * FrameDuration, Rows: 1},
+		},
+		"NES; level 30": {
+			curve: NESGravity,
+			level: 30,
+			want:  Gravity{Interval: FrameDuration, Rows: 1},
+		},
+		"TGM; level 0": {
+			curve: TGMGravity,
+			level: 0,
+			want:  Gravity{Interval: 64 * FrameDuration, Rows: 1},
+		},
+		"TGM; level 34": {
+			curve: TGMGravity,
+			level: 34,
+			want:  Gravity{Interval: FrameDuration * 256 / 6, Rows: 1},
+		},
+		"TGM; level 251": {
+			curve: TGMGravity,
+			level: 251,
+			want:  Gravity{Interval: FrameDuration, Rows: 1},
+		},
+		"TGM; level 300": {
+			curve: TGMGravity,
+			level: 300,
+			want:  Gravity{Interval: FrameDuration, Rows: 2},
+		},
+		"TGM; level 999": {
+			curve: TGMGravity,
+			level: 999,
+			want:  Gravity{Interval: FrameDuration, Rows: InstantGravity},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.curve.Gravity(tc.level))
+		})
+	}
+}
+
+func TestMasterDelays(t *testing.T) {
+	tt := map[string]struct {
+		level int
+		want  Delays
+	}{
+		"level 0": {
+			level: 0,
+			want:  Delays{ARE: 25 * FrameDuration, LineClear: 40 * FrameDuration, Lock: 30 * FrameDuration},
+		},
+		"level 650": {
+			level: 650,
+			want:  Delays{ARE: 25 * FrameDuration, LineClear: 16 * FrameDuration, Lock: 30 * FrameDuration},
+		},
+		"level 999": {
+			level: 999,
+			want:  Delays{ARE: 12 * FrameDuration, LineClear: 6 * FrameDuration, Lock: 17 * FrameDuration},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, MasterDelays.Delays(tc.level))
+
+			f := NewFall(tc.level, WithDelayCurve(MasterDelays), WithARE(time.Hour))
+			assert.Equal(t, tc.want.ARE, f.ARE)
+			assert.Equal(t, tc.want.LineClear, f.LineClearDelay)
+			assert.Equal(t, tc.want.Lock, f.LockDelay)
+		})
+	}
+}
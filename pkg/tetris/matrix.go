@@ -8,6 +8,9 @@ import (
 // Matrix represents the board of cells on which the game is played.
 type Matrix [][]byte
 
+// bufferHeight is the number of rows above the visible Matrix, in which Tetriminos spawn.
+const bufferHeight = 20
+
 // DefaultMatrix creates a new Matrix with a height of 40 and a width of 10.
 func DefaultMatrix() Matrix {
 	m, err := NewMatrix(40, 10)
@@ -20,7 +23,7 @@ func DefaultMatrix() Matrix {
 // NewMatrix creates a new Matrix with the given height and width.
 // It returns an error if the height is less than 20 to allow for a buffer zone of 20 lines.
 func NewMatrix(height, width int) (Matrix, error) {
-	if height <= 20 {
+	if height <= bufferHeight {
 		return nil, errors.New("matrix height must be greater than 20 to allow for a buffer zone of 20 lines")
 	}
 
@@ -31,19 +34,67 @@ func NewMatrix(height, width int) (Matrix, error) {
 	return matrix, nil
 }
 
+// Dimensions are the size of a Matrix.
+type Dimensions struct {
+	Width  int // The number of columns.
+	Height int // The number of visible rows.
+	Buffer int // The number of rows in the buffer zone above the visible rows, where Tetriminos spawn.
+}
+
+// DefaultDimensions are the dimensions of the standard Matrix, which is 10 wide with 20 visible rows below a buffer
+// zone of 20 rows.
+var DefaultDimensions = Dimensions{Width: 10, Height: 20, Buffer: bufferHeight}
+
+// Validate returns an error if Tetriminos cannot be played in a Matrix of these dimensions.
+// Every Tetrimino must fit within the width and visible height, and must be able to spawn in the buffer zone.
+func (d Dimensions) Validate() error {
+	if d.Width < 4 {
+		return fmt.Errorf("matrix width %d must be at least 4", d.Width)
+	}
+	if d.Height < 4 {
+		return fmt.Errorf("matrix height %d must be at least 4", d.Height)
+	}
+	if d.Buffer < 2 {
+		return fmt.Errorf("matrix buffer height %d must be at least 2", d.Buffer)
+	}
+	return nil
+}
+
+// Visible returns the visible rows of a Matrix with these dimensions.
+func (d Dimensions) Visible(m Matrix) Matrix {
+	return m[d.Buffer:]
+}
+
+// NewMatrixWithDimensions creates a new Matrix with the given dimensions, with the buffer zone above the visible rows.
+// The skyline of the Matrix is the buffer height, and Dimensions.Visible returns its visible rows.
+func NewMatrixWithDimensions(d Dimensions) (Matrix, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	matrix := make(Matrix, d.Buffer+d.Height)
+	for i := range matrix {
+		matrix[i] = make([]byte, d.Width)
+	}
+	return matrix, nil
+}
+
 // GetHeight returns the height of the Matrix.
 func (m *Matrix) GetHeight() int {
 	return len(*m)
 }
 
-// GetSkyline returns the skyline; the highest row that the player can see.
-func (m *Matrix) GetSkyline() int {
-	return len(*m) - 20
-}
-
-// GetVisible returns the Matrix without the buffer zone at the top (ie. the visible portion of the Matrix).
-func (m *Matrix) GetVisible() Matrix {
-	return (*m)[20:]
+// IsEmpty returns true if there are no minos in the Matrix.
+// This can be used to detect a Perfect Clear after lines have been removed.
+func (m *Matrix) IsEmpty() bool {
+	for row := range *m {
+		for _, cell := range (*m)[row] {
+			if !isCellEmpty(cell) {
+				return false
+			}
+		}
+	}
+	return true
 }
 
 func (m *Matrix) DeepCopy() *Matrix {
@@ -131,19 +182,7 @@ func (m *Matrix) RemoveCompletedLines(tet *Tetrimino) Action {
 		}
 	}
 
-	switch lines {
-	case 0:
-		return Actions.None
-	case 1:
-		return Actions.Single
-	case 2:
-		return Actions.Double
-	case 3:
-		return Actions.Triple
-	case 4:
-		return Actions.Tetris
-	}
-	return Actions.Unknown
+	return linesAction(lines)
 }
 
 func (m *Matrix) isOutOfBoundsHorizontally(col int) bool {
@@ -254,6 +254,41 @@ func TestMatrix_isOutOfBoundsVertically(t *testing.T) {
 	}
 }
 
+func TestMatrix_IsEmpty(t *testing.T) {
+	tt := map[string]struct {
+		matrix Matrix
+		want   bool
+	}{
+		"empty": {
+			matrix: Matrix{
+				{0, 0},
+				{0, 0},
+			},
+			want: true,
+		},
+		"ghost cells": {
+			matrix: Matrix{
+				{0, 0},
+				{'G', 'G'},
+			},
+			want: true,
+		},
+		"mino": {
+			matrix: Matrix{
+				{0, 0},
+				{0, 'T'},
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.matrix.IsEmpty())
+		})
+	}
+}
+
 func Test_isCellEmpty(t *testing.T) {
 	tt := []struct {
 		mino byte
@@ -461,3 +496,43 @@ func TestMatrix_RemoveTetrimino(t *testing.T) {
 // TODO:
 //   - DeepCopy
 //   - canPlaceInCell
+
+func TestNewMatrixWithDimensions(t *testing.T) {
+	tt := map[string]struct {
+		dimensions Dimensions
+		wantErr    bool
+	}{
+		"default": {
+			dimensions: DefaultDimensions,
+		},
+		"narrow and short": {
+			dimensions: Dimensions{Width: 4, Height: 8, Buffer: 2},
+		},
+		"too narrow": {
+			dimensions: Dimensions{Width: 3, Height: 20, Buffer: 20},
+			wantErr:    true,
+		},
+		"too short": {
+			dimensions: Dimensions{Width: 10, Height: 3, Buffer: 20},
+			wantErr:    true,
+		},
+		"buffer too short": {
+			dimensions: Dimensions{Width: 10, Height: 20, Buffer: 1},
+			wantErr:    true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewMatrixWithDimensions(tc.dimensions)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, got, tc.dimensions.Buffer+tc.dimensions.Height)
+			assert.Len(t, got[0], tc.dimensions.Width)
+			assert.Len(t, tc.dimensions.Visible(got), tc.dimensions.Height)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package single
 
 import (
+	"slices"
 	"time"
 
 	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
@@ -13,6 +14,10 @@ func (g *Game) IsGameOver() bool {
 func (g *Game) GetVisibleMatrix() (tetris.Matrix, error) {
 	matrix := g.matrix.DeepCopy()
 
+	if g.inEntryDelay {
+		return g.dims.Visible(*matrix), nil
+	}
+
 	if g.ghostTet != nil {
 		err := matrix.AddTetrimino(g.ghostTet)
 		if err != nil {
@@ -24,7 +29,7 @@ func (g *Game) GetVisibleMatrix() (tetris.Matrix, error) {
 		return nil, err
 	}
 
-	return matrix.GetVisible(), nil
+	return g.dims.Visible(*matrix), nil
 }
 
 func (g *Game) GetBagTetriminos() []tetris.Tetrimino {
@@ -47,6 +52,116 @@ func (g *Game) GetLinesCleared() int {
 	return g.scoring.Lines()
 }
 
+func (g *Game) GetCombo() int {
+	return g.scoring.Combo()
+}
+
+func (g *Game) GetMaxCombo() int {
+	return g.scoring.MaxCombo()
+}
+
+func (g *Game) GetPerfectClears() int {
+	return g.scoring.PerfectClears()
+}
+
+// GetGrade returns the grade awarded by the scoring rules, or an empty string if they do not award grades.
+func (g *Game) GetGrade() string {
+	return g.scoring.Grade()
+}
+
+// IsInEntryDelay returns true if the game is waiting for the next Tetrimino to spawn (see Fall.EntryDelay).
+func (g *Game) IsInEntryDelay() bool {
+	return g.inEntryDelay
+}
+
+// GetGarbageRemaining returns the number of rows in the Matrix which contain garbage.
+func (g *Game) GetGarbageRemaining() int {
+	return g.matrix.CountGarbageRows()
+}
+
+// GetPiecesPlaced returns the number of Tetriminos which have locked down.
+func (g *Game) GetPiecesPlaced() int {
+	return g.piecesPlaced
+}
+
+// GetPendingGarbage returns the number of garbage rows waiting to rise into the Matrix.
+func (g *Game) GetPendingGarbage() int {
+	return g.pendingGarbage
+}
+
+// IsGarbageCleared returns true if the game ended because all garbage rows were cleared.
+func (g *Game) IsGarbageCleared() bool {
+	return g.garbageCleared
+}
+
+// GetLastAction returns the Action performed by the last Tetrimino to lock down.
+func (g *Game) GetLastAction() tetris.Action {
+	return g.lastAction
+}
+
+// IsPerfectClear returns true if the last Tetrimino to lock down caused a Perfect Clear.
+func (g *Game) IsPerfectClear() bool {
+	return g.perfectClear
+}
+
 func (g *Game) GetDefaultFallInterval() time.Duration {
 	return g.fall.DefaultInterval
 }
+
+// GetLastFinesse returns the finesse of the last Tetrimino to lock down.
+func (g *Game) GetLastFinesse() tetris.Finesse {
+	return g.lastFinesse
+}
+
+// GetFinesseFaults returns the number of Tetriminos which were placed with more inputs than needed.
+func (g *Game) GetFinesseFaults() int {
+	return g.finesseFaults
+}
+
+// GetLastPlacement returns the last Tetrimino to lock down, where it locked down. Nil means none have locked down.
+func (g *Game) GetLastPlacement() *tetris.Tetrimino {
+	return g.lastPlacement
+}
+
+// IsLastPlacementHeld returns true if the last Tetrimino to lock down entered play by holding, even if the Tetrimino
+// it was swapped with is the same type.
+func (g *Game) IsLastPlacementHeld() bool {
+	return g.lastHeld
+}
+
+// GetTetInPlay returns a copy of the Tetrimino in play.
+func (g *Game) GetTetInPlay() *tetris.Tetrimino {
+	return g.tetInPlay.DeepCopy()
+}
+
+// GetSkyline returns the number of rows of the Matrix above the visible Matrix.
+func (g *Game) GetSkyline() int {
+	return g.dims.Buffer
+}
+
+// GetDimensions returns the dimensions of the Matrix. When playing Big these are the dimensions of the logical Matrix.
+func (g *Game) GetDimensions() tetris.Dimensions {
+	return g.dims
+}
+
+// GetVisiblePieceIDs returns the number of the Tetrimino (starting at 1) which filled each cell of the visible Matrix.
+// Empty cells, garbage and the cells of the starting Matrix are 0. Comparing these with GetPiecesPlaced shows how long
+// ago each cell was filled (eg. to hide minos when playing invisible).
+func (g *Game) GetVisiblePieceIDs() [][]int {
+	visible := g.pieceIDs[g.dims.Buffer:]
+	ids := make([][]int, len(visible))
+	for row := range visible {
+		ids[row] = slices.Clone(visible[row])
+	}
+	return ids
+}
+
+// GetMatrix returns a copy of the whole Matrix (including the buffer zone), without the Tetrimino in play or ghost.
+func (g *Game) GetMatrix() tetris.Matrix {
+	return *g.matrix.DeepCopy()
+}
+
+// CanHold returns true if the Tetrimino in play can be held.
+func (g *Game) CanHold() bool {
+	return g.canHold
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand/v2"
+	"slices"
 	"time"
 
 	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
@@ -12,16 +13,52 @@ import (
 // Game represents a single player game of Tetris.
 // This can be used for Marathon, Sprint, Ultra and other single player modes.
 type Game struct {
-	matrix           tetris.Matrix     // The Matrix of cells on which the game is played
-	nextQueue        *tetris.NextQueue // The queue of upcoming Tetriminos
-	tetInPlay        *tetris.Tetrimino // The current Tetrimino in play
-	ghostTet         *tetris.Tetrimino // The ghost Tetrimino
-	holdQueue        *tetris.Tetrimino // The Tetrimino that is being held
-	canHold          bool              // Whether the player can hold the current Tetrimino
-	gameOver         bool              // Whether the game is over
-	softDropStartRow int               // Records where the user began soft drop
-	scoring          *tetris.Scoring   // The scoring system
-	fall             *tetris.Fall      // The system for calculating the fall speed
+	matrix           tetris.Matrix         // The Matrix of cells on which the game is played
+	dims             tetris.Dimensions     // The dimensions of the Matrix
+	nextQueue        *tetris.NextQueue     // The queue of upcoming Tetriminos
+	tetInPlay        *tetris.Tetrimino     // The current Tetrimino in play
+	ghostTet         *tetris.Tetrimino     // The ghost Tetrimino
+	holdQueue        *tetris.Tetrimino     // The Tetrimino that is being held
+	canHold          bool                  // Whether the player can hold the current Tetrimino
+	gameOver         bool                  // Whether the game is over
+	softDropStartRow int                   // Records where the user began soft drop
+	scoring          *tetris.Scoring       // The scoring system
+	fall             *tetris.Fall          // The system for calculating the fall speed
+	rotationSystem   tetris.RotationSystem // The system defining how Tetriminos spawn and rotate
+	lastAction       tetris.Action         // The Action performed by the last Tetrimino to lock down
+	perfectClear     bool                  // Whether the last Tetrimino to lock down caused a Perfect Clear
+	inEntryDelay     bool                  // Whether the game is waiting for the next Tetrimino to spawn
+	isLocking        bool                  // Whether the Tetrimino in play is resting on a surface during lock delay
+	lastMoveRotation bool                  // Whether the last successful movement of the Tetrimino in play was a rotation
+
+	endOnGarbageCleared bool       // Whether the game should end when all garbage rows are cleared
+	endOnComboBreak     bool       // Whether the game should end when a Tetrimino locks down without clearing lines
+	prefill             *Prefill   // The well whose walls are refilled when lines are cleared
+	garbageCleared      bool       // Whether all garbage rows were cleared
+	pendingGarbage      int        // The number of garbage rows waiting to rise into the Matrix
+	rand                *rand.Rand // The random source used to generate garbage
+
+	piecesPlaced int     // The number of Tetriminos which have locked down
+	pieceIDs     [][]int // The number of the Tetrimino (starting at 1) which filled each cell of the Matrix, or 0
+
+	spawnTet      *tetris.Tetrimino // The Tetrimino in play as it was when it spawned
+	inputs        int               // The number of moves and rotations used on the Tetrimino in play
+	shift         int               // The direction (-1 left, 1 right) of the last run of moves, or 0 after a rotation
+	shiftStart    int               // The number of inputs before the moves in the current shift direction
+	lastPlacement *tetris.Tetrimino // The last Tetrimino to lock down, where it locked down
+	lastHeld      bool              // Whether the last Tetrimino to lock down entered play by holding
+	lastFinesse   tetris.Finesse    // The finesse of the last Tetrimino to lock down
+	finesseFaults int               // The number of Tetriminos placed with more inputs than needed
+
+	// The finesse placements of each spawned Tetrimino, which are found once per game since they only depend on the
+	// rotation system and dimensions.
+	finessePlacements map[finesseKey][]tetris.Placement
+}
+
+// finesseKey identifies a spawned Tetrimino by its value and where it spawned.
+type finesseKey struct {
+	value           byte
+	x, y, direction int
 }
 
 type Input struct {
@@ -33,33 +70,167 @@ type Input struct {
 	MaxLines      int  // The maximum number of lines to clear before the game ends. 0 means no limit.
 	EndOnMaxLines bool // Whether the game should end when the maximum number of lines is cleared.
 
-	GhostEnabled bool       // Whether the ghost Tetrimino should be displayed.
-	Rand         *rand.Rand // The random source to use for Tetrimino generation.
+	GhostEnabled bool              // Whether the ghost Tetrimino should be displayed.
+	Rand         *rand.Rand        // The random source to use for Tetrimino generation.
+	Randomizer   tetris.Randomizer // The order Tetriminos are dealt in. Nil means a 7-bag.
+
+	RotationSystem tetris.RotationSystem // The rotation system to use. Nil means SRS.
+	ScoringRules   tetris.ScoringRules   // The scoring rules to use. Nil means guideline rules.
+	GravityCurve   tetris.GravityCurve   // The gravity curve to use. Nil means guideline gravity.
+
+	ARE            time.Duration // The delay between a Tetrimino locking down and the next one spawning.
+	LineClearDelay time.Duration // The delay added to ARE when lines are cleared.
+	LockDelay      time.Duration // The time a Tetrimino can rest on a surface before it locks down.
+
+	DelayCurve tetris.DelayCurve // The delays to use at each level. Overrides ARE, LineClearDelay and LockDelay.
+
+	GarbageRows         int  // The number of garbage rows at the bottom of the Matrix when the game starts.
+	GarbageMessiness    int  // The chance (0-100) that the hole of each garbage row moves from the row below.
+	EndOnGarbageCleared bool // Whether the game should end when all garbage rows are cleared.
+
+	Matrix   tetris.Matrix // The Matrix when the game starts. Nil means an empty Matrix.
+	Sequence []byte        // A fixed sequence of Tetriminos to play, instead of those dealt by the Randomizer.
+	Hold     byte          // The Tetrimino in the hold slot when the game starts. 0 means it is empty.
+
+	// Prefill fills the visible Matrix outside of a well with garbage, and refills it whenever lines are cleared so the
+	// walls of the well never run out. Nil means no prefill.
+	Prefill *Prefill
+	// EndOnComboBreak ends the game when a Tetrimino locks down without clearing lines.
+	EndOnComboBreak bool
+
+	// Dimensions is the size of the Matrix. The zero value means tetris.DefaultDimensions.
+	Dimensions tetris.Dimensions
+
+	// Big plays every Tetrimino at 2x scale. The game is played on a logical Matrix of half the width and visible
+	// height, in which each cell is a 2x2 block of the displayed Matrix. Garbage rows are halved to match.
+	Big bool
+}
+
+// Prefill is the garbage filling the visible Matrix outside of a well, for practicing combos.
+type Prefill struct {
+	WellColumn int // The leftmost column of the well.
+	WellWidth  int // The number of columns in the well.
+
+	// The minos at the bottom of the well when the game starts, from top to bottom. Each row has a value for each
+	// column of the well.
+	Residue [][]bool
+}
+
+// apply fills the walls of the well and adds the residue to the bottom of it. Visible is the first visible row.
+func (p *Prefill) apply(matrix tetris.Matrix, visible int) error {
+	if len(p.Residue) >= len(matrix)-visible {
+		return fmt.Errorf("residue of %d rows does not fit in the matrix", len(p.Residue))
+	}
+	err := matrix.FillWalls(visible, p.WellColumn, p.WellWidth)
+	if err != nil {
+		return err
+	}
+
+	top := len(matrix) - len(p.Residue)
+	for i, row := range p.Residue {
+		if len(row) != p.WellWidth {
+			return fmt.Errorf("residue row %d has %d columns, want %d", i, len(row), p.WellWidth)
+		}
+		for col, filled := range row {
+			if filled {
+				matrix[top+i][p.WellColumn+col] = tetris.GarbageCell
+			}
+		}
+	}
+	return nil
 }
 
 func NewGame(in *Input) (*Game, error) {
-	matrix, err := tetris.NewMatrix(40, 10)
+	matrix, dims, err := newMatrix(in)
 	if err != nil {
 		return nil, err
 	}
-	nq := tetris.NewNextQueue(matrix.GetSkyline(), tetris.WithRandSource(in.Rand))
+	garbageRows := in.GarbageRows
+	if in.Big {
+		garbageRows = (garbageRows + 1) / 2
+	}
+	if garbageRows < 0 || garbageRows >= dims.Height {
+		return nil, fmt.Errorf("invalid garbage rows '%d'", in.GarbageRows)
+	}
+	r := in.Rand
+	if r == nil {
+		//nolint:gosec // This random source is not for any security-related tasks.
+		r = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	if garbageRows > 0 {
+		holes := tetris.GenerateGarbageHoles(r, len(matrix[0]), garbageRows, in.GarbageMessiness)
+		if _, err = matrix.AddGarbageRows(holes); err != nil {
+			return nil, fmt.Errorf("failed to add garbage rows: %w", err)
+		}
+	}
+	if in.Prefill != nil {
+		if err = in.Prefill.apply(matrix, dims.Buffer); err != nil {
+			return nil, fmt.Errorf("invalid prefill: %w", err)
+		}
+	}
 
+	rs := in.RotationSystem
+	if rs == nil {
+		rs = tetris.SRS
+	}
+	nq, err := newNextQueue(in, r, rs, dims)
+	if err != nil {
+		return nil, err
+	}
+
+	hold := tetris.GetEmptyTetrimino()
+	if in.Hold != 0 {
+		hold, err = tetris.GetTetriminoFor(in.Hold, rs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hold: %w", err)
+		}
+		hold.Position.Y += dims.Buffer
+		hold.Position.X = tetris.SpawnColumn(hold.Position.X, dims.Width)
+	}
+
+	var scoringOpts []func(*tetris.Scoring)
+	if in.ScoringRules != nil {
+		scoringOpts = append(scoringOpts, tetris.WithScoringRules(in.ScoringRules))
+	}
 	scoring, err := tetris.NewScoring(
 		in.Level, in.MaxLevel, in.IncreaseLevel, in.EndOnMaxLevel, in.MaxLines, in.EndOnMaxLines,
+		scoringOpts...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create scoring system: %w", err)
 	}
 
+	fallOpts := []func(*tetris.Fall){
+		tetris.WithARE(in.ARE),
+		tetris.WithLineClearDelay(in.LineClearDelay),
+		tetris.WithLockDelay(in.LockDelay),
+	}
+	if in.GravityCurve != nil {
+		fallOpts = append(fallOpts, tetris.WithGravityCurve(in.GravityCurve))
+	}
+	if in.DelayCurve != nil {
+		fallOpts = append(fallOpts, tetris.WithDelayCurve(in.DelayCurve))
+	}
+
 	g := &Game{
 		matrix:           matrix,
+		dims:             dims,
 		nextQueue:        nq,
 		tetInPlay:        nq.Next(),
-		holdQueue:        tetris.GetEmptyTetrimino(),
+		holdQueue:        hold,
 		gameOver:         false,
 		softDropStartRow: matrix.GetHeight(),
 		scoring:          scoring,
-		fall:             tetris.NewFall(in.Level),
+		fall:             tetris.NewFall(in.Level, fallOpts...),
+		rotationSystem:   rs,
+
+		endOnGarbageCleared: in.EndOnGarbageCleared && in.GarbageRows > 0,
+		endOnComboBreak:     in.EndOnComboBreak,
+		prefill:             in.Prefill,
+		rand:                r,
+
+		pieceIDs:          emptyPieceIDs(len(matrix), len(matrix[0])),
+		finessePlacements: make(map[finesseKey][]tetris.Placement),
 	}
 
 	if in.GhostEnabled {
@@ -74,22 +245,125 @@ func NewGame(in *Input) (*Game, error) {
 	return g, nil
 }
 
+// newMatrix creates the Matrix the game starts with, which is the starting Matrix of the input if it has one.
+// When playing Big the visible Matrix is half the height and width of the input dimensions, since each cell is shown
+// at 2x scale. The dimensions of the Matrix are returned with it.
+func newMatrix(in *Input) (tetris.Matrix, tetris.Dimensions, error) {
+	dims := in.Dimensions
+	if dims == (tetris.Dimensions{}) {
+		dims = tetris.DefaultDimensions
+	}
+	if in.Big {
+		if in.Matrix != nil {
+			return nil, dims, errors.New("a starting matrix cannot be played big")
+		}
+		dims.Width /= 2
+		dims.Height /= 2
+	}
+
+	matrix, err := tetris.NewMatrixWithDimensions(dims)
+	if err != nil {
+		return nil, dims, fmt.Errorf("invalid dimensions: %w", err)
+	}
+	if in.Matrix != nil {
+		if len(in.Matrix) != len(matrix) || len(in.Matrix[0]) != len(matrix[0]) {
+			return nil, dims, fmt.Errorf("invalid matrix size, want %dx%d", len(matrix), len(matrix[0]))
+		}
+		matrix = *in.Matrix.DeepCopy()
+	}
+	return matrix, dims, nil
+}
+
+// newNextQueue creates the Next Queue, which deals the fixed sequence of the input if it has one.
+func newNextQueue(
+	in *Input, r *rand.Rand, rs tetris.RotationSystem, dims tetris.Dimensions,
+) (*tetris.NextQueue, error) {
+	nqOpts := []func(*tetris.NextQueue){
+		tetris.WithRandSource(r),
+		tetris.WithRotationSystem(rs),
+		tetris.WithMatrixWidth(dims.Width),
+	}
+	if in.Randomizer != nil {
+		nqOpts = append(nqOpts, tetris.WithRandomizer(in.Randomizer))
+	}
+	if len(in.Sequence) > 0 {
+		sequence := make([]tetris.Tetrimino, len(in.Sequence))
+		for i, value := range in.Sequence {
+			tet, err := tetris.GetTetriminoFor(value, rs)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sequence: %w", err)
+			}
+			sequence[i] = *tet
+		}
+		nqOpts = append(nqOpts, tetris.WithSequence(sequence))
+	}
+	return tetris.NewNextQueue(dims.Buffer, nqOpts...), nil
+}
+
 func (g *Game) MoveLeft() {
-	_ = g.tetInPlay.MoveLeft(g.matrix)
+	if g.inEntryDelay {
+		return
+	}
+	if g.tetInPlay.MoveLeft(g.matrix) {
+		g.lastMoveRotation = false
+	}
+	g.countShift(-1, !g.tetInPlay.DeepCopy().MoveLeft(g.matrix))
+	g.applyInstantGravity()
 	g.updateGhost()
 }
 
 func (g *Game) MoveRight() {
-	_ = g.tetInPlay.MoveRight(g.matrix)
+	if g.inEntryDelay {
+		return
+	}
+	if g.tetInPlay.MoveRight(g.matrix) {
+		g.lastMoveRotation = false
+	}
+	g.countShift(1, !g.tetInPlay.DeepCopy().MoveRight(g.matrix))
+	g.applyInstantGravity()
 	g.updateGhost()
 }
 
+// countShift counts a move left (-1) or right (1) as an input. Moves in the same direction which end against the wall
+// or the stack count as one input, since holding the key to auto-shift (DAS) there is one input.
+func (g *Game) countShift(direction int, blocked bool) {
+	if g.shift != direction {
+		g.shift = direction
+		g.shiftStart = g.inputs
+	}
+	g.inputs++
+	if blocked {
+		g.inputs = g.shiftStart + 1
+	}
+}
+
+// SoftDrop moves the Tetrimino in play down one row, scoring it as a soft drop. Unlike gravity it never locks the
+// Tetrimino down. False is returned if it cannot move down.
+func (g *Game) SoftDrop() bool {
+	if g.inEntryDelay || !g.tetInPlay.MoveDown(g.matrix) {
+		return false
+	}
+	g.lastMoveRotation = false
+	g.scoring.AddSoftDrop(1)
+	return true
+}
+
 func (g *Game) Rotate(clockwise bool) error {
+	if g.inEntryDelay {
+		return nil
+	}
+	g.inputs++
+	g.shift = 0
+	before := g.tetInPlay.CompassDirection
 	err := g.tetInPlay.Rotate(g.matrix, clockwise)
 	if err != nil {
 		return err
 	}
+	if g.tetInPlay.CompassDirection != before {
+		g.lastMoveRotation = true
+	}
 
+	g.applyInstantGravity()
 	g.updateGhost()
 	return nil
 }
@@ -100,14 +374,19 @@ func (g *Game) Rotate(clockwise bool) error {
 // If not allowed to hold, no action is taken.
 // If true is returned the game is over.
 func (g *Game) Hold() (bool, error) {
-	if !g.canHold {
+	if !g.canHold || g.inEntryDelay {
 		return false, nil
 	}
 
 	// Swap the current tetrimino with the hold tetrimino
 	if g.holdQueue.Value == 0 {
+		next := g.nextQueue.Next()
+		if next == nil {
+			// There is no Tetrimino to replace the one in play.
+			return false, nil
+		}
 		g.holdQueue = g.tetInPlay
-		g.tetInPlay = g.nextQueue.Next()
+		g.tetInPlay = next
 	} else {
 		g.holdQueue, g.tetInPlay = g.tetInPlay, g.holdQueue
 	}
@@ -119,27 +398,45 @@ func (g *Game) Hold() (bool, error) {
 	}
 
 	// Reset the hold tetrimino
-	t, err := tetris.GetTetrimino(g.holdQueue.Value)
+	t, err := tetris.GetTetriminoFor(g.holdQueue.Value, g.rotationSystem)
 	if err != nil {
 		return false, err
 	}
 	g.holdQueue = t.DeepCopy()
-	g.holdQueue.Position.Y += g.matrix.GetSkyline()
+	g.holdQueue.Position.Y += g.dims.Buffer
+	g.holdQueue.Position.X = tetris.SpawnColumn(g.holdQueue.Position.X, g.dims.Width)
 
 	g.canHold = false
 	return false, nil
 }
 
-// TickLower moves the current Tetrimino down one row.
-// This should be triggered at a regular interval calculated using Fall.
-// If the Tetrimino cannot move down, it is locked in place and true is returned.
+// TickLower moves the current Tetrimino down by the rows per tick of the gravity curve (usually one row).
+// This should be triggered at a regular interval calculated using Fall (see GetFallInterval).
+// If the Tetrimino cannot move down, it is locked in place and true is returned. When there is a lock delay,
+// the first tick after the Tetrimino lands starts the lock delay instead.
+// If the game is waiting for the entry delay to pass, the next Tetrimino is spawned instead.
 // Game Over is updated if needed.
 func (g *Game) TickLower() (bool, error) {
+	if g.inEntryDelay {
+		return g.spawnNextTet(), nil
+	}
+
+	if g.fall.RowsPerTick > 1 && g.dropTetInPlay(g.fall.RowsPerTick) > 0 {
+		g.isLocking = false
+		return false, nil
+	}
+
+	if g.fall.LockDelay > 0 && !g.isLocking && !g.tetInPlay.DeepCopy().MoveDown(g.matrix) {
+		g.isLocking = true
+		return false, nil
+	}
+
 	lockedDown, err := g.lowerTetInPlay()
 	if err != nil {
 		return false, fmt.Errorf("failed to lower tetrimino: %w", err)
 	}
 	if !lockedDown {
+		g.isLocking = false
 		return false, nil
 	}
 	if g.gameOver {
@@ -153,16 +450,13 @@ func (g *Game) TickLower() (bool, error) {
 		}
 	}
 
-	g.tetInPlay = g.nextQueue.Next()
-	gameOver := g.setupNewTetInPlay()
-	if gameOver {
-		g.gameOver = gameOver
-	}
-
-	return gameOver, nil
+	return g.lockedDown(), nil
 }
 
 func (g *Game) HardDrop() (bool, error) {
+	if g.inEntryDelay {
+		return false, nil
+	}
 	startRow := g.tetInPlay.Position.Y
 
 	for {
@@ -181,19 +475,87 @@ func (g *Game) HardDrop() (bool, error) {
 	linesCleared := g.tetInPlay.Position.Y - startRow
 	g.scoring.AddHardDrop(linesCleared)
 
+	return g.lockedDown(), nil
+}
+
+// QueueGarbage adds the given number of garbage rows to the pending garbage.
+// Pending garbage is cancelled by clearing lines, otherwise it rises into the Matrix when the next Tetrimino
+// locks down without clearing lines.
+func (g *Game) QueueGarbage(rows int) {
+	g.pendingGarbage += rows
+}
+
+// lockedDown should be called after the Tetrimino in play locks down.
+// Pending garbage is cancelled or raised into the Matrix, and then the next Tetrimino is spawned immediately,
+// unless there is an entry delay (ARE and line clear delay), in which case it is spawned by the next call to
+// TickLower. If true is returned the game is over.
+func (g *Game) lockedDown() bool {
+	if g.raisePendingGarbage() {
+		g.gameOver = true
+		return true
+	}
+
+	if g.fall.EntryDelay(g.lastAction.LinesCleared()) > 0 {
+		g.inEntryDelay = true
+		return false
+	}
+	return g.spawnNextTet()
+}
+
+// raisePendingGarbage cancels pending garbage with the lines cleared by the last Tetrimino to lock down.
+// If no lines were cleared, all pending garbage rises into the Matrix as a single block with one hole.
+// If true is returned the garbage pushed minos out of the top of the Matrix (ie. Top Out).
+func (g *Game) raisePendingGarbage() bool {
+	if g.pendingGarbage == 0 {
+		return false
+	}
+
+	if lines := g.lastAction.LinesCleared(); lines > 0 {
+		g.pendingGarbage = max(g.pendingGarbage-lines, 0)
+		return false
+	}
+
+	rows := min(g.pendingGarbage, g.matrix.GetHeight())
+	g.pendingGarbage = 0
+	holes := tetris.GenerateGarbageHoles(g.rand, len(g.matrix[0]), rows, 0)
+
+	// The holes are always in bounds, so no error can occur.
+	toppedOut, _ := g.matrix.AddGarbageRows(holes)
+	g.raisePieceIDs(rows)
+	return toppedOut
+}
+
+// spawnNextTet draws the next Tetrimino from the Next Queue and sets it up in play.
+// If true is returned the game is over.
+func (g *Game) spawnNextTet() bool {
+	g.inEntryDelay = false
+	if g.scoring.ProcessSpawn() {
+		g.gameOver = true
+		return true
+	}
+	g.fall.CalculateFallSpeeds(g.scoring.Level())
+
 	g.tetInPlay = g.nextQueue.Next()
+	if g.tetInPlay == nil {
+		// The fixed sequence of Tetriminos has been played.
+		g.gameOver = true
+		return true
+	}
 	gameOver := g.setupNewTetInPlay()
 	if gameOver {
 		g.gameOver = gameOver
 	}
-
-	return gameOver, nil
+	return gameOver
 }
 
 // ToggleSoftDrop toggles the Soft Drop state of the game.
 // If Soft Drop is enabled, the game will calculate the number of lines cleared and add them to the score.
 func (g *Game) ToggleSoftDrop() {
 	g.fall.ToggleSoftDrop()
+	if g.inEntryDelay {
+		// The soft drop start row is recorded when the next Tetrimino spawns.
+		return
+	}
 	if g.fall.IsSoftDrop {
 		g.softDropStartRow = g.tetInPlay.Position.Y
 		return
@@ -203,17 +565,30 @@ func (g *Game) ToggleSoftDrop() {
 	if linesCleared > 0 {
 		g.scoring.AddSoftDrop(linesCleared)
 	}
-	g.softDropStartRow = g.matrix.GetSkyline()
+	g.softDropStartRow = g.dims.Buffer
 }
 
 // GetFallInterval returns the time interval for the Fall system.
+// During an entry delay this is the time until the next Tetrimino spawns,
+// and during a lock delay it is the time until the Tetrimino locks down.
 func (g *Game) GetFallInterval() time.Duration {
+	if g.inEntryDelay {
+		return g.fall.EntryDelay(g.lastAction.LinesCleared())
+	}
+	if g.isLocking {
+		return g.fall.LockDelay
+	}
 	if g.fall.IsSoftDrop {
 		return g.fall.SoftDropInterval
 	}
 	return g.fall.DefaultInterval
 }
 
+// AddTime passes the time played to the scoring system, for rules which depend on it (eg. grade requirements).
+func (g *Game) AddTime(elapsed time.Duration) {
+	g.scoring.AddTime(elapsed)
+}
+
 // EndGame sets Game.gameOver to true.
 func (g *Game) EndGame() {
 	g.gameOver = true
@@ -226,6 +601,7 @@ func (g *Game) EndGame() {
 // the Game.gameOver value will be set to true.
 func (g *Game) lowerTetInPlay() (bool, error) {
 	if g.tetInPlay.MoveDown(g.matrix) {
+		g.lastMoveRotation = false
 		return false, nil
 	}
 
@@ -234,7 +610,20 @@ func (g *Game) lowerTetInPlay() (bool, error) {
 		return false, err
 	}
 
+	// The Tetrimino is placed even if it ends the game, so it is counted before any game over.
+	g.piecesPlaced++
+	g.lastPlacement = g.tetInPlay.DeepCopy()
+	// Holding is only allowed again once a new Tetrimino enters play, so the Tetrimino in play was held if it is not.
+	g.lastHeld = !g.canHold
+	g.judgeFinesse()
+	g.trackPieceIDs()
+
+	tSpin := tetris.TSpinNone
+	if g.lastMoveRotation {
+		tSpin = g.matrix.DetectTSpin(g.tetInPlay)
+	}
 	action := g.matrix.RemoveCompletedLines(g.tetInPlay)
+	action = tetris.TSpinAction(tSpin, action.LinesCleared())
 	if !action.IsValid() {
 		return false, fmt.Errorf("invalid action received %q", action.String())
 	}
@@ -247,8 +636,29 @@ func (g *Game) lowerTetInPlay() (bool, error) {
 		g.gameOver = true
 	}
 
+	g.lastAction = action
+	g.perfectClear = action.LinesCleared() > 0 && g.matrix.IsEmpty()
+	if g.perfectClear {
+		if err = g.scoring.AddPerfectClear(action); err != nil {
+			return false, fmt.Errorf("failed to add perfect clear: %w", err)
+		}
+	}
+
 	g.fall.CalculateFallSpeeds(g.scoring.Level())
 
+	if g.prefill != nil && action.LinesCleared() > 0 {
+		// The well is known to be in bounds, since it was filled when the game started.
+		_ = g.matrix.FillWalls(g.dims.Buffer, g.prefill.WellColumn, g.prefill.WellWidth)
+	}
+	if g.endOnComboBreak && action.LinesCleared() == 0 {
+		g.gameOver = true
+	}
+
+	if g.endOnGarbageCleared && action.LinesCleared() > 0 && g.matrix.CountGarbageRows() == 0 {
+		g.garbageCleared = true
+		g.gameOver = true
+	}
+
 	return true, nil
 }
 
@@ -256,10 +666,15 @@ func (g *Game) lowerTetInPlay() (bool, error) {
 //   - If possible, move down one row into the visible Matrix.
 //   - Check for Lock Out & Block Out game over conditions.
 //   - Reset Game.softDropStartRow if currently Soft Dropping.
-//   - Set Game.canHold to true.
+//   - Set Game.canHold to true and reset the lock delay.
+//   - If the gravity is 20G, drop it to the bottom of the Matrix.
 //
 // It does not modify Game.tetInPlay. If true is returned the game is over.
 func (g *Game) setupNewTetInPlay() bool {
+	g.spawnTet = g.tetInPlay.DeepCopy()
+	g.inputs = 0
+	g.shift = 0
+
 	// Block Out
 	if !g.tetInPlay.IsValid(g.matrix, false) {
 		g.gameOver = true
@@ -268,22 +683,115 @@ func (g *Game) setupNewTetInPlay() bool {
 
 	if !g.tetInPlay.MoveDown(g.matrix) {
 		// Lock Out
-		if g.tetInPlay.IsAboveSkyline(g.matrix.GetSkyline()) {
+		if g.tetInPlay.IsAboveSkyline(g.dims.Buffer) {
 			g.gameOver = true
 			return true
 		}
 	}
 
 	g.canHold = true
+	g.isLocking = false
+	g.lastMoveRotation = false
 
 	if g.fall.IsSoftDrop {
 		g.softDropStartRow = g.tetInPlay.Position.Y
 	}
 
+	g.applyInstantGravity()
 	g.updateGhost()
 	return false
 }
 
+// judgeFinesse compares the inputs used to place the Tetrimino in play with the fewest inputs needed on an empty
+// Matrix, and counts a finesse fault if more were used.
+func (g *Game) judgeFinesse() {
+	spawn := g.spawnTet
+	key := finesseKey{spawn.Value, spawn.Position.X, spawn.Position.Y, spawn.CompassDirection}
+	placements, ok := g.finessePlacements[key]
+	if !ok {
+		empty, err := tetris.NewMatrixWithDimensions(g.dims)
+		if err != nil {
+			return
+		}
+		placements = tetris.FinessePlacements(spawn, empty)
+		g.finessePlacements[key] = placements
+	}
+
+	i := slices.IndexFunc(placements, func(p tetris.Placement) bool {
+		return tetris.SamePlacement(p.Tetrimino, g.tetInPlay)
+	})
+	if i < 0 {
+		// The placement cannot be judged, so it is not a fault.
+		g.lastFinesse = tetris.Finesse{}
+		return
+	}
+
+	g.lastFinesse = tetris.Finesse{Inputs: g.inputs, Minimum: placements[i].Inputs}
+	if g.lastFinesse.IsFault() {
+		g.finesseFaults++
+	}
+}
+
+// trackPieceIDs records the Tetrimino in play as the filler of its cells, and removes the rows it completes so that
+// Game.pieceIDs matches the Matrix. It must be called after the Tetrimino is added to the Matrix and counted, and
+// before completed lines are removed from it.
+func (g *Game) trackPieceIDs() {
+	id := g.piecesPlaced
+	tet := g.tetInPlay
+	for row := range tet.Cells {
+		for col := range tet.Cells[row] {
+			if tet.Cells[row][col] {
+				g.pieceIDs[tet.Position.Y+row][tet.Position.X+col] = id
+			}
+		}
+	}
+
+	// Lines are removed from the top row of the Tetrimino down, the same as Matrix.RemoveCompletedLines.
+	for row := range tet.Cells {
+		r := tet.Position.Y + row
+		if !slices.Contains(g.matrix[r], 0) {
+			copy(g.pieceIDs[1:r+1], g.pieceIDs[:r])
+			g.pieceIDs[0] = make([]int, len(g.matrix[0]))
+		}
+	}
+}
+
+// raisePieceIDs moves Game.pieceIDs up by the given number of garbage rows, so that it matches the Matrix.
+func (g *Game) raisePieceIDs(rows int) {
+	copy(g.pieceIDs, g.pieceIDs[rows:])
+	for row := len(g.pieceIDs) - rows; row < len(g.pieceIDs); row++ {
+		g.pieceIDs[row] = make([]int, len(g.matrix[0]))
+	}
+}
+
+// emptyPieceIDs returns the piece IDs of an empty Matrix with the given height and width.
+func emptyPieceIDs(height, width int) [][]int {
+	ids := make([][]int, height)
+	for row := range ids {
+		ids[row] = make([]int, width)
+	}
+	return ids
+}
+
+// dropTetInPlay moves the current Tetrimino down by up to the given number of rows without locking it down.
+// The number of rows moved is returned.
+func (g *Game) dropTetInPlay(rows int) int {
+	for i := range rows {
+		if !g.tetInPlay.MoveDown(g.matrix) {
+			return i
+		}
+		g.lastMoveRotation = false
+	}
+	return rows
+}
+
+// applyInstantGravity moves the current Tetrimino to the bottom of the Matrix if the gravity is 20G.
+func (g *Game) applyInstantGravity() {
+	if g.fall.IsInstant() {
+		g.dropTetInPlay(g.matrix.GetHeight())
+	}
+}
+
 func (g *Game) updateGhost() {
 	if g.ghostTet == nil {
 		return
@@ -2,11 +2,14 @@ package single
 
 import (
 	"math/rand/v2"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
 )
 
 func TestToggleSoftDrop(t *testing.T) {
@@ -70,3 +73,783 @@ func TestToggleSoftDrop(t *testing.T) {
 		})
 	}
 }
+
+func TestHardDrop_PerfectClear(t *testing.T) {
+	game, err := NewGame(&Input{
+		Level: 1,
+		Rand:  rand.New(rand.NewPCG(0, 0)),
+	})
+	require.NoError(t, err)
+
+	// Fill the bottom row, except for the 4 columns on the left.
+	bottom := game.matrix.GetHeight() - 1
+	for col := 4; col < len(game.matrix[bottom]); col++ {
+		game.matrix[bottom][col] = 'X'
+	}
+
+	tet, err := tetris.GetTetrimino('I')
+	require.NoError(t, err)
+	tet.Position = tetris.Coordinate{X: 0, Y: game.dims.Buffer}
+	game.tetInPlay = tet
+
+	gameOver, err := game.HardDrop()
+	require.NoError(t, err)
+	require.False(t, gameOver)
+
+	assert.True(t, game.IsPerfectClear())
+	assert.Equal(t, tetris.Actions.Single, game.GetLastAction())
+	assert.Equal(t, 1, game.GetPerfectClears())
+	assert.Equal(t, 1, game.GetLinesCleared())
+}
+
+func TestNewGame_InstantGravity(t *testing.T) {
+	game, err := NewGame(&Input{
+		Level:        500,
+		Rand:         rand.New(rand.NewPCG(0, 0)),
+		GravityCurve: tetris.TGMGravity,
+	})
+	require.NoError(t, err)
+
+	// At 20G the Tetrimino lands as soon as it spawns.
+	assert.False(t, game.tetInPlay.DeepCopy().MoveDown(game.matrix))
+
+	// Moving keeps it on the floor.
+	game.MoveLeft()
+	assert.False(t, game.tetInPlay.DeepCopy().MoveDown(game.matrix))
+
+	// The next tick locks it down.
+	_, err = game.TickLower()
+	require.NoError(t, err)
+	assert.False(t, game.matrix.IsEmpty())
+}
+
+func TestTickLower_MultipleRows(t *testing.T) {
+	game, err := NewGame(&Input{
+		Level:        300,
+		Rand:         rand.New(rand.NewPCG(0, 0)),
+		GravityCurve: tetris.TGMGravity,
+	})
+	require.NoError(t, err)
+	startY := game.tetInPlay.Position.Y
+
+	gameOver, err := game.TickLower()
+	require.NoError(t, err)
+	assert.False(t, gameOver)
+	assert.Equal(t, startY+2, game.tetInPlay.Position.Y)
+}
+
+func TestSoftDrop(t *testing.T) {
+	game, err := NewGame(&Input{Level: 1, Rand: rand.New(rand.NewPCG(0, 0))})
+	require.NoError(t, err)
+	startY := game.tetInPlay.Position.Y
+
+	assert.True(t, game.SoftDrop())
+	assert.Equal(t, startY+1, game.tetInPlay.Position.Y)
+	assert.Equal(t, 1, game.GetTotalScore())
+
+	// Once the Tetrimino lands it stays in play.
+	for {
+		if !game.SoftDrop() {
+			break
+		}
+	}
+	assert.False(t, game.SoftDrop())
+	assert.Zero(t, game.GetPiecesPlaced())
+}
+
+func TestHardDrop_EntryDelay(t *testing.T) {
+	game, err := NewGame(&Input{
+		Level:          1,
+		Rand:           rand.New(rand.NewPCG(0, 0)),
+		ARE:            100 * time.Millisecond,
+		LineClearDelay: 400 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	// Fill the bottom row, except for the 4 columns on the left.
+	bottom := game.matrix.GetHeight() - 1
+	for col := 4; col < len(game.matrix[bottom]); col++ {
+		game.matrix[bottom][col] = 'X'
+	}
+	tet, err := tetris.GetTetrimino('I')
+	require.NoError(t, err)
+	tet.Position = tetris.Coordinate{X: 0, Y: game.dims.Buffer}
+	game.tetInPlay = tet
+
+	gameOver, err := game.HardDrop()
+	require.NoError(t, err)
+	require.False(t, gameOver)
+
+	// The next Tetrimino waits for ARE plus the line clear delay.
+	assert.True(t, game.IsInEntryDelay())
+	assert.Equal(t, 500*time.Millisecond, game.GetFallInterval())
+
+	visible, err := game.GetVisibleMatrix()
+	require.NoError(t, err)
+	assert.True(t, visible.IsEmpty())
+
+	// Inputs are ignored during the entry delay.
+	gameOver, err = game.HardDrop()
+	require.NoError(t, err)
+	assert.False(t, gameOver)
+	assert.True(t, game.IsInEntryDelay())
+
+	// The next tick spawns the next Tetrimino.
+	gameOver, err = game.TickLower()
+	require.NoError(t, err)
+	assert.False(t, gameOver)
+	assert.False(t, game.IsInEntryDelay())
+	assert.Equal(t, time.Second, game.GetFallInterval())
+}
+
+func TestTickLower_LockDelay(t *testing.T) {
+	game, err := NewGame(&Input{
+		Level:        500,
+		Rand:         rand.New(rand.NewPCG(0, 0)),
+		GravityCurve: tetris.TGMGravity,
+		LockDelay:    500 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tetris.FrameDuration, game.GetFallInterval())
+
+	// The first tick after landing starts the lock delay.
+	gameOver, err := game.TickLower()
+	require.NoError(t, err)
+	assert.False(t, gameOver)
+	assert.Equal(t, 500*time.Millisecond, game.GetFallInterval())
+	assert.True(t, game.matrix.IsEmpty())
+
+	// The next tick locks it down.
+	gameOver, err = game.TickLower()
+	require.NoError(t, err)
+	assert.False(t, gameOver)
+	assert.False(t, game.matrix.IsEmpty())
+	assert.Equal(t, tetris.FrameDuration, game.GetFallInterval())
+}
+
+func TestNewGame_Master(t *testing.T) {
+	game, err := NewGame(&Input{
+		Level:         0,
+		MaxLevel:      tetris.MasterMaxLevel,
+		IncreaseLevel: true,
+		EndOnMaxLevel: true,
+		Rand:          rand.New(rand.NewPCG(0, 0)),
+		ScoringRules:  tetris.NewMasterRules(),
+		GravityCurve:  tetris.TGMGravity,
+		DelayCurve:    tetris.MasterDelays,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, game.GetLevel())
+	assert.Equal(t, "9", game.GetGrade())
+
+	gameOver, err := game.HardDrop()
+	require.NoError(t, err)
+	require.False(t, gameOver)
+	assert.True(t, game.IsInEntryDelay())
+	assert.Equal(t, 25*tetris.FrameDuration, game.GetFallInterval())
+
+	// The level increases when the next Tetrimino spawns.
+	gameOver, err = game.TickLower()
+	require.NoError(t, err)
+	require.False(t, gameOver)
+	assert.Equal(t, 1, game.GetLevel())
+}
+
+func TestNewGame_Garbage(t *testing.T) {
+	tt := map[string]struct {
+		garbageRows int
+		wantErr     bool
+	}{
+		"none": {
+			garbageRows: 0,
+		},
+		"some": {
+			garbageRows: 10,
+		},
+		"negative": {
+			garbageRows: -1,
+			wantErr:     true,
+		},
+		"too many": {
+			garbageRows: 20,
+			wantErr:     true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			game, err := NewGame(&Input{
+				Level:       1,
+				Rand:        rand.New(rand.NewPCG(0, 0)),
+				GarbageRows: tc.garbageRows,
+			})
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.garbageRows, game.GetGarbageRemaining())
+		})
+	}
+}
+
+func TestHardDrop_GarbageCleared(t *testing.T) {
+	game, err := NewGame(&Input{
+		Level:               1,
+		Rand:                rand.New(rand.NewPCG(0, 0)),
+		GarbageRows:         1,
+		EndOnGarbageCleared: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, game.GetGarbageRemaining())
+
+	// Fill the hole of the garbage row with a vertical I.
+	bottom := game.matrix.GetHeight() - 1
+	hole := slices.Index(game.matrix[bottom], 0)
+	tet, err := tetris.GetTetrimino('I')
+	require.NoError(t, err)
+	tet.Cells = [][]bool{{true}, {true}, {true}, {true}}
+	tet.Position = tetris.Coordinate{X: hole, Y: game.dims.Buffer}
+	game.tetInPlay = tet
+
+	gameOver, err := game.HardDrop()
+	require.NoError(t, err)
+	assert.True(t, gameOver)
+	assert.True(t, game.IsGarbageCleared())
+	assert.Equal(t, 0, game.GetGarbageRemaining())
+}
+
+func TestHardDrop_QueueGarbage(t *testing.T) {
+	tt := map[string]struct {
+		pending      int
+		clearLine    bool
+		wantPending  int
+		wantGarbage  int
+		wantGameOver bool
+	}{
+		"cancelled by a line clear": {
+			pending:     2,
+			clearLine:   true,
+			wantPending: 1,
+		},
+		"rises without a line clear": {
+			pending:     2,
+			wantGarbage: 2,
+		},
+		"tops out": {
+			pending:      40,
+			wantGameOver: true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			game, err := NewGame(&Input{
+				Level: 1,
+				Rand:  rand.New(rand.NewPCG(0, 0)),
+			})
+			require.NoError(t, err)
+
+			tet, err := tetris.GetTetrimino('I')
+			require.NoError(t, err)
+			if tc.clearLine {
+				// Fill the bottom row, except for the 4 columns on the left.
+				bottom := game.matrix.GetHeight() - 1
+				for col := 4; col < len(game.matrix[bottom]); col++ {
+					game.matrix[bottom][col] = 'X'
+				}
+				tet.Position = tetris.Coordinate{X: 0, Y: game.dims.Buffer}
+				game.tetInPlay = tet
+			}
+
+			game.QueueGarbage(tc.pending)
+			assert.Equal(t, tc.pending, game.GetPendingGarbage())
+
+			gameOver, err := game.HardDrop()
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantGameOver, gameOver)
+			assert.Equal(t, tc.wantPending, game.GetPendingGarbage())
+			if !tc.wantGameOver {
+				assert.Equal(t, tc.wantGarbage, game.GetGarbageRemaining())
+			}
+		})
+	}
+}
+
+func TestNewGame_Sequence(t *testing.T) {
+	matrix, err := tetris.NewMatrix(40, 10)
+	require.NoError(t, err)
+	matrix[39][0] = 'X'
+
+	game, err := NewGame(&Input{
+		Level:    1,
+		Rand:     rand.New(rand.NewPCG(0, 0)),
+		Matrix:   matrix,
+		Sequence: []byte("TO"),
+		Hold:     'I',
+	})
+	require.NoError(t, err)
+	assert.Equal(t, byte('X'), game.matrix[39][0])
+	assert.Equal(t, byte('T'), game.tetInPlay.Value)
+	assert.Equal(t, byte('I'), game.GetHoldTetrimino().Value)
+
+	// Changing the game does not change the starting matrix.
+	game.matrix[39][1] = 'X'
+	assert.Equal(t, byte(0), matrix[39][1])
+
+	gameOver, err := game.HardDrop()
+	require.NoError(t, err)
+	require.False(t, gameOver)
+	assert.Equal(t, byte('O'), game.tetInPlay.Value)
+	assert.Equal(t, 1, game.GetPiecesPlaced())
+
+	// The game is over once the sequence has been played.
+	gameOver, err = game.HardDrop()
+	require.NoError(t, err)
+	assert.True(t, gameOver)
+	assert.Equal(t, 2, game.GetPiecesPlaced())
+}
+
+func TestHardDrop_LastPlacementHeld(t *testing.T) {
+	tt := map[string]struct {
+		hold   byte
+		doHold bool
+		want   bool
+	}{
+		"not held": {
+			want: false,
+		},
+		"held into an empty hold queue, swapping in the same type": {
+			doHold: true,
+			want:   true,
+		},
+		"held, swapping in the same type": {
+			hold:   'T',
+			doHold: true,
+			want:   true,
+		},
+		"held, swapping in another type": {
+			hold:   'I',
+			doHold: true,
+			want:   true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			game, err := NewGame(&Input{Level: 1, Sequence: []byte("TTT"), Hold: tc.hold})
+			require.NoError(t, err)
+			if tc.doHold {
+				gameOver, err := game.Hold()
+				require.NoError(t, err)
+				require.False(t, gameOver)
+				require.False(t, game.CanHold())
+			}
+
+			gameOver, err := game.HardDrop()
+			require.NoError(t, err)
+			require.False(t, gameOver)
+			assert.Equal(t, tc.want, game.IsLastPlacementHeld())
+
+			// The next Tetrimino enters play without holding.
+			_, err = game.HardDrop()
+			require.NoError(t, err)
+			assert.False(t, game.IsLastPlacementHeld())
+		})
+	}
+}
+
+func TestHardDrop_Finesse(t *testing.T) {
+	tt := map[string]struct {
+		moves     func(g *Game)
+		want      tetris.Finesse
+		wantFault bool
+	}{
+		"no inputs": {
+			moves: func(_ *Game) {},
+			want:  tetris.Finesse{Inputs: 0, Minimum: 0},
+		},
+		"fewest inputs": {
+			moves: func(g *Game) {
+				g.MoveLeft()
+				g.MoveLeft()
+			},
+			want: tetris.Finesse{Inputs: 2, Minimum: 2},
+		},
+		"DAS to the wall is one input": {
+			moves: func(g *Game) {
+				// Key repeats continue after the Tetrimino reaches the wall.
+				for range 5 {
+					g.MoveLeft()
+				}
+			},
+			want: tetris.Finesse{Inputs: 1, Minimum: 1},
+		},
+		"tapping back from the wall": {
+			moves: func(g *Game) {
+				for range 6 {
+					g.MoveRight()
+				}
+				g.MoveLeft()
+			},
+			want: tetris.Finesse{Inputs: 2, Minimum: 2},
+		},
+		"fault": {
+			moves: func(g *Game) {
+				g.MoveRight()
+				g.MoveLeft()
+				g.MoveLeft()
+			},
+			want:      tetris.Finesse{Inputs: 3, Minimum: 1},
+			wantFault: true,
+		},
+		"rotations count as inputs": {
+			moves: func(g *Game) {
+				require.NoError(t, g.Rotate(true))
+				require.NoError(t, g.Rotate(true))
+				require.NoError(t, g.Rotate(true))
+			},
+			want:      tetris.Finesse{Inputs: 3, Minimum: 1},
+			wantFault: true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			game, err := NewGame(&Input{
+				Level:    1,
+				Rand:     rand.New(rand.NewPCG(0, 0)),
+				Sequence: []byte("TT"),
+			})
+			require.NoError(t, err)
+
+			tc.moves(game)
+			_, err = game.HardDrop()
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.want, game.GetLastFinesse())
+			assert.Equal(t, tc.wantFault, game.GetLastFinesse().IsFault())
+			if tc.wantFault {
+				assert.Equal(t, 1, game.GetFinesseFaults())
+			} else {
+				assert.Equal(t, 0, game.GetFinesseFaults())
+			}
+			assert.Equal(t, byte('T'), game.GetLastPlacement().Value)
+		})
+	}
+}
+
+func TestHardDrop_FinesseCached(t *testing.T) {
+	game, err := NewGame(&Input{
+		Level:    1,
+		Rand:     rand.New(rand.NewPCG(0, 0)),
+		Sequence: []byte("TTI"),
+	})
+	require.NoError(t, err)
+
+	// The placements of each spawn are only found once.
+	for range 2 {
+		game.MoveRight()
+		game.MoveLeft()
+		_, err = game.HardDrop()
+		require.NoError(t, err)
+		assert.Equal(t, tetris.Finesse{Inputs: 2, Minimum: 0}, game.GetLastFinesse())
+	}
+	assert.Len(t, game.finessePlacements, 1)
+	assert.Equal(t, 2, game.GetFinesseFaults())
+
+	_, err = game.HardDrop()
+	require.NoError(t, err)
+	assert.Len(t, game.finessePlacements, 2)
+}
+
+func TestNewGame_Big(t *testing.T) {
+	tt := map[string]struct {
+		garbageRows int
+		matrix      tetris.Matrix
+		wantGarbage int
+		wantErr     bool
+	}{
+		"empty": {
+			garbageRows: 0,
+			wantGarbage: 0,
+		},
+		"garbage is halved": {
+			garbageRows: 5,
+			wantGarbage: 3,
+		},
+		"too much garbage": {
+			garbageRows: 19,
+			wantErr:     true,
+		},
+		"starting matrix": {
+			matrix:  tetris.DefaultMatrix(),
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			game, err := NewGame(&Input{
+				Level:       1,
+				Rand:        rand.New(rand.NewPCG(0, 0)),
+				GarbageRows: tc.garbageRows,
+				Matrix:      tc.matrix,
+				Sequence:    []byte("TI"),
+				Big:         true,
+			})
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			visible, err := game.GetVisibleMatrix()
+			require.NoError(t, err)
+			assert.Len(t, visible, 10)
+			assert.Len(t, visible[0], 5)
+			assert.Equal(t, tc.wantGarbage, game.GetGarbageRemaining())
+
+			// Tetriminos spawn in the centre of the narrower Matrix.
+			assert.Equal(t, 1, game.tetInPlay.Position.X)
+			_, err = game.Hold()
+			require.NoError(t, err)
+			assert.Equal(t, 1, game.tetInPlay.Position.X)
+			assert.Equal(t, 1, game.GetHoldTetrimino().Position.X)
+		})
+	}
+}
+
+func TestHardDrop_PieceIDs(t *testing.T) {
+	matrix, err := tetris.NewMatrix(40, 10)
+	require.NoError(t, err)
+	for col := 4; col < len(matrix[39]); col++ {
+		matrix[39][col] = 'X'
+	}
+
+	game, err := NewGame(&Input{
+		Level:    1,
+		Rand:     rand.New(rand.NewPCG(0, 0)),
+		Matrix:   matrix,
+		Sequence: []byte("IOTZ"),
+	})
+	require.NoError(t, err)
+
+	// The I clears the bottom row, so no cells are left.
+	for range 3 {
+		game.MoveLeft()
+	}
+	_, err = game.HardDrop()
+	require.NoError(t, err)
+	for _, row := range game.GetVisiblePieceIDs() {
+		assert.Equal(t, make([]int, 10), row)
+	}
+
+	// The O fills the bottom two rows.
+	_, err = game.HardDrop()
+	require.NoError(t, err)
+	ids := game.GetVisiblePieceIDs()
+	assert.Equal(t, []int{0, 0, 0, 0, 2, 2, 0, 0, 0, 0}, ids[18])
+	assert.Equal(t, []int{0, 0, 0, 0, 2, 2, 0, 0, 0, 0}, ids[19])
+
+	// The T lands on the O, then garbage rises beneath them.
+	game.QueueGarbage(1)
+	_, err = game.HardDrop()
+	require.NoError(t, err)
+	ids = game.GetVisiblePieceIDs()
+	assert.Equal(t, []int{0, 0, 0, 3, 3, 3, 0, 0, 0, 0}, ids[16])
+	assert.Equal(t, []int{0, 0, 0, 0, 2, 2, 0, 0, 0, 0}, ids[18])
+	assert.Equal(t, make([]int, 10), ids[19])
+}
+
+func TestNewGame_Dimensions(t *testing.T) {
+	tt := map[string]struct {
+		dimensions tetris.Dimensions
+		big        bool
+		want       tetris.Dimensions
+		wantSpawnX int
+		wantErr    bool
+	}{
+		"default": {
+			want:       tetris.DefaultDimensions,
+			wantSpawnX: 3,
+		},
+		"narrow with short buffer": {
+			dimensions: tetris.Dimensions{Width: 4, Height: 8, Buffer: 2},
+			want:       tetris.Dimensions{Width: 4, Height: 8, Buffer: 2},
+			wantSpawnX: 0,
+		},
+		"wide and big": {
+			dimensions: tetris.Dimensions{Width: 20, Height: 20, Buffer: 20},
+			big:        true,
+			want:       tetris.Dimensions{Width: 10, Height: 10, Buffer: 20},
+			wantSpawnX: 3,
+		},
+		"invalid": {
+			dimensions: tetris.Dimensions{Width: 2, Height: 20, Buffer: 20},
+			wantErr:    true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			game, err := NewGame(&Input{
+				Level:      1,
+				Rand:       rand.New(rand.NewPCG(0, 0)),
+				Sequence:   []byte("TT"),
+				Dimensions: tc.dimensions,
+				Big:        tc.big,
+			})
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, game.GetDimensions())
+			assert.Equal(t, tc.want.Buffer, game.GetSkyline())
+			assert.Equal(t, tc.wantSpawnX, game.tetInPlay.Position.X)
+
+			visible, err := game.GetVisibleMatrix()
+			require.NoError(t, err)
+			assert.Len(t, visible, tc.want.Height)
+			assert.Len(t, visible[0], tc.want.Width)
+
+			// The Tetrimino lands on the bottom row of the Matrix.
+			gameOver, err := game.HardDrop()
+			require.NoError(t, err)
+			require.False(t, gameOver)
+			assert.Equal(t, byte('T'), game.matrix[len(game.matrix)-1][tc.wantSpawnX])
+		})
+	}
+}
+
+func TestHardDrop_Combo(t *testing.T) {
+	game, err := NewGame(&Input{
+		Level:    1,
+		Rand:     rand.New(rand.NewPCG(0, 0)),
+		Sequence: []byte("OIO"),
+		Prefill: &Prefill{
+			WellColumn: 3,
+			WellWidth:  4,
+			Residue: [][]bool{
+				{true, false, false, false},
+				{true, true, false, false},
+			},
+		},
+		EndOnComboBreak: true,
+	})
+	require.NoError(t, err)
+
+	wantWalls := []byte{'X', 'X', 'X', 0, 0, 0, 0, 'X', 'X', 'X'}
+	visible, err := game.GetVisibleMatrix()
+	require.NoError(t, err)
+	assert.Equal(t, wantWalls, visible[5])
+	assert.Equal(t, []byte{'X', 'X', 'X', 'X', 'X', 0, 0, 'X', 'X', 'X'}, visible[19])
+
+	// The O fills the right of the bottom row.
+	game.MoveRight()
+	gameOver, err := game.HardDrop()
+	require.NoError(t, err)
+	require.False(t, gameOver)
+	assert.Equal(t, 1, game.GetLinesCleared())
+
+	// The walls are refilled after the line clear. The top row also contains the next Tetrimino.
+	visible, err = game.GetVisibleMatrix()
+	require.NoError(t, err)
+	assert.Equal(t, wantWalls[:3], visible[0][:3])
+	assert.Equal(t, wantWalls[7:], visible[0][7:])
+	assert.Equal(t, []byte{'X', 'X', 'X', 'X', 0, 'O', 'O', 'X', 'X', 'X'}, visible[19])
+
+	// A vertical I fills the hole, continuing the combo.
+	require.NoError(t, game.Rotate(true))
+	game.MoveLeft()
+	gameOver, err = game.HardDrop()
+	require.NoError(t, err)
+	require.False(t, gameOver)
+	assert.Equal(t, 1, game.GetCombo())
+
+	// The O cannot clear a line, so the combo breaks and the game ends.
+	gameOver, err = game.HardDrop()
+	require.NoError(t, err)
+	assert.True(t, gameOver)
+	assert.Equal(t, 1, game.GetMaxCombo())
+}
+
+func TestNewGame_Prefill(t *testing.T) {
+	tt := map[string]struct {
+		prefill *Prefill
+		wantErr bool
+	}{
+		"valid": {
+			prefill: &Prefill{WellColumn: 3, WellWidth: 4, Residue: [][]bool{{true, true, true, false}}},
+		},
+		"well out of bounds": {
+			prefill: &Prefill{WellColumn: 8, WellWidth: 4},
+			wantErr: true,
+		},
+		"residue too wide": {
+			prefill: &Prefill{WellColumn: 3, WellWidth: 4, Residue: [][]bool{{true, true, true, false, true}}},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewGame(&Input{
+				Level:   1,
+				Rand:    rand.New(rand.NewPCG(0, 0)),
+				Prefill: tc.prefill,
+			})
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestHardDrop_GameOverPlaced(t *testing.T) {
+	tt := map[string]struct {
+		in *Input
+	}{
+		"max lines": {
+			in: &Input{MaxLines: 1, EndOnMaxLines: true},
+		},
+		"combo break": {
+			in: &Input{EndOnComboBreak: true},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			tc.in.Level = 1
+			tc.in.Rand = rand.New(rand.NewPCG(0, 0))
+			game, err := NewGame(tc.in)
+			require.NoError(t, err)
+
+			// Fill the bottom row, except for the 4 columns on the left.
+			bottom := game.matrix.GetHeight() - 1
+			for col := 4; col < len(game.matrix[bottom]); col++ {
+				game.matrix[bottom][col] = 'X'
+			}
+			if tc.in.EndOnComboBreak {
+				// Leave the row incomplete so that the combo breaks.
+				game.matrix[bottom][4] = 0
+			}
+
+			tet, err := tetris.GetTetrimino('I')
+			require.NoError(t, err)
+			tet.Position = tetris.Coordinate{X: 0, Y: game.dims.Buffer}
+			game.tetInPlay = tet
+
+			gameOver, err := game.HardDrop()
+			require.NoError(t, err)
+			require.True(t, gameOver)
+
+			assert.Equal(t, 1, game.GetPiecesPlaced())
+			require.NotNil(t, game.GetLastPlacement())
+			assert.Equal(t, byte('I'), game.GetLastPlacement().Value)
+		})
+	}
+}
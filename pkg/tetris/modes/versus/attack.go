@@ -0,0 +1,70 @@
+package versus
+
+import (
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+)
+
+// lineAttacks are the garbage rows sent by each action which clears lines, before bonuses.
+var lineAttacks = map[tetris.Action]int{
+	tetris.Actions.Single:          0,
+	tetris.Actions.Double:          1,
+	tetris.Actions.Triple:          2,
+	tetris.Actions.Tetris:          4,
+	tetris.Actions.MiniTSpinSingle: 0,
+	tetris.Actions.TSpinSingle:     2,
+	tetris.Actions.TSpinDouble:     4,
+	tetris.Actions.TSpinTriple:     6,
+}
+
+// comboAttacks are the extra garbage rows sent by each combo count. Longer combos send the last.
+var comboAttacks = []int{0, 1, 1, 2, 2, 3, 3, 4, 4, 4, 5}
+
+const (
+	// backToBackAttack is the extra garbage row sent by a back-to-back Tetris or T-Spin.
+	backToBackAttack = 1
+	// perfectClearAttack is the extra garbage rows sent by a perfect clear.
+	perfectClearAttack = 10
+)
+
+// Attack returns the number of garbage rows sent by the action, following the guideline. The combo is the number of
+// consecutive line clears after the first (see tetris.Scoring.Combo), and backToBack is whether the action continues a
+// back-to-back chain.
+func Attack(a tetris.Action, combo int, backToBack, perfectClear bool) int {
+	if a.LinesCleared() == 0 {
+		return 0
+	}
+	attack := lineAttacks[a]
+	if combo > 0 {
+		attack += comboAttacks[min(combo, len(comboAttacks)-1)]
+	}
+	if backToBack {
+		attack += backToBackAttack
+	}
+	if perfectClear {
+		attack += perfectClearAttack
+	}
+	return attack
+}
+
+// Attacker works out the garbage a player's game attacks with, following the back-to-back chain across placements.
+// The zero value is ready to use.
+type Attacker struct {
+	backToBack bool // Whether the last action which cleared lines was a Tetris or T-Spin.
+}
+
+// Placed returns the number of garbage rows sent by the last Tetrimino to lock down in the game (see Attack). It must
+// be called once for each Tetrimino placed, so back-to-back chains are followed.
+func (a *Attacker) Placed(game *single.Game) (int, error) {
+	action := game.GetLastAction()
+	if action.LinesCleared() == 0 {
+		return 0, nil
+	}
+	starts, err := action.StartsBackToBack()
+	if err != nil {
+		return 0, err
+	}
+	attack := Attack(action, game.GetCombo(), starts && a.backToBack, game.IsPerfectClear())
+	a.backToBack = starts
+	return attack, nil
+}
@@ -0,0 +1,170 @@
+package versus
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// confidenceZ is the z-score of the 95% confidence intervals of win rates.
+const confidenceZ = 1.96
+
+// TournamentInput configures a round robin tournament.
+type TournamentInput struct {
+	Players      []*Player
+	GamesPerPair int           // The number of matches each pair of players plays.
+	MaxDuration  time.Duration // The most simulated time a match lasts before it is drawn. 0 means no limit.
+	Seed         uint64        // The seed of each pair's first two matches, incremented for each two after.
+	Workers      int           // The number of matches played at once. 0 means 1.
+}
+
+// Standing is a player's record in a tournament.
+type Standing struct {
+	Player                  *Player
+	Wins, Losses, Draws     int
+	WinRate                 float64 // The fraction of matches won, counting draws as half a win.
+	WinRateLow, WinRateHigh float64 // The 95% confidence interval of the win rate (see WilsonInterval).
+}
+
+// Pairing is the record of a pair of players against each other in a tournament.
+type Pairing struct {
+	Players [2]int // The indices of the players.
+	Wins    [2]int // The matches each player won.
+	Draws   int
+}
+
+// TournamentResult is the outcome of a tournament.
+type TournamentResult struct {
+	Standings []Standing // The players' records, best win rate first.
+	Pairings  []Pairing  // The records of each pair of players, in the order the players were given.
+}
+
+// RoundRobin plays a tournament in which every pair of players plays the given number of matches. Each pair plays
+// matches two at a time with the same seed, swapping sides for the second so neither is favoured by the Tetriminos or
+// by moving first. Matches are played in parallel, and the results do not depend on the number of workers.
+func RoundRobin(ctx context.Context, in *TournamentInput) (*TournamentResult, error) {
+	if len(in.Players) < 2 {
+		return nil, errors.New("a tournament needs at least 2 players")
+	}
+
+	type job struct {
+		pairing int
+		match   int
+	}
+	var pairings []Pairing
+	var jobs []job
+	for a := range in.Players {
+		for b := a + 1; b < len(in.Players); b++ {
+			for m := range in.GamesPerPair {
+				jobs = append(jobs, job{pairing: len(pairings), match: m})
+			}
+			pairings = append(pairings, Pairing{Players: [2]int{a, b}})
+		}
+	}
+
+	// Each match's winner is stored in its own slot, then tallied in order.
+	winners := make([]int, len(jobs))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	var nextJob atomic.Int64
+
+	for range max(in.Workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(nextJob.Add(1) - 1)
+				if i >= len(jobs) || ctx.Err() != nil {
+					return
+				}
+				j := jobs[i]
+				players := pairings[j.pairing].Players
+				// Players swap sides every match.
+				first := j.match % 2
+				result, err := Play(ctx, &Input{
+					Players:     [2]*Player{in.Players[players[first]], in.Players[players[1-first]]},
+					Seed:        in.Seed + uint64(j.match/2), //nolint:gosec // Matches are numbered from 0.
+					MaxDuration: in.MaxDuration,
+				})
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+				winners[i] = Draw
+				if result.Winner != Draw {
+					winners[i] = players[(result.Winner+first)%2]
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	standings := make([]Standing, len(in.Players))
+	for i, p := range in.Players {
+		standings[i].Player = p
+	}
+	for i, j := range jobs {
+		pairing := &pairings[j.pairing]
+		a, b := pairing.Players[0], pairing.Players[1]
+		switch winners[i] {
+		case Draw:
+			pairing.Draws++
+			standings[a].Draws++
+			standings[b].Draws++
+		case a:
+			pairing.Wins[0]++
+			standings[a].Wins++
+			standings[b].Losses++
+		default:
+			pairing.Wins[1]++
+			standings[b].Wins++
+			standings[a].Losses++
+		}
+	}
+	for i := range standings {
+		s := &standings[i]
+		matches := s.Wins + s.Losses + s.Draws
+		s.WinRate, s.WinRateLow, s.WinRateHigh = 0, 0, 1
+		if matches > 0 {
+			score := float64(s.Wins) + float64(s.Draws)/2
+			s.WinRate = score / float64(matches)
+			s.WinRateLow, s.WinRateHigh = WilsonInterval(score, matches)
+		}
+	}
+	slices.SortStableFunc(standings, func(a, b Standing) int {
+		return cmp.Compare(b.WinRate, a.WinRate)
+	})
+	return &TournamentResult{Standings: standings, Pairings: pairings}, nil
+}
+
+// WilsonInterval returns the 95% Wilson score interval of the rate of wins in the given number of matches. Unlike
+// the normal approximation, it stays within 0 and 1 and is reliable for few matches or rates near 0 or 1.
+func WilsonInterval(wins float64, matches int) (low, high float64) {
+	if matches <= 0 {
+		return 0, 1
+	}
+	n := float64(matches)
+	p := wins / n
+	z2 := confidenceZ * confidenceZ
+	centre := (p + z2/(2*n)) / (1 + z2/n)
+	margin := confidenceZ / (1 + z2/n) * math.Sqrt(p*(1-p)/n+z2/(4*n*n))
+	return max(centre-margin, 0), min(centre+margin, 1)
+}
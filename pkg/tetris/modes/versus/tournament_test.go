@@ -0,0 +1,98 @@
+package versus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+func TestWilsonInterval(t *testing.T) {
+	tt := map[string]struct {
+		wins      float64
+		matches   int
+		wantLow   float64
+		wantHigh  float64
+		tolerance float64
+	}{
+		"half of 100": {
+			wins:     50,
+			matches:  100,
+			wantLow:  0.4038,
+			wantHigh: 0.5962,
+		},
+		"all of 10": {
+			wins:     10,
+			matches:  10,
+			wantLow:  0.7225,
+			wantHigh: 1,
+		},
+		"none of 10": {
+			wins:     0,
+			matches:  10,
+			wantLow:  0,
+			wantHigh: 0.2775,
+		},
+		"no matches": {
+			wins:     0,
+			matches:  0,
+			wantLow:  0,
+			wantHigh: 1,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			low, high := WilsonInterval(tc.wins, tc.matches)
+			assert.InDelta(t, tc.wantLow, low, 0.0001)
+			assert.InDelta(t, tc.wantHigh, high, 0.0001)
+		})
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	greedy := solver.NewGreedySearch(eval.ElTetris())
+	players := []*Player{
+		{Name: "slow", Solver: greedy, PiecesPerSecond: 1},
+		{Name: "fast", Solver: greedy, PiecesPerSecond: 4},
+		{Name: "also slow", Solver: greedy, PiecesPerSecond: 1},
+	}
+
+	var results []*TournamentResult
+	for _, workers := range []int{1, 4} {
+		result, err := RoundRobin(context.Background(), &TournamentInput{
+			Players:      players,
+			GamesPerPair: 2,
+			MaxDuration:  3 * time.Minute,
+			Seed:         1,
+			Workers:      workers,
+		})
+		require.NoError(t, err)
+		results = append(results, result)
+	}
+	// The results do not depend on the number of workers.
+	assert.Equal(t, results[0], results[1])
+
+	result := results[0]
+	require.Len(t, result.Standings, 3)
+	require.Len(t, result.Pairings, 3)
+	best := result.Standings[0]
+	assert.Equal(t, "fast", best.Player.Name)
+	assert.Equal(t, 4, best.Wins+best.Losses+best.Draws)
+	assert.InDelta(t, 1, best.WinRate, 0.001)
+	assert.Less(t, best.WinRateLow, best.WinRate)
+
+	// The slow players play the same moves, and draw.
+	assert.Equal(t, [2]int{0, 2}, result.Pairings[1].Players)
+	assert.Equal(t, 2, result.Pairings[1].Draws)
+}
+
+func TestRoundRobin_TooFewPlayers(t *testing.T) {
+	_, err := RoundRobin(context.Background(), &TournamentInput{Players: []*Player{{}}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,147 @@
+// Package versus plays matches between two solvers, which attack each other with garbage. Matches are played headless
+// in simulated time, so they run as fast as the solvers can choose moves. Attacker works out the garbage sent by games
+// played in real time, such as a human against a solver.
+package versus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+// Draw is the winner of a match which neither player won.
+const Draw = -1
+
+// Player is a side of a match, played by a solver. How strong the player is depends on how far the solver searches
+// (eg. solver.WithBeamDepth) and how fast the player places Tetriminos.
+type Player struct {
+	Name            string
+	Solver          solver.Solver
+	PiecesPerSecond float64 // The number of Tetriminos placed per second of the match's simulated time.
+}
+
+// Input configures a match.
+type Input struct {
+	Players     [2]*Player
+	Seed        uint64        // The seed of both games, so both players are dealt the same Tetriminos.
+	MaxDuration time.Duration // The most simulated time the match lasts before it is drawn. 0 means no limit.
+}
+
+// Result is the outcome of a match.
+type Result struct {
+	Winner   int           // The index of the player who won, or Draw.
+	Pieces   [2]int        // The number of Tetriminos each player placed.
+	Lines    [2]int        // The number of lines each player cleared.
+	Sent     [2]int        // The number of garbage rows each player sent.
+	Duration time.Duration // The simulated time the match lasted.
+}
+
+// side is the state of a player's game.
+type side struct {
+	player   *Player
+	game     *single.Game
+	attacker Attacker
+	interval time.Duration // The time between the player's moves.
+	next     time.Duration // When the player next places a Tetrimino.
+}
+
+// Play plays the match until a player tops out or cannot move, the most time has passed, or the context is done. The
+// players take turns as their speeds allow. Each placement sends garbage to the opponent (see Attack), which rises
+// into their Matrix when their next Tetrimino locks down without clearing lines. Clearing lines cancels pending
+// garbage instead, one row per line.
+func Play(ctx context.Context, in *Input) (*Result, error) {
+	var sides [2]*side
+	for i, p := range in.Players {
+		if p == nil || p.Solver == nil {
+			return nil, fmt.Errorf("player %d has no solver", i+1)
+		}
+		if p.PiecesPerSecond <= 0 {
+			return nil, fmt.Errorf("player %d places %v pieces per second, want more than 0", i+1, p.PiecesPerSecond)
+		}
+		game, err := single.NewGame(&single.Input{
+			Level: 1,
+			//nolint:gosec // This random source is not for any security-related tasks.
+			Rand: rand.New(rand.NewPCG(in.Seed, in.Seed)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("creating game: %w", err)
+		}
+		interval := time.Duration(float64(time.Second) / p.PiecesPerSecond)
+		sides[i] = &side{player: p, game: game, interval: interval, next: interval}
+	}
+
+	result := &Result{Winner: Draw}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// The player who moves next is the one whose move is due first.
+		i := 0
+		if sides[1].next < sides[0].next {
+			i = 1
+		}
+		s := sides[i]
+		if in.MaxDuration > 0 && s.next > in.MaxDuration {
+			result.Duration = in.MaxDuration
+			break
+		}
+		result.Duration = s.next
+
+		lost, err := s.move(ctx, sides[1-i], &result.Sent[i])
+		if err != nil {
+			return nil, fmt.Errorf("player %d: %w", i+1, err)
+		}
+		if lost {
+			result.Winner = 1 - i
+			break
+		}
+		s.next += s.interval
+	}
+
+	for i, s := range sides {
+		result.Pieces[i] = s.game.GetPiecesPlaced()
+		result.Lines[i] = s.game.GetLinesCleared()
+	}
+	return result, nil
+}
+
+// move places the player's next Tetrimino and sends the garbage it attacks with to the opponent, adding it to sent.
+// If true is returned the player has lost.
+func (s *side) move(ctx context.Context, opponent *side, sent *int) (bool, error) {
+	if s.game.IsInEntryDelay() {
+		gameOver, err := s.game.TickLower()
+		if err != nil {
+			return false, fmt.Errorf("ending entry delay: %w", err)
+		}
+		if gameOver {
+			return true, nil
+		}
+	}
+
+	move, err := s.player.Solver.Solve(ctx, solver.NewPosition(s.game))
+	if errors.Is(err, solver.ErrNoMoves) {
+		return true, nil
+	} else if err != nil {
+		return false, fmt.Errorf("choosing move: %w", err)
+	}
+	gameOver, err := solver.Play(s.game, move)
+	if err != nil {
+		return false, fmt.Errorf("playing move: %w", err)
+	}
+	if gameOver {
+		return true, nil
+	}
+
+	attack, err := s.attacker.Placed(s.game)
+	if err != nil {
+		return false, fmt.Errorf("working out attack: %w", err)
+	}
+	opponent.game.QueueGarbage(attack)
+	*sent += attack
+	return false, nil
+}
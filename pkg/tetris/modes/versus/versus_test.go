@@ -0,0 +1,162 @@
+package versus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/solver"
+)
+
+func TestAttack(t *testing.T) {
+	tt := map[string]struct {
+		action       tetris.Action
+		combo        int
+		backToBack   bool
+		perfectClear bool
+		want         int
+	}{
+		"no lines": {
+			action: tetris.Actions.TSpin,
+			want:   0,
+		},
+		"single": {
+			action: tetris.Actions.Single,
+			want:   0,
+		},
+		"tetris": {
+			action: tetris.Actions.Tetris,
+			want:   4,
+		},
+		"back-to-back T-Spin double": {
+			action:     tetris.Actions.TSpinDouble,
+			backToBack: true,
+			want:       5,
+		},
+		"double in a combo": {
+			action: tetris.Actions.Double,
+			combo:  4,
+			want:   3,
+		},
+		"long combo": {
+			action: tetris.Actions.Single,
+			combo:  20,
+			want:   5,
+		},
+		"perfect clear": {
+			action:       tetris.Actions.Tetris,
+			perfectClear: true,
+			want:         14,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Attack(tc.action, tc.combo, tc.backToBack, tc.perfectClear))
+		})
+	}
+}
+
+func TestAttacker_Placed(t *testing.T) {
+	// The bottom 8 rows are full except for the rightmost column, so each I Tetrimino dropped there clears 4 lines.
+	matrix := tetris.DefaultMatrix()
+	for row := len(matrix) - 8; row < len(matrix); row++ {
+		for col := range len(matrix[row]) - 1 {
+			matrix[row][col] = tetris.GarbageCell
+		}
+	}
+	game, err := single.NewGame(&single.Input{Level: 1, Matrix: matrix, Sequence: []byte{'I', 'I', 'O'}})
+	require.NoError(t, err)
+
+	var attacker Attacker
+	dropRight := func(rotate bool) int {
+		if rotate {
+			require.NoError(t, game.Rotate(true))
+		}
+		for range len(matrix[0]) {
+			game.MoveRight()
+		}
+		_, err := game.HardDrop()
+		require.NoError(t, err)
+
+		attack, err := attacker.Placed(game)
+		require.NoError(t, err)
+		return attack
+	}
+
+	// A Tetris.
+	assert.Equal(t, 4, dropRight(true))
+	// A back-to-back Tetris, in a combo, which perfect clears the matrix.
+	assert.Equal(t, 16, dropRight(true))
+	// No lines are cleared.
+	assert.Equal(t, 0, dropRight(false))
+}
+
+func TestPlay(t *testing.T) {
+	greedy := solver.NewGreedySearch(eval.ElTetris())
+	tt := map[string]struct {
+		players     [2]*Player
+		maxDuration time.Duration
+		wantWinner  int
+	}{
+		"equal players are drawn": {
+			players: [2]*Player{
+				{Name: "a", Solver: greedy, PiecesPerSecond: 1},
+				{Name: "b", Solver: greedy, PiecesPerSecond: 1},
+			},
+			maxDuration: 50 * time.Second,
+			wantWinner:  Draw,
+		},
+		"a much faster player wins": {
+			players: [2]*Player{
+				{Name: "slow", Solver: greedy, PiecesPerSecond: 1},
+				{Name: "fast", Solver: greedy, PiecesPerSecond: 4},
+			},
+			wantWinner: 1,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			result, err := Play(context.Background(), &Input{Players: tc.players, Seed: 1, MaxDuration: tc.maxDuration})
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantWinner, result.Winner)
+			assert.Positive(t, result.Duration)
+
+			if tc.wantWinner == Draw {
+				assert.Equal(t, [2]int{50, 50}, result.Pieces)
+				assert.Equal(t, tc.maxDuration, result.Duration)
+				return
+			}
+			assert.Greater(t, result.Pieces[1], result.Pieces[0])
+			assert.Positive(t, result.Sent[1])
+		})
+	}
+}
+
+func TestPlay_InvalidPlayers(t *testing.T) {
+	greedy := solver.NewGreedySearch(eval.ElTetris())
+	tt := map[string]struct {
+		players [2]*Player
+	}{
+		"no solver": {
+			players: [2]*Player{{PiecesPerSecond: 1}, {Solver: greedy, PiecesPerSecond: 1}},
+		},
+		"no speed": {
+			players: [2]*Player{{Solver: greedy, PiecesPerSecond: 1}, {Solver: greedy}},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			_, err := Play(context.Background(), &Input{Players: tc.players})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -4,12 +4,16 @@ import (
 	"math/rand/v2"
 )
 
-// NextQueue is a collection of up to 14 Tetriminos that are drawn from randomly.
-// The queue is refilled when it has less than 7 Tetriminos.
+// NextQueue is a collection of upcoming Tetriminos, in the order chosen by its Randomizer.
+// The queue is refilled when it has 7 or less Tetriminos.
 type NextQueue struct {
-	elements []Tetrimino
-	skyline  int
-	rand     *rand.Rand
+	elements       []Tetrimino
+	skyline        int
+	width          int // The width of the Matrix the Tetriminos spawn in.
+	rand           *rand.Rand
+	rotationSystem RotationSystem
+	randomizer     Randomizer
+	isFixed        bool // Whether the queue only contains a fixed sequence of Tetriminos, and is never refilled.
 }
 
 // NewNextQueue creates a new NextQueue of Tetriminos.
@@ -17,8 +21,11 @@ func NewNextQueue(skyline int, opts ...func(*NextQueue)) *NextQueue {
 	nq := &NextQueue{
 		elements: make([]Tetrimino, 0, 14),
 		skyline:  skyline,
+		width:    standardWidth,
 		//nolint:gosec // This random source is not for any security-related tasks.
-		rand: rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+		rand:           rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+		rotationSystem: SRS,
+		randomizer:     NewBagRandomizer(1),
 	}
 
 	for _, opt := range opts {
@@ -35,14 +42,50 @@ func WithRandSource(rand *rand.Rand) func(*NextQueue) {
 	}
 }
 
+// WithRotationSystem sets the RotationSystem used for the Tetriminos in the queue.
+func WithRotationSystem(rs RotationSystem) func(*NextQueue) {
+	return func(nq *NextQueue) {
+		nq.rotationSystem = rs
+	}
+}
+
+// WithMatrixWidth sets the width of the Matrix the Tetriminos spawn in, so they spawn in its centre.
+// The default is the standard width of 10.
+func WithMatrixWidth(width int) func(*NextQueue) {
+	return func(nq *NextQueue) {
+		nq.width = width
+	}
+}
+
+// WithRandomizer sets the Randomizer used to choose the order of the Tetriminos in the queue.
+func WithRandomizer(r Randomizer) func(*NextQueue) {
+	return func(nq *NextQueue) {
+		nq.randomizer = r
+	}
+}
+
+// WithSequence sets the queue to only contain the given sequence of Tetriminos, in order.
+// The queue is never refilled, so Next returns nil once the sequence has been drawn.
+func WithSequence(tetriminos []Tetrimino) func(*NextQueue) {
+	return func(nq *NextQueue) {
+		nq.elements = append(make([]Tetrimino, 0, len(tetriminos)), tetriminos...)
+		nq.isFixed = true
+	}
+}
+
 // GetElements returns the Tetriminos in the queue.
 func (nq *NextQueue) GetElements() []Tetrimino {
 	return nq.elements
 }
 
 // Next returns the next Tetrimino, removing it from the queue and refilling if necessary.
-// This applies the skyline value (provided in NewNextQueue) to the Tetriminos Y axis.
+// This applies the skyline value (provided in NewNextQueue) to the Tetriminos Y axis, and centres it in the width of
+// the Matrix (see WithMatrixWidth).
+// If the queue has a fixed sequence which has been drawn, nil is returned.
 func (nq *NextQueue) Next() *Tetrimino {
+	if len(nq.elements) == 0 {
+		return nil
+	}
 	tet := nq.elements[0]
 	nq.elements = nq.elements[1:]
 
@@ -51,23 +94,20 @@ func (nq *NextQueue) Next() *Tetrimino {
 	}
 
 	tet.Position.Y += nq.skyline
+	tet.Position.X = SpawnColumn(tet.Position.X, nq.width)
 	return &tet
 }
 
-// fill adds Tetriminos to the queue until it has 7 or more.
-// This is done by getting all valid Tetriminos, shuffling them, and adding them to the queue.
+// fill adds at least 7 Tetriminos to the queue if it has 7 or less.
+// This is done by getting all valid Tetriminos and adding those generated by the Randomizer to the queue.
 func (nq *NextQueue) fill() {
-	if len(nq.elements) > 7 {
+	if nq.isFixed || len(nq.elements) > 7 {
 		return
 	}
 
-	tetriminos := GetValidTetriminos()
-	perm := nq.rand.Perm(len(tetriminos))
-	for _, i := range perm {
-		if len(nq.elements) == 14 {
-			// This should be impossible whilst there are only 7 Tetriminos and we check that there is space for 7 in the queue
-			return
-		}
-		nq.elements = append(nq.elements, tetriminos[i])
+	tetriminos := GetValidTetriminosFor(nq.rotationSystem)
+	target := len(nq.elements) + len(tetriminos)
+	for len(nq.elements) < target {
+		nq.elements = append(nq.elements, nq.randomizer.Generate(nq.rand, tetriminos)...)
 	}
 }
@@ -0,0 +1,113 @@
+package tetris
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+// Randomizer decides the order that Tetriminos are added to the Next Queue.
+type Randomizer interface {
+	Name() string
+	// Generate returns the next Tetriminos to add to the Next Queue, chosen from the given Tetriminos.
+	Generate(r *rand.Rand, tetriminos []Tetrimino) []Tetrimino
+}
+
+// GetRandomizer returns the Randomizer with the given name.
+// Valid names are "7-Bag", "14-Bag", "Random", and "TGM". An empty name returns the 7-Bag.
+func GetRandomizer(name string) (Randomizer, error) {
+	switch strings.ToLower(name) {
+	case "", "7-bag":
+		return NewBagRandomizer(1), nil
+	case "14-bag":
+		return NewBagRandomizer(2), nil
+	case "random":
+		return NewMemorylessRandomizer(), nil
+	case "tgm":
+		return NewHistoryRandomizer(4, 4), nil
+	default:
+		return nil, fmt.Errorf("unknown randomizer %q", name)
+	}
+}
+
+// BagRandomizer shuffles a bag containing a number of copies of every Tetrimino.
+// This is the randomizer of modern guideline games, which use a bag of 7 (ie. 1 copy).
+type BagRandomizer struct {
+	copies int
+}
+
+// NewBagRandomizer creates a BagRandomizer with the given number of copies of each Tetrimino in a bag.
+func NewBagRandomizer(copies int) *BagRandomizer {
+	return &BagRandomizer{copies: max(copies, 1)}
+}
+
+func (b *BagRandomizer) Name() string {
+	return fmt.Sprintf("%d-Bag", b.copies*len(validTetriminoValues))
+}
+
+func (b *BagRandomizer) Generate(r *rand.Rand, tetriminos []Tetrimino) []Tetrimino {
+	bag := make([]Tetrimino, 0, len(tetriminos)*b.copies)
+	for range b.copies {
+		bag = append(bag, tetriminos...)
+	}
+
+	result := make([]Tetrimino, 0, len(bag))
+	for _, i := range r.Perm(len(bag)) {
+		result = append(result, bag[i])
+	}
+	return result
+}
+
+// MemorylessRandomizer chooses every Tetrimino at random, without regard to the previous Tetriminos.
+type MemorylessRandomizer struct{}
+
+// NewMemorylessRandomizer creates a new MemorylessRandomizer.
+func NewMemorylessRandomizer() *MemorylessRandomizer {
+	return &MemorylessRandomizer{}
+}
+
+func (m *MemorylessRandomizer) Name() string {
+	return "Random"
+}
+
+func (m *MemorylessRandomizer) Generate(r *rand.Rand, tetriminos []Tetrimino) []Tetrimino {
+	return []Tetrimino{tetriminos[r.IntN(len(tetriminos))]}
+}
+
+// HistoryRandomizer chooses Tetriminos at random, rerolling those which are in the history of recent Tetriminos.
+// This is the randomizer of the TGM series. The history starts full of S and Z so neither is dealt first.
+type HistoryRandomizer struct {
+	history []byte
+	rolls   int
+}
+
+// NewHistoryRandomizer creates a HistoryRandomizer which remembers the given number of Tetriminos,
+// and rolls up to the given number of times to choose a Tetrimino which is not in the history.
+func NewHistoryRandomizer(size, rolls int) *HistoryRandomizer {
+	history := make([]byte, max(size, 1))
+	for i := range history {
+		history[i] = "ZS"[i%2]
+	}
+	return &HistoryRandomizer{
+		history: history,
+		rolls:   max(rolls, 1),
+	}
+}
+
+func (h *HistoryRandomizer) Name() string {
+	return "TGM"
+}
+
+func (h *HistoryRandomizer) Generate(r *rand.Rand, tetriminos []Tetrimino) []Tetrimino {
+	var tet Tetrimino
+	for range h.rolls {
+		tet = tetriminos[r.IntN(len(tetriminos))]
+		if !slices.Contains(h.history, tet.Value) {
+			break
+		}
+	}
+
+	h.history = append(h.history[1:], tet.Value)
+	return []Tetrimino{tet}
+}
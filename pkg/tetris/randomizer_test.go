@@ -0,0 +1,104 @@
+package tetris
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRandomizer(t *testing.T) {
+	tt := map[string]struct {
+		name     string
+		wantName string
+		wantErr  bool
+	}{
+		"default": {
+			name:     "",
+			wantName: "7-Bag",
+		},
+		"7-bag": {
+			name:     "7-bag",
+			wantName: "7-Bag",
+		},
+		"14-bag": {
+			name:     "14-Bag",
+			wantName: "14-Bag",
+		},
+		"random": {
+			name:     "Random",
+			wantName: "Random",
+		},
+		"tgm": {
+			name:     "TGM",
+			wantName: "TGM",
+		},
+		"unknown": {
+			name:    "nope",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r, err := GetRandomizer(tc.name)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantName, r.Name())
+		})
+	}
+}
+
+func TestBagRandomizer_Generate(t *testing.T) {
+	tt := map[string]struct {
+		copies int
+	}{
+		"7-bag": {
+			copies: 1,
+		},
+		"14-bag": {
+			copies: 2,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(0, 0))
+			tetriminos := GetValidTetriminosFor(SRS)
+
+			bag := NewBagRandomizer(tc.copies).Generate(r, tetriminos)
+			require.Len(t, bag, len(tetriminos)*tc.copies)
+
+			for _, tet := range tetriminos {
+				count := 0
+				for _, b := range bag {
+					if b.Value == tet.Value {
+						count++
+					}
+				}
+				assert.Equal(t, tc.copies, count, "Tetrimino %q", tet.Value)
+			}
+		})
+	}
+}
+
+func TestHistoryRandomizer_Generate(t *testing.T) {
+	r := rand.New(rand.NewPCG(0, 0))
+	tetriminos := GetValidTetriminosFor(SRS)
+	h := NewHistoryRandomizer(4, 4)
+
+	first := h.Generate(r, tetriminos)
+	require.Len(t, first, 1)
+
+	for range 100 {
+		history := slices.Clone(h.history)
+		next := h.Generate(r, tetriminos)
+		require.Len(t, next, 1)
+		assert.Equal(t, append(history[1:], next[0].Value), h.history)
+	}
+}
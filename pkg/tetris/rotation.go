@@ -0,0 +1,355 @@
+package tetris
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A RotationSystem defines how Tetriminos enter the Matrix and how they rotate once in play.
+// It supplies the spawn position and orientation of each Tetrimino along with the kick tables
+// that are tried, in order, when rotating.
+type RotationSystem interface {
+	// Name returns the name used to select this rotation system (eg. in config).
+	Name() string
+
+	// SpawnPosition returns the initial position of the Tetrimino with the given value.
+	// This is relative to the skyline, so negative Y values are within the buffer zone.
+	SpawnPosition(value byte) Coordinate
+
+	// SpawnCells returns the cells of the Tetrimino with the given value in its spawn orientation.
+	SpawnCells(value byte) [][]bool
+
+	// Compasses returns the offsets used when rotating the Tetrimino with the given value.
+	// The clockwise compass is indexed by the compass direction being rotated into, and the
+	// counter-clockwise compass by the compass direction being rotated out of.
+	Compasses(value byte) (clockwise, counterClockwise RotationCompass)
+}
+
+// standardWidth is the width of the Matrix which the spawn positions of rotation systems are given for.
+const standardWidth = 10
+
+// SpawnColumn returns the column that a Tetrimino spawning at the given column of a standard 10 wide Matrix spawns at
+// in a Matrix of the given width, so it stays the same distance from the centre.
+func SpawnColumn(col, width int) int {
+	return col + (width-standardWidth)/2
+}
+
+var (
+	// SRS is the Super Rotation System used by modern guideline games. It is the default.
+	SRS RotationSystem = newSRS()
+
+	// SRSPlus is SRS with the I Tetrimino kicks made symmetrical, as popularised by TETR.IO.
+	SRSPlus RotationSystem = newSRSPlus()
+
+	// ARS is the Arika Rotation System used by the Tetris The Grand Master series.
+	// Pieces spawn flat side up, rotate within a bottom-aligned 3x3 box, and kick one column right then
+	// one column left. The I Tetrimino does not kick.
+	// Note that the centre column rule for J, L and T is not modelled.
+	ARS RotationSystem = newARS()
+
+	// NES is the rotation system used by the original NES Tetris. Pieces rotate about a fixed centre and never kick.
+	NES RotationSystem = newNES()
+)
+
+// RotationSystems contains all of the available rotation systems.
+var RotationSystems = []RotationSystem{SRS, SRSPlus, ARS, NES}
+
+// GetRotationSystem returns the rotation system with the given name.
+// Names are case-insensitive. An empty name returns SRS.
+func GetRotationSystem(name string) (RotationSystem, error) {
+	if name == "" {
+		return SRS, nil
+	}
+	for _, rs := range RotationSystems {
+		if strings.EqualFold(rs.Name(), name) {
+			return rs, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown rotation system %q", name)
+}
+
+// tableRotationSystem is a RotationSystem which is fully described by lookup tables.
+type tableRotationSystem struct {
+	name             string
+	spawnPositions   map[byte]Coordinate
+	spawnCells       map[byte][][]bool
+	clockwise        map[byte]RotationCompass
+	counterClockwise map[byte]RotationCompass
+}
+
+func (rs *tableRotationSystem) Name() string {
+	return rs.name
+}
+
+func (rs *tableRotationSystem) SpawnPosition(value byte) Coordinate {
+	return rs.spawnPositions[value]
+}
+
+func (rs *tableRotationSystem) SpawnCells(value byte) [][]bool {
+	return deepCopyCells(rs.spawnCells[value])
+}
+
+func (rs *tableRotationSystem) Compasses(value byte) (RotationCompass, RotationCompass) {
+	return rs.clockwise[value], rs.counterClockwise[value]
+}
+
+// rotationStates describes the rotation of a single Tetrimino in the notation commonly used by rotation system
+// references: each orientation sits within a fixed rotation box and kicks are listed with positive Y going up.
+type rotationStates struct {
+	// corners is the top-left corner of the Tetrimino's cells within the rotation box for each compass direction.
+	corners [4]Coordinate
+
+	// clockwiseKicks holds the kicks to try when rotating clockwise out of each compass direction.
+	clockwiseKicks [4][]Coordinate
+
+	// counterClockwiseKicks holds the kicks to try when rotating counter-clockwise out of each compass direction.
+	counterClockwiseKicks [4][]Coordinate
+}
+
+// compasses converts the rotation states into the offsets used by Tetrimino.Rotate.
+// Since Tetrimino cells are trimmed to their bounding box, each offset is the movement of the top-left corner
+// within the rotation box plus the kick (with its Y axis flipped to match the Matrix).
+func (s *rotationStates) compasses() (RotationCompass, RotationCompass) {
+	var cw, ccw RotationCompass
+	for from := range 4 {
+		to := (from + 1) % 4
+		cw[to] = make(RotationOffsets, len(s.clockwiseKicks[from]))
+		for i, kick := range s.clockwiseKicks[from] {
+			cw[to][i] = &Coordinate{
+				X: s.corners[to].X - s.corners[from].X + kick.X,
+				Y: s.corners[to].Y - s.corners[from].Y - kick.Y,
+			}
+		}
+
+		to = (from + 3) % 4
+		ccw[from] = make(RotationOffsets, len(s.counterClockwiseKicks[from]))
+		for i, kick := range s.counterClockwiseKicks[from] {
+			ccw[from][i] = &Coordinate{
+				X: s.corners[to].X - s.corners[from].X + kick.X,
+				Y: s.corners[to].Y - s.corners[from].Y - kick.Y,
+			}
+		}
+	}
+	return cw, ccw
+}
+
+// mirroredKicks returns counter-clockwise kicks which undo the given clockwise kicks.
+// Rotation systems such as SRS are defined so that rotating counter-clockwise out of a direction
+// tries the negated kicks of rotating clockwise into it.
+func mirroredKicks(clockwise [4][]Coordinate) [4][]Coordinate {
+	var result [4][]Coordinate
+	for from := range 4 {
+		into := clockwise[(from+3)%4]
+		result[from] = make([]Coordinate, len(into))
+		for i, kick := range into {
+			result[from][i] = Coordinate{X: -kick.X, Y: -kick.Y}
+		}
+	}
+	return result
+}
+
+// repeatedKicks returns the same kicks for every compass direction.
+func repeatedKicks(kicks ...Coordinate) [4][]Coordinate {
+	return [4][]Coordinate{kicks, kicks, kicks, kicks}
+}
+
+// defaultSpawnCells is the spawn orientation of each Tetrimino in guideline games.
+var defaultSpawnCells = map[byte][][]bool{
+	'I': {
+		{true, true, true, true},
+	},
+	'O': {
+		{true, true},
+		{true, true},
+	},
+	'T': {
+		{false, true, false},
+		{true, true, true},
+	},
+	'S': {
+		{false, true, true},
+		{true, true, false},
+	},
+	'Z': {
+		{true, true, false},
+		{false, true, true},
+	},
+	'J': {
+		{true, false, false},
+		{true, true, true},
+	},
+	'L': {
+		{false, false, true},
+		{true, true, true},
+	},
+}
+
+// flatSideUpSpawnCells is the spawn orientation used by ARS and NES, where J, L and T are upside down
+// compared to the guideline.
+var flatSideUpSpawnCells = map[byte][][]bool{
+	'I': defaultSpawnCells['I'],
+	'O': defaultSpawnCells['O'],
+	'T': {
+		{true, true, true},
+		{false, true, false},
+	},
+	'S': defaultSpawnCells['S'],
+	'Z': defaultSpawnCells['Z'],
+	'J': {
+		{true, true, true},
+		{false, false, true},
+	},
+	'L': {
+		{true, true, true},
+		{true, false, false},
+	},
+}
+
+// spawnPositionsByValue expands startingPositions so it can be looked up by Tetrimino value.
+func spawnPositionsByValue() map[byte]Coordinate {
+	return map[byte]Coordinate{
+		'I': startingPositions['I'],
+		'O': startingPositions['O'],
+		'T': startingPositions['6'],
+		'S': startingPositions['6'],
+		'Z': startingPositions['6'],
+		'J': startingPositions['6'],
+		'L': startingPositions['6'],
+	}
+}
+
+// srsKicksI and srsKicks6 are the standard SRS clockwise kick tables for the I Tetrimino and
+// the Tetriminos within a 3x3 rotation box (T, S, Z, J, L) respectively.
+var (
+	srsKicksI = [4][]Coordinate{
+		{{X: 0, Y: 0}, {X: -2, Y: 0}, {X: 1, Y: 0}, {X: -2, Y: -1}, {X: 1, Y: 2}},
+		{{X: 0, Y: 0}, {X: -1, Y: 0}, {X: 2, Y: 0}, {X: -1, Y: 2}, {X: 2, Y: -1}},
+		{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: -1, Y: 0}, {X: 2, Y: 1}, {X: -1, Y: -2}},
+		{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: -2, Y: 0}, {X: 1, Y: -2}, {X: -2, Y: 1}},
+	}
+	srsKicks6 = [4][]Coordinate{
+		{{X: 0, Y: 0}, {X: -1, Y: 0}, {X: -1, Y: 1}, {X: 0, Y: -2}, {X: -1, Y: -2}},
+		{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: -1}, {X: 0, Y: 2}, {X: 1, Y: 2}},
+		{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: -2}, {X: 1, Y: -2}},
+		{{X: 0, Y: 0}, {X: -1, Y: 0}, {X: -1, Y: -1}, {X: 0, Y: 2}, {X: -1, Y: 2}},
+	}
+)
+
+// srsStates describes SRS for each key of RotationCompasses.
+var srsStates = map[byte]rotationStates{
+	'I': {
+		corners:               [4]Coordinate{{X: 0, Y: 1}, {X: 2, Y: 0}, {X: 0, Y: 2}, {X: 1, Y: 0}},
+		clockwiseKicks:        srsKicksI,
+		counterClockwiseKicks: mirroredKicks(srsKicksI),
+	},
+	'O': {
+		corners:               [4]Coordinate{},
+		clockwiseKicks:        repeatedKicks(Coordinate{X: 0, Y: 0}),
+		counterClockwiseKicks: repeatedKicks(Coordinate{X: 0, Y: 0}),
+	},
+	'6': {
+		corners:               [4]Coordinate{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 0}},
+		clockwiseKicks:        srsKicks6,
+		counterClockwiseKicks: mirroredKicks(srsKicks6),
+	},
+}
+
+func newSRS() *tableRotationSystem {
+	rs := &tableRotationSystem{
+		name:             "SRS",
+		spawnPositions:   spawnPositionsByValue(),
+		spawnCells:       defaultSpawnCells,
+		clockwise:        make(map[byte]RotationCompass),
+		counterClockwise: make(map[byte]RotationCompass),
+	}
+	for value := range defaultSpawnCells {
+		key := value
+		if value != 'I' && value != 'O' {
+			key = '6'
+		}
+		s := srsStates[key]
+		rs.clockwise[value], rs.counterClockwise[value] = s.compasses()
+	}
+	return rs
+}
+
+func newSRSPlus() *tableRotationSystem {
+	rs := newSRS()
+	rs.name = "SRS+"
+
+	states := rotationStates{
+		corners:        srsStates['I'].corners,
+		clockwiseKicks: srsKicksI,
+		counterClockwiseKicks: [4][]Coordinate{
+			{{X: 0, Y: 0}, {X: -1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: -1}, {X: -1, Y: 2}},
+			{{X: 0, Y: 0}, {X: -1, Y: 0}, {X: 2, Y: 0}, {X: -1, Y: -2}, {X: 2, Y: 1}},
+			{{X: 0, Y: 0}, {X: -2, Y: 0}, {X: 1, Y: 0}, {X: -2, Y: 1}, {X: 1, Y: -2}},
+			{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: -2, Y: 0}, {X: 1, Y: -2}, {X: -2, Y: 1}},
+		},
+	}
+	states.clockwiseKicks[0] = []Coordinate{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: -2, Y: 0}, {X: -2, Y: -1}, {X: 1, Y: 2}}
+	states.clockwiseKicks[3] = []Coordinate{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: -2, Y: 0}, {X: 1, Y: 2}, {X: -2, Y: -1}}
+
+	rs.clockwise['I'], rs.counterClockwise['I'] = states.compasses()
+	return rs
+}
+
+func newARS() *tableRotationSystem {
+	kicks := repeatedKicks(Coordinate{X: 0, Y: 0}, Coordinate{X: 1, Y: 0}, Coordinate{X: -1, Y: 0})
+	noKicks := repeatedKicks(Coordinate{X: 0, Y: 0})
+	noRotation := [4]Coordinate{}
+
+	states := map[byte]rotationStates{
+		'I': {corners: [4]Coordinate{{X: 0, Y: 1}, {X: 2, Y: 0}, {X: 0, Y: 1}, {X: 2, Y: 0}}},
+		'O': {corners: noRotation},
+		'T': {corners: [4]Coordinate{{X: 0, Y: 1}, {X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}}},
+		'S': {corners: [4]Coordinate{{X: 0, Y: 1}, {X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 0}}},
+		'Z': {corners: [4]Coordinate{{X: 0, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}}},
+		'J': {corners: [4]Coordinate{{X: 0, Y: 1}, {X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}}},
+		'L': {corners: [4]Coordinate{{X: 0, Y: 1}, {X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}}},
+	}
+
+	rs := &tableRotationSystem{
+		name:             "ARS",
+		spawnPositions:   spawnPositionsByValue(),
+		spawnCells:       flatSideUpSpawnCells,
+		clockwise:        make(map[byte]RotationCompass),
+		counterClockwise: make(map[byte]RotationCompass),
+	}
+	for value, s := range states {
+		if value == 'I' || value == 'O' {
+			s.clockwiseKicks, s.counterClockwiseKicks = noKicks, noKicks
+		} else {
+			s.clockwiseKicks, s.counterClockwiseKicks = kicks, kicks
+		}
+		rs.clockwise[value], rs.counterClockwise[value] = s.compasses()
+	}
+	return rs
+}
+
+func newNES() *tableRotationSystem {
+	noKicks := repeatedKicks(Coordinate{X: 0, Y: 0})
+
+	states := map[byte]rotationStates{
+		'I': {corners: [4]Coordinate{{X: 0, Y: 2}, {X: 2, Y: 0}, {X: 0, Y: 2}, {X: 2, Y: 0}}},
+		'O': {corners: [4]Coordinate{}},
+		'T': {corners: [4]Coordinate{{X: 0, Y: 1}, {X: 0, Y: 0}, {X: 0, Y: 0}, {X: 1, Y: 0}}},
+		'S': {corners: [4]Coordinate{{X: 0, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}}},
+		'Z': {corners: [4]Coordinate{{X: 0, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}}},
+		'J': {corners: [4]Coordinate{{X: 0, Y: 1}, {X: 0, Y: 0}, {X: 0, Y: 0}, {X: 1, Y: 0}}},
+		'L': {corners: [4]Coordinate{{X: 0, Y: 1}, {X: 0, Y: 0}, {X: 0, Y: 0}, {X: 1, Y: 0}}},
+	}
+
+	rs := &tableRotationSystem{
+		name:             "NES",
+		spawnPositions:   spawnPositionsByValue(),
+		spawnCells:       flatSideUpSpawnCells,
+		clockwise:        make(map[byte]RotationCompass),
+		counterClockwise: make(map[byte]RotationCompass),
+	}
+	for value, s := range states {
+		s.clockwiseKicks, s.counterClockwiseKicks = noKicks, noKicks
+		rs.clockwise[value], rs.counterClockwise[value] = s.compasses()
+	}
+	return rs
+}
@@ -0,0 +1,243 @@
+package tetris
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRotationSystem(t *testing.T) {
+	tt := map[string]struct {
+		name    string
+		want    RotationSystem
+		wantErr error
+	}{
+		"empty": {
+			name: "",
+			want: SRS,
+		},
+		"SRS": {
+			name: "SRS",
+			want: SRS,
+		},
+		"srs+": {
+			name: "srs+",
+			want: SRSPlus,
+		},
+		"ARS": {
+			name: "ARS",
+			want: ARS,
+		},
+		"NES": {
+			name: "NES",
+			want: NES,
+		},
+		"unknown": {
+			name:    "DTET",
+			wantErr: errors.New("unknown rotation system \"DTET\""),
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			result, err := GetRotationSystem(tc.name)
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, result)
+		})
+	}
+}
+
+func TestRotationStates_compasses(t *testing.T) {
+	for key, states := range srsStates {
+		t.Run(string(key), func(t *testing.T) {
+			cw, ccw := states.compasses()
+
+			assert.EqualValues(t, RotationCompasses[key], cw)
+			for dir := range ccw {
+				require.Len(t, ccw[dir], len(cw[dir]))
+				for i := range ccw[dir] {
+					assert.Equal(t, -cw[dir][i].X, ccw[dir][i].X)
+					assert.Equal(t, -cw[dir][i].Y, ccw[dir][i].Y)
+				}
+			}
+		})
+	}
+}
+
+// Checks that rotating a full turn in an empty matrix, in either direction, returns the Tetrimino
+// to its original cells and position.
+func TestRotationSystems_FullTurn(t *testing.T) {
+	for _, rs := range RotationSystems {
+		for _, tet := range GetValidTetriminosFor(rs) {
+			for _, clockwise := range []bool{true, false} {
+				name := rs.Name() + "/" + string(tet.Value)
+				if !clockwise {
+					name += "/counter-clockwise"
+				}
+				t.Run(name, func(t *testing.T) {
+					matrix := DefaultMatrix()
+					original := tet.DeepCopy()
+					original.Position.Y += DefaultDimensions.Buffer
+					rotated := original.DeepCopy()
+
+					for range 4 {
+						require.NoError(t, rotated.Rotate(matrix, clockwise))
+					}
+
+					assert.Equal(t, original.Cells, rotated.Cells)
+					assert.Equal(t, original.Position, rotated.Position)
+					assert.Equal(t, 0, rotated.CompassDirection)
+				})
+			}
+		}
+	}
+}
+
+func TestRotationSystems_SpawnCells(t *testing.T) {
+	tt := map[string]struct {
+		rs   RotationSystem
+		want [][]bool
+	}{
+		"SRS": {
+			rs: SRS,
+			want: [][]bool{
+				{false, true, false},
+				{true, true, true},
+			},
+		},
+		"ARS": {
+			rs: ARS,
+			want: [][]bool{
+				{true, true, true},
+				{false, true, false},
+			},
+		},
+		"NES": {
+			rs: NES,
+			want: [][]bool{
+				{true, true, true},
+				{false, true, false},
+			},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			tet, err := GetTetriminoFor('T', tc.rs)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, tet.Cells)
+		})
+	}
+}
+
+func TestRotationSystems_Kicks(t *testing.T) {
+	blockedMatrix := func() Matrix {
+		m := DefaultMatrix()
+		m[22][8] = 'X'
+		return m
+	}
+
+	tt := map[string]struct {
+		rs               RotationSystem
+		value            byte
+		cells            [][]bool
+		compassDirection int
+		clockwise        bool
+		matrix           Matrix
+		position         Coordinate
+		wantPos          Coordinate
+		wantDir          int
+	}{
+		"ARS; T kicks right off the left wall": {
+			rs:    ARS,
+			value: 'T',
+			cells: [][]bool{
+				{true, false},
+				{true, true},
+				{true, false},
+			},
+			compassDirection: 3,
+			clockwise:        true,
+			matrix: Matrix{
+				{0, 0, 0},
+				{0, 0, 0},
+				{0, 0, 0},
+			},
+			position: Coordinate{X: 0, Y: 0},
+			wantPos:  Coordinate{X: 0, Y: 1},
+			wantDir:  0,
+		},
+		"NES; no kick": {
+			rs:        NES,
+			value:     'I',
+			clockwise: true,
+			matrix: Matrix{
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+			},
+			position: Coordinate{X: 0, Y: 3},
+			wantPos:  Coordinate{X: 0, Y: 3},
+			wantDir:  0,
+		},
+		"SRS; I counter-clockwise from spawn": {
+			rs:        SRS,
+			value:     'I',
+			clockwise: false,
+			matrix:    DefaultMatrix(),
+			position:  Coordinate{X: 3, Y: 21},
+			wantPos:   Coordinate{X: 4, Y: 20},
+			wantDir:   3,
+		},
+		"SRS+; I counter-clockwise from spawn": {
+			rs:        SRSPlus,
+			value:     'I',
+			clockwise: false,
+			matrix:    DefaultMatrix(),
+			position:  Coordinate{X: 3, Y: 21},
+			wantPos:   Coordinate{X: 4, Y: 20},
+			wantDir:   3,
+		},
+		"SRS; I clockwise kicks left first": {
+			rs:        SRS,
+			value:     'I',
+			clockwise: true,
+			matrix:    blockedMatrix(),
+			position:  Coordinate{X: 6, Y: 21},
+			wantPos:   Coordinate{X: 6, Y: 20},
+			wantDir:   1,
+		},
+		"SRS+; I clockwise kicks right first": {
+			rs:        SRSPlus,
+			value:     'I',
+			clockwise: true,
+			matrix:    blockedMatrix(),
+			position:  Coordinate{X: 6, Y: 21},
+			wantPos:   Coordinate{X: 9, Y: 20},
+			wantDir:   1,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			tet, err := GetTetriminoFor(tc.value, tc.rs)
+			require.NoError(t, err)
+			tet.Position = tc.position
+			if tc.cells != nil {
+				tet.Cells = tc.cells
+				tet.CompassDirection = tc.compassDirection
+			}
+
+			require.NoError(t, tet.Rotate(tc.matrix, tc.clockwise))
+			assert.Equal(t, tc.wantPos, tet.Position)
+			assert.Equal(t, tc.wantDir, tet.CompassDirection)
+		})
+	}
+}
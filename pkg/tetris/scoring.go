@@ -2,12 +2,16 @@ package tetris
 
 import (
 	"fmt"
+	"time"
 )
 
 // Scoring is a scoring system for Tetris.
-// It keeps track of the current level, total score, and lines cleared.
+// It keeps track of the current level, total score, lines cleared, combos, and perfect clears.
 // It also has options to increase the level, end the game on max level, and end the game on max lines.
+// The points awarded and the level goal are determined by its ScoringRules (GuidelineRules by default).
 type Scoring struct {
+	rules ScoringRules
+
 	level         int
 	maxLevel      int
 	increaseLevel bool
@@ -19,15 +23,38 @@ type Scoring struct {
 
 	total      int
 	backToBack bool
+
+	consecutiveClears int // The number of consecutive actions which cleared lines.
+	maxCombo          int
+	perfectClears     int
+	lastAction        ActionContext // The last action processed.
+}
+
+// perfectClearPoints maps the number of lines cleared to the points awarded by GuidelineRules for a Perfect Clear.
+var perfectClearPoints = map[int]int{
+	1: 800,
+	2: 1200,
+	3: 1800,
+	4: 2000,
 }
 
+// backToBackTetrisPerfectClearPoints is awarded by GuidelineRules instead of perfectClearPoints for a
+// Back-to-Back Tetris Perfect Clear.
+const backToBackTetrisPerfectClearPoints = 3200
+
+// comboPoints is multiplied by the combo count and level to calculate the GuidelineRules combo bonus.
+const comboPoints = 50
+
 // NewScoring creates a new scoring system.
 func NewScoring(
 	level, maxLevel int,
 	increaseLevel, endOnMaxLevel bool,
 	maxLines int,
-	endOnMaxLines bool) (*Scoring, error) {
+	endOnMaxLines bool,
+	opts ...func(*Scoring)) (*Scoring, error) {
 	s := &Scoring{
+		rules: NewGuidelineRules(),
+
 		level:         level,
 		maxLevel:      maxLevel,
 		increaseLevel: increaseLevel,
@@ -36,11 +63,40 @@ func NewScoring(
 		maxLines:      maxLines,
 		endOnMaxLines: endOnMaxLines,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
 	return s, s.validate()
 }
 
+// WithScoringRules sets the ScoringRules used to award points and advance levels.
+func WithScoringRules(rules ScoringRules) func(*Scoring) {
+	return func(s *Scoring) {
+		s.rules = rules
+	}
+}
+
+// Rules returns the ScoringRules in use.
+func (s *Scoring) Rules() ScoringRules {
+	if s.rules == nil {
+		s.rules = NewGuidelineRules()
+	}
+	return s.rules
+}
+
+// Grade returns the grade awarded by the ScoringRules, or an empty string if they do not award grades.
+func (s *Scoring) Grade() string {
+	if g, ok := s.Rules().(Grader); ok {
+		return g.Grade()
+	}
+	return ""
+}
+
 func (s *Scoring) validate() error {
-	if s.level <= 0 {
+	// Rules which count levels directly (eg. MasterRules) start at level 0.
+	if _, ok := s.Rules().(LevelCounter); s.level < 0 || (s.level == 0 && !ok) {
 		return fmt.Errorf("invalid level '%d'", s.level)
 	}
 	if s.maxLevel < 0 {
@@ -70,42 +126,96 @@ func (s *Scoring) Lines() int {
 	return s.lines
 }
 
+// Combo returns the current combo count.
+// This is the number of consecutive line clears after the first, so it is 0 when no combo is active.
+func (s *Scoring) Combo() int {
+	return max(s.consecutiveClears-1, 0)
+}
+
+// MaxCombo returns the highest combo count reached.
+func (s *Scoring) MaxCombo() int {
+	return s.maxCombo
+}
+
+// PerfectClears returns the number of Perfect Clears achieved.
+func (s *Scoring) PerfectClears() int {
+	return s.perfectClears
+}
+
 // AddSoftDrop adds points for a soft drop.
 func (s *Scoring) AddSoftDrop(lines int) {
-	s.total += lines
+	s.addPoints(s.Rules().SoftDropPoints(lines))
 }
 
 // AddHardDrop adds points for a hard drop.
 func (s *Scoring) AddHardDrop(lines int) {
-	s.total += lines * 2
+	s.addPoints(s.Rules().HardDropPoints(lines))
+}
+
+// addPoints adds the points to the total, and passes them to the ScoringRules if they implement PointsTracker.
+func (s *Scoring) addPoints(points int) {
+	s.total += points
+	if pt, ok := s.Rules().(PointsTracker); ok && points > 0 {
+		pt.AddPoints(points)
+	}
+}
+
+// AddTime passes the time played to the ScoringRules, if they implement TimeTracker.
+func (s *Scoring) AddTime(elapsed time.Duration) {
+	if tt, ok := s.Rules().(TimeTracker); ok {
+		tt.AddTime(elapsed)
+	}
+}
+
+// ProcessSpawn advances the level when a Tetrimino spawns, if the ScoringRules implement LevelCounter.
+// The returned boolean indicates if the game should end.
+func (s *Scoring) ProcessSpawn() bool {
+	lc, ok := s.Rules().(LevelCounter)
+	if !ok || !s.increaseLevel {
+		return false
+	}
+	return s.setLevel(lc.SpawnLevel(s.level))
 }
 
 // ProcessAction processes an action and updates the score, lines cleared, level, etc.
 // The returned boolean indicates if the game should end.
 func (s *Scoring) ProcessAction(a Action) (bool, error) {
-	if a == Actions.None {
-		return false, nil
+	if a.LinesCleared() == 0 {
+		s.consecutiveClears = 0
 	}
 
-	points := float64(a.GetPoints())
+	ctx := ActionContext{
+		Action: a,
+		Level:  s.level,
+	}
+	if a != Actions.None {
+		var err error
+		var result bool
+		if result, err = a.EndsBackToBack(); result {
+			s.backToBack = false
+		} else if result, err = a.StartsBackToBack(); result {
+			ctx.BackToBack = s.backToBack
+			s.backToBack = true
+		}
+		if err != nil {
+			return false, err
+		}
 
-	var err error
-	var result bool
-	backToBack := 0.0
-	if result, err = a.EndsBackToBack(); result {
-		s.backToBack = false
-	} else if result, err = a.StartsBackToBack(); result {
-		if s.backToBack {
-			backToBack = points * 0.5
+		if a.LinesCleared() > 0 {
+			s.consecutiveClears++
+			ctx.Combo = s.Combo()
+			s.maxCombo = max(s.maxCombo, ctx.Combo)
 		}
-		s.backToBack = true
-	}
-	if err != nil {
-		return false, err
 	}
+	s.lastAction = ctx
 
-	s.total += int(points+backToBack) * s.level
-	s.lines += int((points + backToBack) / 100)
+	score := s.Rules().ScoreAction(ctx)
+	s.addPoints(score.Points)
+	s.lines += score.LineCredit
+
+	if a == Actions.None {
+		return false, nil
+	}
 
 	// if max lines enabled, and max lines reached
 	if s.maxLines > 0 && s.lines >= s.maxLines {
@@ -116,8 +226,15 @@ func (s *Scoring) ProcessAction(a Action) (bool, error) {
 		}
 	}
 
+	if lc, ok := s.Rules().(LevelCounter); ok {
+		if !s.increaseLevel {
+			return false, nil
+		}
+		return s.setLevel(lc.ClearLevel(s.level, a.LinesCleared())), nil
+	}
+
 	// while increase level enabled, and the next level was reached
-	for s.increaseLevel && s.lines >= s.level*5 {
+	for s.increaseLevel && s.lines >= s.Rules().LevelGoal(s.level) {
 		s.level++
 
 		// if no max level, or max level not reached
@@ -135,3 +252,29 @@ func (s *Scoring) ProcessAction(a Action) (bool, error) {
 
 	return false, nil
 }
+
+// setLevel sets the level, limited to the max level. The returned boolean indicates if the game should end.
+func (s *Scoring) setLevel(level int) bool {
+	s.level = level
+	if s.maxLevel <= 0 || s.level < s.maxLevel {
+		return false
+	}
+
+	s.level = s.maxLevel
+	return s.endOnMaxLevel
+}
+
+// AddPerfectClear adds points for a Perfect Clear (ie. the Matrix is empty after the given action cleared lines).
+// This should be called after ProcessAction has processed the same action.
+func (s *Scoring) AddPerfectClear(a Action) error {
+	if a.LinesCleared() == 0 {
+		return fmt.Errorf("action %q cannot be a perfect clear", a.String())
+	}
+	if s.lastAction.Action != a {
+		return fmt.Errorf("action %q was not the last action processed", a.String())
+	}
+
+	s.addPoints(s.Rules().PerfectClearPoints(s.lastAction))
+	s.perfectClears++
+	return nil
+}
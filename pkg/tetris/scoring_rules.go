@@ -0,0 +1,498 @@
+package tetris
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ScoringRules determines how Scoring awards points, counts lines towards the level goal, and advances levels.
+// Implementations may be stateful, so a new instance should be used for each game.
+type ScoringRules interface {
+	// Name returns the name used to select these rules (eg. in config).
+	Name() string
+
+	// ScoreAction returns the points and line credit awarded for the given action.
+	// It is called exactly once for every action processed by Scoring, including Actions.None.
+	ScoreAction(ctx ActionContext) ActionScore
+
+	// PerfectClearPoints returns the bonus points awarded when the given action causes a Perfect Clear.
+	// It is called after ScoreAction has scored the same action.
+	PerfectClearPoints(ctx ActionContext) int
+
+	// LevelGoal returns the total line credit required to advance beyond the given level.
+	LevelGoal(level int) int
+
+	// SoftDropPoints returns the points awarded for soft dropping the given number of rows.
+	SoftDropPoints(rows int) int
+
+	// HardDropPoints returns the points awarded for hard dropping the given number of rows.
+	HardDropPoints(rows int) int
+}
+
+// Grader is implemented by ScoringRules which award a grade for performance.
+type Grader interface {
+	// Grade returns the grade currently awarded (eg. "9", "S1").
+	Grade() string
+}
+
+// LevelCounter is implemented by ScoringRules which advance the level directly, as in the TGM series,
+// rather than by comparing line credit with LevelGoal.
+type LevelCounter interface {
+	// SpawnLevel returns the level after a Tetrimino spawns at the given level.
+	SpawnLevel(level int) int
+
+	// ClearLevel returns the level after the given number of lines are cleared at the given level.
+	ClearLevel(level, lines int) int
+}
+
+// PointsTracker is implemented by ScoringRules which depend on the points awarded, so the methods which return points
+// do not need to change any state.
+type PointsTracker interface {
+	// AddPoints is called with the points awarded each time the score increases.
+	AddPoints(points int)
+}
+
+// TimeTracker is implemented by ScoringRules which depend on the time played.
+type TimeTracker interface {
+	// AddTime is called with the time played since it was last called.
+	AddTime(elapsed time.Duration)
+}
+
+// ActionContext describes an Action being scored.
+type ActionContext struct {
+	Action Action
+
+	// Level is the level at the time of the action.
+	Level int
+
+	// BackToBack is true if the action continues a Back-to-Back sequence.
+	BackToBack bool
+
+	// Combo is the combo count including this action. It is 0 for the first line clear of a combo.
+	Combo int
+}
+
+// ActionScore is the result of scoring an ActionContext.
+type ActionScore struct {
+	// Points is the number of points awarded.
+	Points int
+
+	// LineCredit is the number of lines counted towards the level goal.
+	LineCredit int
+}
+
+// GetScoringRules returns a new instance of the scoring rules with the given name.
+// Names are case-insensitive. An empty name returns GuidelineRules.
+func GetScoringRules(name string) (ScoringRules, error) {
+	switch strings.ToLower(name) {
+	case "", "guideline":
+		return NewGuidelineRules(), nil
+	case "nes":
+		return NewNESRules(), nil
+	case "tgm":
+		return NewTGMRules(), nil
+	case "master":
+		return NewMasterRules(), nil
+	default:
+		return nil, fmt.Errorf("unknown scoring rules %q", name)
+	}
+}
+
+// GuidelineRules are the scoring rules of modern guideline games.
+// Points are multiplied by the level, Back-to-Back clears are worth 1.5x, combos are worth 50 x combo,
+// and the level goal is variable (ie. the line credit of an action is its points divided by 100).
+type GuidelineRules struct{}
+
+// NewGuidelineRules creates a new set of GuidelineRules.
+func NewGuidelineRules() *GuidelineRules {
+	return &GuidelineRules{}
+}
+
+func (r *GuidelineRules) Name() string {
+	return "Guideline"
+}
+
+func (r *GuidelineRules) ScoreAction(ctx ActionContext) ActionScore {
+	if ctx.Action == Actions.None {
+		return ActionScore{}
+	}
+
+	points := ctx.Action.GetPoints()
+	if ctx.BackToBack {
+		points += points / 2
+	}
+
+	return ActionScore{
+		Points:     (points + ctx.Combo*comboPoints) * ctx.Level,
+		LineCredit: points / 100,
+	}
+}
+
+func (r *GuidelineRules) PerfectClearPoints(ctx ActionContext) int {
+	if ctx.Action == Actions.Tetris && ctx.BackToBack {
+		return backToBackTetrisPerfectClearPoints * ctx.Level
+	}
+	return perfectClearPoints[ctx.Action.LinesCleared()] * ctx.Level
+}
+
+func (r *GuidelineRules) LevelGoal(level int) int {
+	return level * 5
+}
+
+func (r *GuidelineRules) SoftDropPoints(rows int) int {
+	return rows
+}
+
+func (r *GuidelineRules) HardDropPoints(rows int) int {
+	return rows * 2
+}
+
+// NESRules are the scoring rules of the original NES Tetris.
+// Line clears are worth 40, 100, 300, or 1200 points multiplied by the level, and the level increases every 10 lines.
+// Levels start at 1 rather than 0, so the multiplier matches NES level + 1.
+// There are no bonuses for T-Spins, Back-to-Back clears, combos, Perfect Clears, or hard drops.
+type NESRules struct{}
+
+// nesLinePoints maps the number of lines cleared to the base points awarded by NESRules.
+var nesLinePoints = map[int]int{
+	0: 0,
+	1: 40,
+	2: 100,
+	3: 300,
+	4: 1200,
+}
+
+// NewNESRules creates a new set of NESRules.
+func NewNESRules() *NESRules {
+	return &NESRules{}
+}
+
+func (r *NESRules) Name() string {
+	return "NES"
+}
+
+func (r *NESRules) ScoreAction(ctx ActionContext) ActionScore {
+	lines := ctx.Action.LinesCleared()
+	return ActionScore{
+		Points:     nesLinePoints[lines] * ctx.Level,
+		LineCredit: lines,
+	}
+}
+
+func (r *NESRules) PerfectClearPoints(_ ActionContext) int {
+	return 0
+}
+
+func (r *NESRules) LevelGoal(level int) int {
+	return level * 10
+}
+
+func (r *NESRules) SoftDropPoints(rows int) int {
+	return rows
+}
+
+func (r *NESRules) HardDropPoints(_ int) int {
+	return 0
+}
+
+// TGMRules are scoring rules in the style of the Tetris The Grand Master series.
+// Points use a simplified form of the TGM formula: ceil((level + lines) / 4) x lines x (combo + 1),
+// with Perfect Clears (bravos) quadrupling the points of the clear.
+// Alongside the score, hidden grade points are awarded for each clear based on the current internal grade,
+// the lines cleared, the combo, and the level. Every 100 grade points the internal grade increases.
+// Grade points decay over time while no combo is active (see TGMRules.AddTime).
+type TGMRules struct {
+	internalGrade int
+	gradePoints   int
+	comboActive   bool
+	lastPoints    int
+	sinceDecay    time.Duration
+}
+
+// tgmGradeTable contains, for each internal grade, the frames between grade point decays and
+// the base grade points for clearing 1 to 4 lines.
+var tgmGradeTable = []struct {
+	decayFrames int
+	points      [4]int
+}{
+	{125, [4]int{10, 20, 40, 50}},
+	{80, [4]int{10, 20, 30, 40}},
+	{80, [4]int{10, 20, 30, 40}},
+	{50, [4]int{10, 15, 30, 40}},
+	{45, [4]int{10, 15, 20, 40}},
+	{45, [4]int{5, 15, 20, 30}},
+	{45, [4]int{5, 10, 20, 30}},
+	{40, [4]int{5, 10, 15, 30}},
+	{40, [4]int{5, 10, 15, 30}},
+	{40, [4]int{5, 10, 15, 30}},
+	{40, [4]int{2, 12, 13, 30}},
+	{40, [4]int{2, 12, 13, 30}},
+	{30, [4]int{2, 12, 13, 30}},
+	{30, [4]int{2, 12, 13, 30}},
+	{30, [4]int{2, 12, 13, 30}},
+	{20, [4]int{2, 12, 13, 30}},
+	{20, [4]int{2, 12, 13, 30}},
+	{20, [4]int{2, 12, 13, 30}},
+	{20, [4]int{2, 12, 13, 30}},
+	{20, [4]int{2, 12, 13, 30}},
+	{15, [4]int{2, 12, 13, 30}},
+	{15, [4]int{2, 12, 13, 30}},
+	{15, [4]int{2, 12, 13, 30}},
+	{15, [4]int{2, 12, 13, 30}},
+	{15, [4]int{2, 12, 13, 30}},
+	{15, [4]int{2, 12, 13, 30}},
+	{15, [4]int{2, 12, 13, 30}},
+	{15, [4]int{2, 12, 13, 30}},
+	{15, [4]int{2, 12, 13, 30}},
+	{15, [4]int{2, 12, 13, 30}},
+	{10, [4]int{2, 12, 13, 30}},
+	{10, [4]int{2, 12, 13, 30}},
+}
+
+// tgmComboMultipliers contains the grade point multiplier (x10) for clearing 1 to 4 lines,
+// indexed by combo count. Combos beyond the end of the table use the last entry.
+var tgmComboMultipliers = [][4]int{
+	{10, 10, 10, 10},
+	{10, 12, 14, 15},
+	{10, 12, 15, 18},
+	{10, 14, 16, 20},
+	{10, 14, 17, 22},
+	{10, 14, 18, 23},
+	{10, 14, 19, 24},
+	{10, 15, 20, 25},
+	{10, 15, 21, 26},
+	{10, 20, 25, 30},
+}
+
+// tgmGrades maps each internal grade to the grade which is displayed.
+var tgmGrades = []string{
+	"9", "8", "7", "6", "5", "4", "4", "3", "3", "2", "2", "2", "1", "1", "1", "S1",
+	"S1", "S2", "S3", "S4", "S4", "S4", "S5", "S5", "S6", "S6", "S7", "S7", "S8", "S8", "S9", "S9",
+}
+
+// NewTGMRules creates a new set of TGMRules starting at grade 9.
+func NewTGMRules() *TGMRules {
+	return &TGMRules{}
+}
+
+func (r *TGMRules) Name() string {
+	return "TGM"
+}
+
+func (r *TGMRules) ScoreAction(ctx ActionContext) ActionScore {
+	lines := ctx.Action.LinesCleared()
+	r.comboActive = lines > 0
+	if lines == 0 {
+		r.lastPoints = 0
+		return ActionScore{}
+	}
+
+	r.addGradePoints(lines, ctx.Combo, ctx.Level)
+
+	r.lastPoints = tgmPoints(ctx, lines)
+	return ActionScore{
+		Points:     r.lastPoints,
+		LineCredit: lines,
+	}
+}
+
+// tgmPoints returns the points awarded by the TGM formula for clearing the given number of lines.
+func tgmPoints(ctx ActionContext, lines int) int {
+	return ((ctx.Level + lines + 3) / 4) * lines * (ctx.Combo + 1)
+}
+
+func (r *TGMRules) addGradePoints(lines, combo, level int) {
+	row := tgmGradeTable[min(r.internalGrade, len(tgmGradeTable)-1)]
+	multiplier := tgmComboMultipliers[min(combo, len(tgmComboMultipliers)-1)][lines-1]
+	levelMultiplier := 1 + level/250
+
+	// Round the combo multiplier up, as TGM does.
+	points := (row.points[lines-1]*multiplier + 9) / 10
+	r.gradePoints += points * levelMultiplier
+
+	for r.gradePoints >= 100 && r.internalGrade < len(tgmGrades)-1 {
+		r.gradePoints -= 100
+		r.internalGrade++
+	}
+	if r.internalGrade == len(tgmGrades)-1 {
+		r.gradePoints = min(r.gradePoints, 99)
+	}
+}
+
+func (r *TGMRules) PerfectClearPoints(_ ActionContext) int {
+	return r.lastPoints * 3
+}
+
+func (r *TGMRules) LevelGoal(level int) int {
+	return level * 10
+}
+
+func (r *TGMRules) SoftDropPoints(rows int) int {
+	return rows
+}
+
+func (r *TGMRules) HardDropPoints(rows int) int {
+	return rows * 2
+}
+
+// AddTime decays the hidden grade points for the given duration of play.
+// Grade points do not decay while a combo is active.
+func (r *TGMRules) AddTime(elapsed time.Duration) {
+	if r.comboActive {
+		return
+	}
+
+	r.sinceDecay += elapsed
+	for {
+		interval := FrameDuration * time.Duration(tgmGradeTable[min(r.internalGrade, len(tgmGradeTable)-1)].decayFrames)
+		if r.sinceDecay < interval {
+			return
+		}
+		r.sinceDecay -= interval
+		r.gradePoints = max(r.gradePoints-1, 0)
+	}
+}
+
+// Grade returns the grade corresponding to the current internal grade.
+func (r *TGMRules) Grade() string {
+	return tgmGrades[r.internalGrade]
+}
+
+// GradePoints returns the hidden grade points accumulated towards the next internal grade.
+func (r *TGMRules) GradePoints() int {
+	return r.gradePoints
+}
+
+// MasterRules are the scoring rules of a TGM-style Master mode, where levels run from 0 to 999.
+// The level increases by 1 for each Tetrimino spawned and by the number of lines cleared, except that
+// Tetriminos cannot advance the level past a section stop (x99 and 998); only clearing lines can.
+// Points use the same formula as TGMRules, and the grade is awarded from the score. The Grand Master grade
+// additionally requires reaching each checkpoint (see masterCheckpoints) with enough points within the time limit.
+type MasterRules struct {
+	score      int // The points awarded by these rules, which is used to award grades.
+	elapsed    time.Duration
+	lastPoints int
+
+	gmEligible bool
+	isComplete bool
+}
+
+// MasterMaxLevel is the level at which a game using MasterRules is complete.
+const MasterMaxLevel = 999
+
+// masterGrades contains the grades awarded by MasterRules, with the score required for each.
+var masterGrades = []struct {
+	grade string
+	score int
+}{
+	{"9", 0}, {"8", 400}, {"7", 800}, {"6", 1400}, {"5", 2000}, {"4", 3500}, {"3", 5500}, {"2", 8000},
+	{"1", 12000}, {"S1", 16000}, {"S2", 22000}, {"S3", 30000}, {"S4", 40000}, {"S5", 52000},
+	{"S6", 66000}, {"S7", 82000}, {"S8", 100000}, {"S9", 120000},
+}
+
+// masterGrandMaster is the grade awarded by MasterRules when every checkpoint is met.
+const masterGrandMaster = "GM"
+
+// masterCheckpoints contains the levels at which the requirements for the Grand Master grade are checked.
+var masterCheckpoints = []struct {
+	level int
+	score int
+	time  time.Duration
+}{
+	{300, 12000, 4*time.Minute + 15*time.Second},
+	{500, 40000, 7*time.Minute + 30*time.Second},
+	{MasterMaxLevel, 126000, 13*time.Minute + 30*time.Second},
+}
+
+// NewMasterRules creates a new set of MasterRules starting at grade 9.
+func NewMasterRules() *MasterRules {
+	return &MasterRules{
+		gmEligible: true,
+	}
+}
+
+func (r *MasterRules) Name() string {
+	return "Master"
+}
+
+func (r *MasterRules) ScoreAction(ctx ActionContext) ActionScore {
+	lines := ctx.Action.LinesCleared()
+	if lines == 0 {
+		r.lastPoints = 0
+		return ActionScore{}
+	}
+
+	r.lastPoints = tgmPoints(ctx, lines)
+	return ActionScore{
+		Points:     r.lastPoints,
+		LineCredit: lines,
+	}
+}
+
+func (r *MasterRules) PerfectClearPoints(_ ActionContext) int {
+	return r.lastPoints * 3
+}
+
+// LevelGoal returns the level at the end of the section containing the given level (eg. 100 for level 42).
+// It is not used to advance the level, but is the target displayed in TGM.
+func (r *MasterRules) LevelGoal(level int) int {
+	return min((level/100+1)*100, MasterMaxLevel)
+}
+
+func (r *MasterRules) SoftDropPoints(rows int) int {
+	return rows
+}
+
+func (r *MasterRules) HardDropPoints(rows int) int {
+	return rows * 2
+}
+
+func (r *MasterRules) SpawnLevel(level int) int {
+	if level%100 == 99 || level >= MasterMaxLevel-1 {
+		return level
+	}
+	return r.advance(level, level+1)
+}
+
+func (r *MasterRules) ClearLevel(level, lines int) int {
+	return r.advance(level, min(level+lines, MasterMaxLevel))
+}
+
+// advance checks the requirements of any checkpoints passed when moving between the given levels.
+func (r *MasterRules) advance(from, to int) int {
+	for _, cp := range masterCheckpoints {
+		if from < cp.level && to >= cp.level && (r.score < cp.score || r.elapsed > cp.time) {
+			r.gmEligible = false
+		}
+	}
+	if to >= MasterMaxLevel {
+		r.isComplete = true
+	}
+	return to
+}
+
+// AddPoints adds the points awarded to the score the grade is awarded from.
+func (r *MasterRules) AddPoints(points int) {
+	r.score += points
+}
+
+func (r *MasterRules) AddTime(elapsed time.Duration) {
+	r.elapsed += elapsed
+}
+
+// Grade returns the grade awarded for the current score, or the Grand Master grade once it has been achieved.
+func (r *MasterRules) Grade() string {
+	if r.isComplete && r.gmEligible {
+		return masterGrandMaster
+	}
+
+	grade := masterGrades[0].grade
+	for _, g := range masterGrades {
+		if r.score < g.score {
+			break
+		}
+		grade = g.grade
+	}
+	return grade
+}
@@ -0,0 +1,297 @@
+package tetris
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetScoringRules(t *testing.T) {
+	tt := map[string]struct {
+		name     string
+		wantName string
+		wantErr  error
+	}{
+		"empty": {
+			name:     "",
+			wantName: "Guideline",
+		},
+		"guideline": {
+			name:     "guideline",
+			wantName: "Guideline",
+		},
+		"NES": {
+			name:     "NES",
+			wantName: "NES",
+		},
+		"TGM": {
+			name:     "TGM",
+			wantName: "TGM",
+		},
+		"unknown": {
+			name:    "BPS",
+			wantErr: errors.New("unknown scoring rules \"BPS\""),
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			rules, err := GetScoringRules(tc.name)
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantName, rules.Name())
+		})
+	}
+}
+
+func TestNESRules_ScoreAction(t *testing.T) {
+	tt := map[string]struct {
+		a     Action
+		level int
+		want  ActionScore
+	}{
+		"none": {
+			a:     Actions.None,
+			level: 1,
+			want:  ActionScore{Points: 0, LineCredit: 0},
+		},
+		"single; level 1": {
+			a:     Actions.Single,
+			level: 1,
+			want:  ActionScore{Points: 40, LineCredit: 1},
+		},
+		"double; level 5": {
+			a:     Actions.Double,
+			level: 5,
+			want:  ActionScore{Points: 500, LineCredit: 2},
+		},
+		"triple; level 10": {
+			a:     Actions.Triple,
+			level: 10,
+			want:  ActionScore{Points: 3000, LineCredit: 3},
+		},
+		"tetris; level 19": {
+			a:     Actions.Tetris,
+			level: 19,
+			want:  ActionScore{Points: 22800, LineCredit: 4},
+		},
+		"T-spin double is a double": {
+			a:     Actions.TSpinDouble,
+			level: 1,
+			want:  ActionScore{Points: 100, LineCredit: 2},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got := NewNESRules().ScoreAction(ActionContext{Action: tc.a, Level: tc.level, BackToBack: true, Combo: 3})
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestScoring_NESLevelProgression(t *testing.T) {
+	s, err := NewScoring(1, 0, true, false, 0, false, WithScoringRules(NewNESRules()))
+	require.NoError(t, err)
+
+	// 2 Tetrises and a Single is 9 lines; not enough for level 2.
+	for _, a := range []Action{Actions.Tetris, Actions.Tetris, Actions.Single} {
+		_, err = s.ProcessAction(a)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 9, s.Lines())
+	assert.Equal(t, 1, s.Level())
+	assert.Equal(t, 1200+1200+40, s.Total())
+
+	_, err = s.ProcessAction(Actions.Single)
+	require.NoError(t, err)
+	assert.Equal(t, 10, s.Lines())
+	assert.Equal(t, 2, s.Level())
+
+	s.AddHardDrop(10)
+	s.AddSoftDrop(3)
+	assert.Equal(t, 1200+1200+40+40+3, s.Total())
+}
+
+func TestTGMRules_Grade(t *testing.T) {
+	r := NewTGMRules()
+	assert.Equal(t, "9", r.Grade())
+
+	// At internal grade 0 a Tetris is worth 50 grade points.
+	r.ScoreAction(ActionContext{Action: Actions.Tetris, Level: 1})
+	assert.Equal(t, 50, r.GradePoints())
+	assert.Equal(t, "9", r.Grade())
+
+	// Combos multiply the grade points; 50 x 1.5 = 75.
+	r.ScoreAction(ActionContext{Action: Actions.Tetris, Level: 1, Combo: 1})
+	assert.Equal(t, "8", r.Grade())
+	assert.Equal(t, 25, r.GradePoints())
+
+	// Grade points do not decay during a combo.
+	r.AddTime(time.Minute)
+	assert.Equal(t, 25, r.GradePoints())
+
+	// At internal grade 1 grade points decay every 80 frames.
+	r.ScoreAction(ActionContext{Action: Actions.None, Level: 1})
+	r.AddTime(FrameDuration * 80 * 5)
+	assert.Equal(t, 20, r.GradePoints())
+	r.AddTime(time.Hour)
+	assert.Equal(t, 0, r.GradePoints())
+	assert.Equal(t, "8", r.Grade())
+}
+
+func TestScoring_Grade(t *testing.T) {
+	s, err := NewScoring(1, 0, false, false, 0, false)
+	require.NoError(t, err)
+	assert.Empty(t, s.Grade())
+
+	s, err = NewScoring(1, 0, false, false, 0, false, WithScoringRules(NewTGMRules()))
+	require.NoError(t, err)
+	assert.Equal(t, "9", s.Grade())
+}
+
+func TestMasterRules_Levels(t *testing.T) {
+	tt := map[string]struct {
+		level     int
+		lines     int
+		wantSpawn int
+		wantClear int
+	}{
+		"start": {
+			level:     0,
+			lines:     1,
+			wantSpawn: 1,
+			wantClear: 1,
+		},
+		"section stop": {
+			level:     99,
+			lines:     2,
+			wantSpawn: 99,
+			wantClear: 101,
+		},
+		"final stop": {
+			level:     998,
+			lines:     4,
+			wantSpawn: 998,
+			wantClear: 999,
+		},
+		"mid section": {
+			level:     450,
+			lines:     3,
+			wantSpawn: 451,
+			wantClear: 453,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := NewMasterRules()
+			assert.Equal(t, tc.wantSpawn, r.SpawnLevel(tc.level))
+			assert.Equal(t, tc.wantClear, r.ClearLevel(tc.level, tc.lines))
+		})
+	}
+}
+
+func TestMasterRules_Grade(t *testing.T) {
+	tt := map[string]struct {
+		score     int
+		elapsed   [3]time.Duration // The time played when reaching levels 300, 500 and 999.
+		wantGrade string
+	}{
+		"no points": {
+			score:     0,
+			elapsed:   [3]time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute},
+			wantGrade: "9",
+		},
+		"grade 1": {
+			score:     12000,
+			elapsed:   [3]time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute},
+			wantGrade: "1",
+		},
+		"S9; too slow at level 300": {
+			score:     130000,
+			elapsed:   [3]time.Duration{5 * time.Minute, 6 * time.Minute, 7 * time.Minute},
+			wantGrade: "S9",
+		},
+		"S9; too slow at level 999": {
+			score:     130000,
+			elapsed:   [3]time.Duration{4 * time.Minute, 7 * time.Minute, 14 * time.Minute},
+			wantGrade: "S9",
+		},
+		"GM": {
+			score:     130000,
+			elapsed:   [3]time.Duration{4 * time.Minute, 7 * time.Minute, 13 * time.Minute},
+			wantGrade: "GM",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := NewMasterRules()
+			r.score = tc.score
+
+			var played time.Duration
+			for i, level := range []int{298, 498, 998} {
+				r.AddTime(tc.elapsed[i] - played)
+				played = tc.elapsed[i]
+				r.ClearLevel(level, 4)
+			}
+			assert.Equal(t, tc.wantGrade, r.Grade())
+		})
+	}
+}
+
+func TestScoring_MasterRules(t *testing.T) {
+	_, err := NewScoring(0, 0, true, false, 0, false)
+	require.EqualError(t, err, "invalid level '0'")
+
+	s, err := NewScoring(0, MasterMaxLevel, true, true, 0, false, WithScoringRules(NewMasterRules()))
+	require.NoError(t, err)
+
+	for range 99 {
+		assert.False(t, s.ProcessSpawn())
+	}
+	assert.Equal(t, 99, s.Level())
+
+	// The section stop holds the level until lines are cleared.
+	assert.False(t, s.ProcessSpawn())
+	assert.Equal(t, 99, s.Level())
+
+	gameOver, err := s.ProcessAction(Actions.Double)
+	require.NoError(t, err)
+	assert.False(t, gameOver)
+	assert.Equal(t, 101, s.Level())
+	assert.Equal(t, ((99+2+3)/4)*2, s.Total())
+	assert.Equal(t, 200, s.Rules().LevelGoal(s.Level()))
+
+	// Reaching level 999 ends the game.
+	s.level = 997
+	gameOver, err = s.ProcessAction(Actions.Tetris)
+	require.NoError(t, err)
+	assert.True(t, gameOver)
+	assert.Equal(t, MasterMaxLevel, s.Level())
+}
+
+func TestScoring_MasterRulesGradePoints(t *testing.T) {
+	r := NewMasterRules()
+	s, err := NewScoring(0, MasterMaxLevel, true, true, 0, false, WithScoringRules(r))
+	require.NoError(t, err)
+
+	// Asking the rules for points does not award them.
+	for range 3 {
+		assert.Equal(t, 400, r.HardDropPoints(200))
+		assert.Equal(t, 400, r.SoftDropPoints(400))
+	}
+	assert.Equal(t, "9", s.Grade())
+
+	// Points awarded by the scoring count towards the grade.
+	s.AddHardDrop(200)
+	assert.Equal(t, 400, s.Total())
+	assert.Equal(t, "8", s.Grade())
+}
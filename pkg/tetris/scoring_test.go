@@ -410,3 +410,95 @@ func TestScoring_ProcessAction(t *testing.T) {
 		})
 	}
 }
+
+func TestScoring_Combo(t *testing.T) {
+	s, err := NewScoring(2, 0, false, false, 0, false)
+	require.NoError(t, err)
+
+	actions := []struct {
+		a             Action
+		wantCombo     int
+		wantMaxCombo  int
+		wantIncrement int
+	}{
+		{a: Actions.Single, wantCombo: 0, wantMaxCombo: 0, wantIncrement: 100 * 2},
+		{a: Actions.Double, wantCombo: 1, wantMaxCombo: 1, wantIncrement: (300 + 50) * 2},
+		{a: Actions.Single, wantCombo: 2, wantMaxCombo: 2, wantIncrement: (100 + 100) * 2},
+		{a: Actions.None, wantCombo: 0, wantMaxCombo: 2, wantIncrement: 0},
+		{a: Actions.Single, wantCombo: 0, wantMaxCombo: 2, wantIncrement: 100 * 2},
+		{a: Actions.TSpin, wantCombo: 0, wantMaxCombo: 2, wantIncrement: 400 * 2},
+	}
+
+	for i, step := range actions {
+		before := s.Total()
+		_, err = s.ProcessAction(step.a)
+		require.NoError(t, err)
+
+		assert.Equal(t, step.wantCombo, s.Combo(), "combo after action %d", i)
+		assert.Equal(t, step.wantMaxCombo, s.MaxCombo(), "max combo after action %d", i)
+		assert.Equal(t, step.wantIncrement, s.Total()-before, "points for action %d", i)
+	}
+}
+
+func TestScoring_AddPerfectClear(t *testing.T) {
+	tt := map[string]struct {
+		a             Action
+		wasBackToBack bool
+		wantTotal     int
+		wantErr       error
+	}{
+		"single": {
+			a:         Actions.Single,
+			wantTotal: 800,
+		},
+		"double": {
+			a:         Actions.Double,
+			wantTotal: 1200,
+		},
+		"triple": {
+			a:         Actions.Triple,
+			wantTotal: 1800,
+		},
+		"tetris": {
+			a:         Actions.Tetris,
+			wantTotal: 2000,
+		},
+		"back to back tetris": {
+			a:             Actions.Tetris,
+			wasBackToBack: true,
+			wantTotal:     3200,
+		},
+		"T-spin double": {
+			a:         Actions.TSpinDouble,
+			wantTotal: 1200,
+		},
+		"none": {
+			a:       Actions.None,
+			wantErr: errors.New("action \"None\" cannot be a perfect clear"),
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			s := &Scoring{
+				level: 1,
+				lastAction: ActionContext{
+					Action:     tc.a,
+					Level:      1,
+					BackToBack: tc.wasBackToBack,
+				},
+			}
+
+			err := s.AddPerfectClear(tc.a)
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+				assert.Equal(t, 0, s.PerfectClears())
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wantTotal, s.Total())
+			assert.Equal(t, 1, s.PerfectClears())
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package tetris
+
+import "slices"
+
+// SearchMoves searches the positions and orientations the Tetrimino can be moved to with the given moves, breadth
+// first so that each is reached with the fewest moves. Each move changes the Tetrimino it is given, and leaves it
+// unchanged if it cannot be made.
+//
+// For each position visit is called with the Tetrimino there and a function returning the indices of the moves which
+// reach it, in order. The Tetrimino must not be modified. The search stops when visit returns true.
+func SearchMoves(tet *Tetrimino, moves []func(t *Tetrimino), visit func(t *Tetrimino, path func() []int) bool) {
+	type state struct {
+		x, y, direction int
+	}
+	type node struct {
+		tet    Tetrimino
+		parent int // The index of the node this was reached from, or -1 for the first.
+		move   int
+	}
+	stateOf := func(t *Tetrimino) state {
+		return state{t.Position.X, t.Position.Y, t.CompassDirection}
+	}
+
+	// Moving and rotating a Tetrimino replaces its cells rather than modifying them, so shallow copies are safe.
+	nodes := []node{{tet: *tet, parent: -1}}
+	visited := map[state]bool{stateOf(tet): true}
+
+	for i := 0; i < len(nodes); i++ {
+		current := nodes[i].tet
+		path := func() []int {
+			var path []int
+			for n := i; nodes[n].parent >= 0; n = nodes[n].parent {
+				path = append(path, nodes[n].move)
+			}
+			slices.Reverse(path)
+			return path
+		}
+		if visit(&current, path) {
+			return
+		}
+
+		for m, move := range moves {
+			next := current
+			move(&next)
+			if visited[stateOf(&next)] {
+				continue
+			}
+			visited[stateOf(&next)] = true
+			nodes = append(nodes, node{tet: next, parent: i, move: m})
+		}
+	}
+}
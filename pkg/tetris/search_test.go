@@ -0,0 +1,63 @@
+package tetris
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchMoves(t *testing.T) {
+	matrix, err := NewMatrix(40, 10)
+	require.NoError(t, err)
+	spawn, err := GetTetrimino('O')
+	require.NoError(t, err)
+	spawn.Position.Y += DefaultDimensions.Buffer
+
+	moves := []func(t *Tetrimino){
+		func(t *Tetrimino) { t.MoveLeft(matrix) },
+		func(t *Tetrimino) { t.MoveRight(matrix) },
+	}
+
+	tt := map[string]struct {
+		x        int
+		wantPath []int
+	}{
+		"start": {
+			x:        spawn.Position.X,
+			wantPath: nil,
+		},
+		"two to the left": {
+			x:        spawn.Position.X - 2,
+			wantPath: []int{0, 0},
+		},
+		"right wall": {
+			x:        8,
+			wantPath: []int{1, 1, 1, 1},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			var path []int
+			found := false
+			SearchMoves(spawn, moves, func(tet *Tetrimino, p func() []int) bool {
+				if tet.Position.X != tc.x {
+					return false
+				}
+				path, found = p(), true
+				return true
+			})
+			require.True(t, found)
+			assert.Equal(t, tc.wantPath, path)
+		})
+	}
+
+	// Every column is visited once.
+	var visits int
+	SearchMoves(spawn, moves, func(_ *Tetrimino, _ func() []int) bool {
+		visits++
+		return false
+	})
+	assert.Equal(t, 9, visits)
+}
@@ -0,0 +1,205 @@
+package solver
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+)
+
+var _ Solver = &BeamSearch{}
+
+// BeamSearch searches the moves of the Tetrimino in play, the held Tetrimino and the Next Queue, keeping only the
+// positions the evaluator scores highest at each depth. It is configured with options passed to NewBeamSearch.
+type BeamSearch struct {
+	scorer  scorer
+	width   int // The most positions kept at each depth.
+	depth   int // The most moves searched ahead. 0 means every known Tetrimino.
+	workers int // The most goroutines which score moves at once.
+}
+
+// NewBeamSearch creates a BeamSearch which scores moves with the evaluator.
+func NewBeamSearch(ev eval.Evaluator, opts ...func(*BeamSearch)) *BeamSearch {
+	b := &BeamSearch{
+		scorer:  scorer{evaluator: ev},
+		width:   16,
+		workers: 1,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// WithBeamWidth sets the most positions kept at each depth of the search. The default is 16.
+func WithBeamWidth(width int) func(*BeamSearch) {
+	return func(b *BeamSearch) {
+		b.width = max(width, 1)
+	}
+}
+
+// WithBeamDepth sets the most moves searched ahead. The default of 0 searches every known Tetrimino.
+func WithBeamDepth(depth int) func(*BeamSearch) {
+	return func(b *BeamSearch) {
+		b.depth = depth
+	}
+}
+
+// WithBeamWorkers sets the most goroutines which score moves at once. The moves of each depth, starting with those from
+// the position being solved, are split between them. The move chosen does not depend on the number of workers.
+// The default is 1.
+func WithBeamWorkers(workers int) func(*BeamSearch) {
+	return func(b *BeamSearch) {
+		b.workers = max(workers, 1)
+	}
+}
+
+// WithBeamTable sets the transposition table which caches the scores of moves. It can be shared by searches which use
+// the same evaluator, including those running at once. By default no scores are cached.
+func WithBeamTable(table *TranspositionTable) func(*BeamSearch) {
+	return func(b *BeamSearch) {
+		b.scorer.table = table
+	}
+}
+
+// beamNode is a position of the beam, with the moves made to reach it and their total score.
+type beamNode struct {
+	pos   *Position
+	moves []Move
+	score float64
+	over  bool // Whether the game is over, so the position cannot be searched further.
+}
+
+// Solve returns the first move of the plan the evaluator scores highest (see Plan).
+func (b *BeamSearch) Solve(ctx context.Context, pos *Position) (Move, error) {
+	plan, err := b.Plan(ctx, pos)
+	if err != nil {
+		return Move{}, err
+	}
+	return plan[0], nil
+}
+
+// Plan returns the sequence of moves the evaluator scores highest in total. The moves say whether to hold before
+// placing, which is considered at every depth. Each depth is searched in full before the next, so when the context is
+// done the best sequence of the deepest finished depth is used. The first depth is always finished.
+func (b *BeamSearch) Plan(ctx context.Context, pos *Position) ([]Move, error) {
+	best, err := b.search(ctx, beamNode{pos: pos})
+	if err != nil {
+		return nil, err
+	}
+	return best.moves, nil
+}
+
+// PlanWith returns the sequence of moves the evaluator scores highest in total which starts with the given move, and
+// that total. It is searched the same way as Plan, so the totals of different first moves can be compared.
+func (b *BeamSearch) PlanWith(ctx context.Context, pos *Position, move Move) ([]Move, float64, error) {
+	s, err := b.scorer.successor(pos, hashMatrix(pos.Matrix), move)
+	if err != nil {
+		return nil, 0, err
+	}
+	best, err := b.search(ctx, beamNode{pos: s.next, moves: []Move{s.move}, score: s.score, over: s.over})
+	if err != nil {
+		return nil, 0, err
+	}
+	return best.moves, best.score, nil
+}
+
+// search expands the beam from the node until the depth of the search is reached, and returns the best node found.
+// The first depth is always finished.
+func (b *BeamSearch) search(ctx context.Context, root beamNode) (beamNode, error) {
+	beam := []beamNode{root}
+	for depth := len(root.moves); b.depth == 0 || depth < b.depth; depth++ {
+		next, err := b.expand(ctx, beam, depth == 0)
+		if err != nil {
+			return beamNode{}, err
+		}
+		if len(next) == 0 {
+			break
+		}
+		beam = next
+	}
+
+	if len(beam[0].moves) == 0 {
+		return beamNode{}, ErrNoMoves
+	}
+	return beam[0], nil
+}
+
+// expand returns the best positions one move deeper than the beam, best first. Positions which are the same as a
+// better one are left out. Nil is returned if there are none, or the context is done before they are all found
+// (unless finish is true).
+func (b *BeamSearch) expand(ctx context.Context, beam []beamNode, finish bool) ([]beamNode, error) {
+	type job struct {
+		node int
+		hash uint64 // The hash of the Matrix of the node.
+		move Move
+	}
+	var jobs []job
+	for i, node := range beam {
+		if !finish && ctx.Err() != nil {
+			return nil, nil
+		}
+		if node.over {
+			continue
+		}
+		hash := hashMatrix(node.pos.Matrix)
+		for _, move := range moves(node.pos) {
+			jobs = append(jobs, job{node: i, hash: hash, move: move})
+		}
+	}
+
+	// Each move is scored into its own slot, so the order of the moves does not depend on the workers.
+	next := make([]beamNode, len(jobs))
+	err := parallel(b.workers, len(jobs), func(i int) error {
+		if !finish {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		j := jobs[i]
+		s, err := b.scorer.successor(beam[j.node].pos, j.hash, j.move)
+		if err != nil {
+			return err
+		}
+		parent := beam[j.node]
+		next[i] = beamNode{
+			pos:   s.next,
+			moves: append(slices.Clip(parent.moves), s.move),
+			score: parent.score + s.score,
+			over:  s.over,
+		}
+		return nil
+	})
+	if err != nil {
+		if !finish && errors.Is(err, ctx.Err()) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// Positions which end the game are only kept if there are no others. Ties keep the order the moves were found in.
+	slices.SortStableFunc(next, func(a, c beamNode) int {
+		if a.over != c.over {
+			if a.over {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(c.score, a.score)
+	})
+
+	kept := make([]beamNode, 0, b.width)
+	seen := make(map[uint64]bool)
+	for _, node := range next {
+		if len(kept) == b.width {
+			break
+		}
+		if key := node.pos.hash(); !seen[key] {
+			seen[key] = true
+			kept = append(kept, node)
+		}
+	}
+	return kept, nil
+}
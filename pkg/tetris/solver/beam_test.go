@@ -0,0 +1,160 @@
+package solver
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+)
+
+func TestSolvers_Play(t *testing.T) {
+	tt := map[string]struct {
+		solver Solver
+		pieces int
+	}{
+		"beam search": {
+			solver: NewBeamSearch(eval.ElTetris(), WithBeamWidth(4), WithBeamDepth(3)),
+			pieces: 50,
+		},
+		"mcts": {
+			solver: NewMCTS(eval.ElTetris(), WithIterations(30), WithMCTSSeed(1)),
+			pieces: 20,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			game, err := single.NewGame(&single.Input{Level: 1, Rand: rand.New(rand.NewPCG(1, 1))})
+			require.NoError(t, err)
+
+			for range tc.pieces {
+				move, err := tc.solver.Solve(context.Background(), NewPosition(game))
+				require.NoError(t, err)
+				gameOver, err := Play(game, move)
+				require.NoError(t, err)
+				require.False(t, gameOver)
+			}
+			assert.Equal(t, tc.pieces, game.GetPiecesPlaced())
+			assert.Positive(t, game.GetLinesCleared())
+		})
+	}
+}
+
+func TestBeamSearch_Solve(t *testing.T) {
+	tt := map[string]struct {
+		pos     *Position
+		want    byte
+		wantErr error
+	}{
+		"places the I in the well": {
+			pos: &Position{
+				Matrix: newMatrix(t,
+					"..........",
+					"XXXXXXXXX.",
+					"XXXXXXXXX.",
+					"XXXXXXXXX.",
+					"XXXXXXXXX.",
+				),
+				Current: spawn(t, 'O'),
+				CanHold: true,
+				Queue:   queue(t, "I"),
+			},
+			want: 'I',
+		},
+		"no moves": {
+			pos:     &Position{Matrix: newMatrix(t)},
+			wantErr: ErrNoMoves,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			move, err := NewBeamSearch(eval.ElTetris()).Solve(context.Background(), tc.pos)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, move.Placement.Value)
+			assert.True(t, move.Hold)
+		})
+	}
+}
+
+func TestBeamSearch_PlanWith(t *testing.T) {
+	pos := &Position{
+		Matrix: newMatrix(t,
+			"..........",
+			"XXXXXXXXX.",
+			"XXXXXXXXX.",
+			"XXXXXXXXX.",
+			"XXXXXXXXX.",
+		),
+		Current: spawn(t, 'I'),
+		Queue:   queue(t, "OT"),
+	}
+	b := NewBeamSearch(eval.ElTetris(), WithBeamDepth(2))
+	best, err := b.Plan(context.Background(), pos)
+	require.NoError(t, err)
+
+	plan, bestScore, err := b.PlanWith(context.Background(), pos, best[0])
+	require.NoError(t, err)
+	assert.Equal(t, best, plan)
+
+	// Covering the well with the I scores worse than filling it.
+	var covered Move
+	for _, move := range Drops(pos.Current, pos.Matrix) {
+		if move.Placement.Position.Y+lowestCellRow(move.Placement) == len(pos.Matrix)-5 {
+			covered = move
+			break
+		}
+	}
+	require.NotNil(t, covered.Placement)
+	plan, score, err := b.PlanWith(context.Background(), pos, covered)
+	require.NoError(t, err)
+	require.Len(t, plan, 2)
+	assert.Equal(t, covered, plan[0])
+	assert.Less(t, score, bestScore)
+}
+
+func TestBeamSearch_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	// The first depth is searched even when the context is done.
+	move, err := NewBeamSearch(eval.ElTetris()).Solve(ctx, &Position{
+		Matrix:  newMatrix(t),
+		Current: spawn(t, 'T'),
+		Queue:   queue(t, "IOSZJL"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, byte('T'), move.Placement.Value)
+}
+
+func TestBeamSearch_Workers(t *testing.T) {
+	game, err := single.NewGame(&single.Input{Level: 1, Rand: rand.New(rand.NewPCG(2, 2))})
+	require.NoError(t, err)
+	table := NewTranspositionTable(1)
+
+	// The moves chosen are the same however many workers there are, and whether scores are cached.
+	for range 10 {
+		pos := NewPosition(game)
+		want, err := NewBeamSearch(eval.ElTetris(), WithBeamDepth(3)).Solve(context.Background(), pos)
+		require.NoError(t, err)
+		got, err := NewBeamSearch(eval.ElTetris(), WithBeamDepth(3), WithBeamWorkers(4), WithBeamTable(table)).
+			Solve(context.Background(), pos)
+		require.NoError(t, err)
+		assert.Equal(t, minos(want.Placement), minos(got.Placement))
+		assert.Equal(t, want.Hold, got.Hold)
+
+		_, err = Play(game, got)
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,162 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+)
+
+// ErrNoMoves is returned when no Tetrimino can be placed.
+var ErrNoMoves = errors.New("no moves are possible")
+
+// Drops returns a move to every distinct placement the Tetrimino can be hard dropped into, after moving it left or
+// right and rotating it from where it is. Each move has the fewest inputs which reach its placement this way.
+// Unlike Placements, no placement which needs a soft drop (eg. a tuck or spin) is returned.
+func Drops(tet *tetris.Tetrimino, matrix tetris.Matrix) []Move {
+	type state struct {
+		x, y, direction int
+	}
+	type node struct {
+		tet    tetris.Tetrimino
+		inputs []Input
+	}
+	stateOf := func(t *tetris.Tetrimino) state {
+		return state{t.Position.X, t.Position.Y, t.CompassDirection}
+	}
+
+	moves := []struct {
+		input Input
+		move  func(t *tetris.Tetrimino)
+	}{
+		{InputLeft, func(t *tetris.Tetrimino) { t.MoveLeft(matrix) }},
+		{InputRight, func(t *tetris.Tetrimino) { t.MoveRight(matrix) }},
+		// Rotation only fails for invalid rotation compasses, in which case the Tetrimino is not modified.
+		{InputRotateClockwise, func(t *tetris.Tetrimino) { _ = t.Rotate(matrix, true) }},
+		{InputRotateCounterClockwise, func(t *tetris.Tetrimino) { _ = t.Rotate(matrix, false) }},
+	}
+
+	var drops []Move
+	seen := make(map[[4]tetris.Coordinate]bool)
+	visited := map[state]bool{stateOf(tet): true}
+	queue := []node{{tet: *tet}}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		landed := current.tet
+		hardDrop(&landed, matrix)
+		if key := minos(&landed); !seen[key] {
+			seen[key] = true
+			drops = append(drops, Move{
+				Placement: landed.DeepCopy(),
+				Inputs:    append(slices.Clone(current.inputs), InputHardDrop),
+			})
+		}
+
+		for _, m := range moves {
+			next := current.tet
+			m.move(&next)
+			if visited[stateOf(&next)] {
+				continue
+			}
+			visited[stateOf(&next)] = true
+			queue = append(queue, node{tet: next, inputs: append(slices.Clone(current.inputs), m.input)})
+		}
+	}
+	return drops
+}
+
+// hardDrop moves the Tetrimino down until it lands.
+func hardDrop(t *tetris.Tetrimino, matrix tetris.Matrix) {
+	for {
+		if !t.MoveDown(matrix) {
+			return
+		}
+	}
+}
+
+// Greedy returns the drop (see Drops) which the evaluator scores highest. The Tetrimino in play is considered, and
+// if it can be held so is the Tetrimino holding would swap in.
+func Greedy(pos *Position, ev eval.Evaluator) (Move, error) {
+	var best Move
+	bestScore, found := 0.0, false
+
+	for _, candidate := range candidates(pos) {
+		for _, move := range Drops(candidate.tet, pos.Matrix) {
+			score, _, err := ev.Evaluate(pos.Matrix, move.Placement)
+			if err != nil {
+				return Move{}, fmt.Errorf("evaluating placement: %w", err)
+			}
+			if !found || score > bestScore {
+				move.Hold = candidate.hold
+				best, bestScore, found = move, score, true
+			}
+		}
+	}
+	if !found {
+		return Move{}, ErrNoMoves
+	}
+	return best, nil
+}
+
+var _ Solver = &GreedySearch{}
+
+// GreedySearch is a Solver which makes the drop the evaluator scores highest, without searching ahead (see Greedy).
+type GreedySearch struct {
+	evaluator eval.Evaluator
+}
+
+// NewGreedySearch creates a GreedySearch which scores moves with the evaluator.
+func NewGreedySearch(ev eval.Evaluator) *GreedySearch {
+	return &GreedySearch{evaluator: ev}
+}
+
+// Solve returns the drop the evaluator scores highest. It is too quick to need to stop when the context is done.
+func (g *GreedySearch) Solve(_ context.Context, pos *Position) (Move, error) {
+	return Greedy(pos, g.evaluator)
+}
+
+// candidate is a Tetrimino which can be placed next, and whether it must be held for.
+type candidate struct {
+	tet  *tetris.Tetrimino
+	hold bool
+}
+
+// candidates returns the Tetriminos which can be placed next: the Tetrimino in play, and if it can be held the held
+// Tetrimino (or the next in the queue if none is held, as the first hold draws from the Next Queue). Holding is left
+// out when it would swap in a Tetrimino like the one in play, since it could only be placed the same ways, or one which
+// is blocked where it spawns, since that ends the game.
+func candidates(pos *Position) []candidate {
+	var c []candidate
+	if pos.Current != nil {
+		c = append(c, candidate{tet: pos.Current})
+	}
+
+	var swap *tetris.Tetrimino
+	switch {
+	case !pos.CanHold:
+		return c
+	case pos.Hold != nil:
+		swap = pos.Hold
+	case len(pos.Queue) > 0:
+		swap = &pos.Queue[0]
+	default:
+		return c
+	}
+	if (pos.Current != nil && swap.Value == pos.Current.Value) || !swap.IsValid(pos.Matrix, false) {
+		return c
+	}
+	return append(c, candidate{tet: entered(swap, pos.Matrix), hold: true})
+}
+
+// entered returns a copy of the spawned Tetrimino where it enters play, which is one row down if it can move there.
+func entered(spawn *tetris.Tetrimino, matrix tetris.Matrix) *tetris.Tetrimino {
+	tet := *spawn
+	tet.MoveDown(matrix)
+	return &tet
+}
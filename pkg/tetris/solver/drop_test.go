@@ -0,0 +1,98 @@
+package solver
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+)
+
+func TestDrops(t *testing.T) {
+	tt := map[string]struct {
+		value  byte
+		matrix tetris.Matrix
+		want   int
+	}{
+		"I": {
+			value:  'I',
+			matrix: newMatrix(t),
+			want:   17,
+		},
+		"T": {
+			value:  'T',
+			matrix: newMatrix(t),
+			want:   34,
+		},
+		"O cannot be tucked under an overhang": {
+			value: 'O',
+			matrix: newMatrix(t,
+				"XXXXXX....",
+				"..........",
+				"..........",
+			),
+			// 6 on top of the overhang, and 3 on the floor beside it.
+			want: 9,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			drops := Drops(spawn(t, tc.value), tc.matrix)
+			assert.Len(t, drops, tc.want)
+			for _, d := range drops {
+				assert.True(t, d.Placement.IsValid(tc.matrix, true))
+				assert.False(t, d.Placement.DeepCopy().MoveDown(tc.matrix))
+				assert.Equal(t, InputHardDrop, d.Inputs[len(d.Inputs)-1])
+			}
+		})
+	}
+}
+
+func TestGreedy_Play(t *testing.T) {
+	game, err := single.NewGame(&single.Input{Level: 1, Rand: rand.New(rand.NewPCG(1, 1))})
+	require.NoError(t, err)
+
+	// Each move chosen by the greedy solver is played by its inputs.
+	holds := 0
+	for range 100 {
+		move, err := Greedy(NewPosition(game), eval.ElTetris())
+		require.NoError(t, err)
+		if move.Hold {
+			holds++
+		}
+
+		gameOver, err := Play(game, move)
+		require.NoError(t, err)
+		require.False(t, gameOver)
+	}
+	assert.Equal(t, 100, game.GetPiecesPlaced())
+	assert.Positive(t, game.GetLinesCleared())
+	assert.Positive(t, holds)
+}
+
+func TestPlay_MissedPlacement(t *testing.T) {
+	game, err := single.NewGame(&single.Input{Level: 1, Sequence: []byte("OO")})
+	require.NoError(t, err)
+
+	// The inputs hard drop the O where it spawns, rather than at the left wall.
+	placement := game.GetTetInPlay()
+	placement.Position.X = 0
+	placement.Position.Y = len(game.GetMatrix()) - 2
+	_, err = Play(game, Move{Placement: placement, Inputs: []Input{InputHardDrop}})
+	require.ErrorIs(t, err, ErrMissedPlacement)
+}
+
+func TestPlay_CannotHold(t *testing.T) {
+	game, err := single.NewGame(&single.Input{Level: 1, Sequence: []byte("TIO")})
+	require.NoError(t, err)
+	_, err = game.Hold()
+	require.NoError(t, err)
+
+	_, err = Play(game, Move{Hold: true})
+	require.ErrorIs(t, err, ErrCannotHold)
+}
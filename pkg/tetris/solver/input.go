@@ -0,0 +1,115 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+)
+
+// Input is an input to a game which moves the Tetrimino in play.
+type Input int
+
+const (
+	InputLeft Input = iota
+	InputRight
+	InputRotateClockwise
+	InputRotateCounterClockwise
+	InputSoftDrop // Moves the Tetrimino down one row, without locking it down.
+	InputHardDrop
+)
+
+func (i Input) String() string {
+	switch i {
+	case InputLeft:
+		return "left"
+	case InputRight:
+		return "right"
+	case InputRotateClockwise:
+		return "rotate clockwise"
+	case InputRotateCounterClockwise:
+		return "rotate counter-clockwise"
+	case InputSoftDrop:
+		return "soft drop"
+	case InputHardDrop:
+		return "hard drop"
+	}
+	return fmt.Sprintf("Input(%d)", int(i))
+}
+
+var (
+	// ErrMissedPlacement is returned by Play when the inputs of a move do not lock down its placement.
+	ErrMissedPlacement = errors.New("inputs did not lock down the placement")
+	// ErrCannotHold is returned by Play when a move holds, but the Tetrimino in play cannot be held.
+	ErrCannotHold = errors.New("tetrimino in play cannot be held")
+	// ErrEntryDelay is returned by Play when the game is waiting for the next Tetrimino to enter play, so it ignores
+	// inputs.
+	ErrEntryDelay = errors.New("game is in an entry delay")
+)
+
+// Play performs the move in the game, holding first if the move says to. The inputs of the move must lock down the
+// Tetrimino in play, otherwise an error is returned. If the move has no inputs, the fewest which reach its placement
+// are used (see Path). If true is returned the game is over.
+func Play(g *single.Game, move Move) (bool, error) {
+	if g.IsInEntryDelay() {
+		return false, ErrEntryDelay
+	}
+	if move.Hold {
+		if !g.CanHold() {
+			return false, ErrCannotHold
+		}
+		gameOver, err := g.Hold()
+		if err != nil {
+			return false, fmt.Errorf("holding: %w", err)
+		}
+		if gameOver {
+			return true, nil
+		}
+	}
+
+	inputs := move.Inputs
+	if inputs == nil {
+		var err error
+		inputs, err = Path(g.GetTetInPlay(), move.Placement, g.GetMatrix())
+		if err != nil {
+			return false, fmt.Errorf("finding path to placement: %w", err)
+		}
+	}
+
+	placed := g.GetPiecesPlaced()
+	for _, input := range inputs {
+		gameOver, err := apply(g, input)
+		if err != nil {
+			return false, fmt.Errorf("applying input %q: %w", input, err)
+		}
+		if gameOver {
+			return true, nil
+		}
+	}
+
+	if g.GetPiecesPlaced() != placed+1 || minos(g.GetLastPlacement()) != minos(move.Placement) {
+		return false, ErrMissedPlacement
+	}
+	return false, nil
+}
+
+// apply performs the input in the game. If true is returned the game is over.
+func apply(g *single.Game, input Input) (bool, error) {
+	switch input {
+	case InputLeft:
+		g.MoveLeft()
+	case InputRight:
+		g.MoveRight()
+	case InputRotateClockwise:
+		return false, g.Rotate(true)
+	case InputRotateCounterClockwise:
+		return false, g.Rotate(false)
+	case InputSoftDrop:
+		g.SoftDrop()
+	case InputHardDrop:
+		return g.HardDrop()
+	default:
+		return false, fmt.Errorf("unknown input %d", int(input))
+	}
+	return false, nil
+}
@@ -0,0 +1,291 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+)
+
+var _ Solver = &MCTS{}
+
+// MCTS is a Monte Carlo tree search. Each iteration samples the Tetriminos after the Next Queue from the 7-bag, follows
+// the tree of moves to a move which has not been tried, and plays greedily from there to score it. It is configured
+// with options passed to NewMCTS.
+type MCTS struct {
+	scorer       scorer
+	seed         uint64
+	workers      int     // The number of trees searched at once.
+	iterations   int     // The most iterations to search for, across every tree.
+	rolloutDepth int     // The number of moves played greedily to score a new move.
+	exploration  float64 // How much less tried moves are favoured over those which have scored well.
+}
+
+// NewMCTS creates an MCTS which scores moves with the evaluator.
+func NewMCTS(ev eval.Evaluator, opts ...func(*MCTS)) *MCTS {
+	m := &MCTS{
+		scorer:       scorer{evaluator: ev},
+		workers:      1,
+		iterations:   1000,
+		rolloutDepth: 2,
+		exploration:  math.Sqrt2,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// WithMCTSSeed sets the seed of the random Tetriminos sampled by the search. A search with the same seed, position,
+// workers and number of iterations always chooses the same move.
+func WithMCTSSeed(seed uint64) func(*MCTS) {
+	return func(m *MCTS) {
+		m.seed = seed
+	}
+}
+
+// WithMCTSWorkers sets the number of trees searched at once, each on its own goroutine with its share of the
+// iterations and its own samples of the Tetriminos. The visits of the moves from the position being solved are summed
+// across the trees. The default is 1.
+func WithMCTSWorkers(workers int) func(*MCTS) {
+	return func(m *MCTS) {
+		m.workers = max(workers, 1)
+	}
+}
+
+// WithMCTSTable sets the transposition table which caches the scores of moves. It can be shared by searches which use
+// the same evaluator, including those running at once. By default no scores are cached.
+func WithMCTSTable(table *TranspositionTable) func(*MCTS) {
+	return func(m *MCTS) {
+		m.scorer.table = table
+	}
+}
+
+// WithIterations sets the most iterations to search for, if the context is not done first. The default is 1000.
+func WithIterations(iterations int) func(*MCTS) {
+	return func(m *MCTS) {
+		m.iterations = max(iterations, 1)
+	}
+}
+
+// WithRolloutDepth sets the number of moves played greedily to score a new move of the tree. The default is 2.
+func WithRolloutDepth(depth int) func(*MCTS) {
+	return func(m *MCTS) {
+		m.rolloutDepth = depth
+	}
+}
+
+// WithExploration sets how much moves which have been tried less are favoured over those which have scored well.
+// The default is √2.
+func WithExploration(c float64) func(*MCTS) {
+	return func(m *MCTS) {
+		m.exploration = c
+	}
+}
+
+// mctsNode is a move of the tree, with the results of the iterations which made it.
+type mctsNode struct {
+	move   Move
+	score  float64 // The score the evaluator gave the move.
+	visits int
+	total  float64 // The sum of the values of the iterations which made the move.
+
+	// children are the moves after this one, by the Tetriminos which could be placed (see candidateKey). Which
+	// Tetriminos they are can depend on the Tetriminos sampled by the iteration.
+	children map[[2]byte][]*mctsNode
+}
+
+// mctsTree is the state of a search.
+type mctsTree struct {
+	root               *mctsNode
+	depth              int     // The most moves deep the tree is.
+	minValue, maxValue float64 // The range of the values seen, which are normalised when comparing moves.
+}
+
+// Solve returns the move which was made by the most iterations. The search stops after the most iterations (see
+// WithIterations) or when the context is done, but each tree always finishes at least one iteration.
+func (m *MCTS) Solve(ctx context.Context, pos *Position) (Move, error) {
+	trees := make([]*mctsTree, m.workers)
+	err := parallel(m.workers, m.workers, func(w int) error {
+		iterations := m.iterations / m.workers
+		if w < m.iterations%m.workers {
+			iterations++
+		}
+		//nolint:gosec // This random source is not for any security-related tasks, and w is non-negative.
+		r := rand.New(rand.NewPCG(m.seed, uint64(w)))
+		tree := &mctsTree{
+			root:     &mctsNode{},
+			minValue: math.Inf(1),
+			maxValue: math.Inf(-1),
+		}
+		for i := 0; i < max(iterations, 1); i++ {
+			if i > 0 && ctx.Err() != nil {
+				break
+			}
+			if err := m.iterate(r, tree, pos); err != nil {
+				return err
+			}
+		}
+		trees[w] = tree
+		return nil
+	})
+	if err != nil {
+		return Move{}, err
+	}
+
+	// Every tree has the same moves from the position, in the same order.
+	key := candidateKey(pos)
+	roots := trees[0].root.children[key]
+	visits := make([]int, len(roots))
+	totals := make([]float64, len(roots))
+	for _, tree := range trees {
+		for i, child := range tree.root.children[key] {
+			visits[i] += child.visits
+			totals[i] += child.total
+		}
+	}
+
+	mean := func(i int) float64 {
+		if visits[i] == 0 {
+			return 0
+		}
+		return totals[i] / float64(visits[i])
+	}
+	best := -1
+	for i := range roots {
+		if best < 0 || visits[i] > visits[best] || (visits[i] == visits[best] && mean(i) > mean(best)) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return Move{}, ErrNoMoves
+	}
+	return roots[best].move, nil
+}
+
+// iterate samples the Tetriminos after the Next Queue, then selects moves down the tree until one is made for the
+// first time, scores it with a greedy rollout and records the value in every move made.
+func (m *MCTS) iterate(r *rand.Rand, tree *mctsTree, root *Position) error {
+	// Enough Tetriminos are sampled to make a move deeper than the tree, and then the rollout.
+	pos := root.sampleQueue(r, tree.depth+1+m.rolloutDepth)
+	node := tree.root
+	path := []*mctsNode{node}
+	total, moves := 0.0, 0
+
+	for {
+		key := candidateKey(pos)
+		children, expanded := node.children[key]
+		if !expanded {
+			succs, err := m.scorer.successors(pos)
+			if err != nil {
+				return err
+			}
+			for _, s := range succs {
+				children = append(children, &mctsNode{move: s.move, score: s.score})
+			}
+			if node.children == nil {
+				node.children = make(map[[2]byte][]*mctsNode)
+			}
+			node.children[key] = children
+		}
+		if len(children) == 0 {
+			break
+		}
+
+		child := m.selectChild(tree, children)
+		matrix, _, err := lock(pos.Matrix, child.move.Placement)
+		if err != nil {
+			return fmt.Errorf("locking down placement: %w", err)
+		}
+		next, ok := pos.after(child.move, matrix)
+		pos = next
+		path = append(path, child)
+		total += child.score
+		moves++
+		node = child
+		if !ok || child.visits == 0 {
+			break
+		}
+	}
+
+	tree.depth = max(tree.depth, len(path)-1)
+
+	for range m.rolloutDepth {
+		succs, err := m.scorer.successors(pos)
+		if err != nil {
+			return err
+		}
+		if len(succs) == 0 {
+			break
+		}
+		best := succs[0]
+		for _, s := range succs[1:] {
+			if s.score > best.score {
+				best = s
+			}
+		}
+		pos = best.next
+		total += best.score
+		moves++
+		if best.over {
+			break
+		}
+	}
+
+	// The value is the mean score of the moves, so iterations which end the game early are comparable.
+	value := 0.0
+	if moves > 0 {
+		value = total / float64(moves)
+	}
+	tree.minValue = math.Min(tree.minValue, value)
+	tree.maxValue = math.Max(tree.maxValue, value)
+	for _, n := range path {
+		n.visits++
+		n.total += value
+	}
+	return nil
+}
+
+// selectChild returns the move to make: the first which has not been made, or else the one with the highest upper
+// confidence bound (UCT).
+func (m *MCTS) selectChild(tree *mctsTree, children []*mctsNode) *mctsNode {
+	visits := 0
+	for _, child := range children {
+		if child.visits == 0 {
+			return child
+		}
+		visits += child.visits
+	}
+
+	var best *mctsNode
+	bestBound := math.Inf(-1)
+	for _, child := range children {
+		exploit := 0.0
+		if tree.maxValue > tree.minValue {
+			exploit = (child.mean() - tree.minValue) / (tree.maxValue - tree.minValue)
+		}
+		bound := exploit + m.exploration*math.Sqrt(math.Log(float64(visits))/float64(child.visits))
+		if bound > bestBound {
+			best, bestBound = child, bound
+		}
+	}
+	return best
+}
+
+func (n *mctsNode) mean() float64 {
+	if n.visits == 0 {
+		return 0
+	}
+	return n.total / float64(n.visits)
+}
+
+// candidateKey returns the values of the Tetriminos which can be placed from the position (see candidates).
+func candidateKey(pos *Position) [2]byte {
+	var key [2]byte
+	for i, c := range candidates(pos) {
+		key[i] = c.tet.Value
+	}
+	return key
+}
@@ -0,0 +1,65 @@
+package solver
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+)
+
+func TestMCTS_Deterministic(t *testing.T) {
+	pos := &Position{
+		Matrix: newMatrix(t,
+			"..........",
+			"XX..XXX..X",
+			"XXX.XXXX.X",
+		),
+		Current: spawn(t, 'T'),
+		CanHold: true,
+		Queue:   queue(t, "SZ"),
+		Bag:     []byte("IJLO"),
+	}
+
+	table := NewTranspositionTable(1)
+	solve := func() Move {
+		move, err := NewMCTS(eval.ElTetris(),
+			WithIterations(100), WithMCTSSeed(7), WithMCTSWorkers(4), WithMCTSTable(table),
+		).Solve(context.Background(), pos)
+		require.NoError(t, err)
+		return move
+	}
+
+	// The trees are searched at once, and share the table, but the move chosen is the same each time.
+	want := solve()
+	for range 2 {
+		got := solve()
+		assert.Equal(t, minos(want.Placement), minos(got.Placement))
+		assert.Equal(t, want.Hold, got.Hold)
+	}
+}
+
+func TestPosition_SampleQueue(t *testing.T) {
+	pos := &Position{
+		Matrix:  newMatrix(t),
+		Current: spawn(t, 'T'),
+		Queue:   queue(t, "SZIJLOT"),
+		Bag:     []byte("OT"),
+	}
+
+	//nolint:gosec // This random source is not for any security-related tasks.
+	sampled := pos.sampleQueue(rand.New(rand.NewPCG(1, 1)), 9)
+	require.Len(t, sampled.Queue, 16)
+
+	// The rest of the bag comes first, and then a new bag.
+	values := make([]byte, 0, 9)
+	for _, tet := range sampled.Queue[7:] {
+		values = append(values, tet.Value)
+	}
+	assert.ElementsMatch(t, []byte("OT"), values[:2])
+	assert.ElementsMatch(t, bagValues, values[2:])
+	assert.Len(t, pos.Queue, 7)
+}
@@ -0,0 +1,81 @@
+package solver
+
+import (
+	"errors"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+)
+
+// ErrUnreachable is returned when the Tetrimino cannot be moved to a placement.
+var ErrUnreachable = errors.New("placement cannot be reached")
+
+// Path returns the fewest inputs which move the Tetrimino from where it is to the target placement and lock it down
+// there. Moving left or right, rotating (with the kicks of the Tetrimino's rotation compasses) and soft dropping one
+// row are each an input, so the path can tuck the Tetrimino under an overhang or spin it into a slot. The path ends
+// with a hard drop, which is not counted.
+//
+// The target is reached when the hard drop fills the same cells, so orientations of the I, S, Z and O Tetriminos
+// which fill the same cells are the same. Of equally short paths, those which move and rotate before soft dropping are
+// preferred. If the target cannot be reached ErrUnreachable is returned.
+// Each input is one action in the game, so a shift to the wall costs one input per column, unlike in
+// tetris.FinessePlacements.
+func Path(tet, target *tetris.Tetrimino, matrix tetris.Matrix) ([]Input, error) {
+	want := minos(target)
+	var path []Input
+	searchPaths(tet, matrix, func(landed *tetris.Tetrimino, inputs func() []Input) bool {
+		if minos(landed) != want {
+			return false
+		}
+		path = inputs()
+		return true
+	})
+	if path == nil {
+		return nil, ErrUnreachable
+	}
+	return path, nil
+}
+
+// Reachable returns a move to every distinct placement the Tetrimino can reach from where it is, with the fewest
+// inputs which reach it (see Path). Unlike Drops, placements which need soft drops (eg. tucks and spins) are included.
+func Reachable(tet *tetris.Tetrimino, matrix tetris.Matrix) []Move {
+	var reachable []Move
+	seen := make(map[[4]tetris.Coordinate]bool)
+	searchPaths(tet, matrix, func(landed *tetris.Tetrimino, inputs func() []Input) bool {
+		if key := minos(landed); !seen[key] {
+			seen[key] = true
+			reachable = append(reachable, Move{Placement: landed.DeepCopy(), Inputs: inputs()})
+		}
+		return false
+	})
+	return reachable
+}
+
+// searchPaths searches the states the Tetrimino can be moved to breadth first, so each is reached with the fewest
+// inputs (see tetris.SearchMoves). For each state, visit is called with where a hard drop from it lands and a function
+// returning the inputs which lock it down there. The search stops when visit returns true.
+func searchPaths(
+	tet *tetris.Tetrimino, matrix tetris.Matrix, visit func(landed *tetris.Tetrimino, inputs func() []Input) bool,
+) {
+	inputs := []Input{InputLeft, InputRight, InputRotateClockwise, InputRotateCounterClockwise, InputSoftDrop}
+	moves := []func(t *tetris.Tetrimino){
+		func(t *tetris.Tetrimino) { t.MoveLeft(matrix) },
+		func(t *tetris.Tetrimino) { t.MoveRight(matrix) },
+		// Rotation only fails for invalid rotation compasses, in which case the Tetrimino is not modified.
+		func(t *tetris.Tetrimino) { _ = t.Rotate(matrix, true) },
+		func(t *tetris.Tetrimino) { _ = t.Rotate(matrix, false) },
+		func(t *tetris.Tetrimino) { t.MoveDown(matrix) },
+	}
+
+	tetris.SearchMoves(tet, moves, func(t *tetris.Tetrimino, path func() []int) bool {
+		landed := *t
+		hardDrop(&landed, matrix)
+		return visit(&landed, func() []Input {
+			moved := path()
+			path := make([]Input, 0, len(moved)+1)
+			for _, m := range moved {
+				path = append(path, inputs[m])
+			}
+			return append(path, InputHardDrop)
+		})
+	})
+}
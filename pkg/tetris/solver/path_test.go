@@ -0,0 +1,150 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+)
+
+func TestPath(t *testing.T) {
+	tt := map[string]struct {
+		value     byte
+		matrix    tetris.Matrix
+		target    func(t *tetris.Tetrimino, matrix tetris.Matrix)
+		wantLen   int
+		wantInput Input // An input the path must use.
+		wantErr   error
+	}{
+		"move to the wall": {
+			value:  'T',
+			matrix: newMatrix(t),
+			target: func(t *tetris.Tetrimino, matrix tetris.Matrix) {
+				for {
+					if !t.MoveRight(matrix) {
+						break
+					}
+				}
+			},
+			wantLen:   5,
+			wantInput: InputRight,
+		},
+		"tuck under an overhang": {
+			value: 'O',
+			matrix: newMatrix(t,
+				"XXXXXX....",
+				"..........",
+				"..........",
+			),
+			target: func(t *tetris.Tetrimino, matrix tetris.Matrix) {
+				t.Position.X, t.Position.Y = 4, len(matrix)-2
+			},
+			wantInput: InputSoftDrop,
+		},
+		"tuck upside down into a t-slot": {
+			value: 'T',
+			matrix: newMatrix(t,
+				"..........",
+				"XXXX......",
+				"XXX...XXXX",
+				"XXXX.XXXXX",
+			),
+			target: func(t *tetris.Tetrimino, matrix tetris.Matrix) {
+				// Upside down, filling the slot.
+				_ = t.Rotate(matrix, true)
+				_ = t.Rotate(matrix, true)
+				t.Position.X, t.Position.Y = 3, len(matrix)-2
+			},
+			wantInput: InputSoftDrop,
+		},
+		"enclosed": {
+			value: 'O',
+			matrix: newMatrix(t,
+				"XXXXXXXXXX",
+				"..........",
+				"..........",
+			),
+			target: func(t *tetris.Tetrimino, matrix tetris.Matrix) {
+				t.Position.X, t.Position.Y = 4, len(matrix)-2
+			},
+			wantErr: ErrUnreachable,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			tet := spawn(t, tc.value)
+			target := tet.DeepCopy()
+			tc.target(target, tc.matrix)
+			require.True(t, target.IsValid(tc.matrix, true))
+			hardDrop(target, tc.matrix)
+
+			path, err := Path(tet, target, tc.matrix)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tc.wantLen > 0 {
+				assert.Len(t, path, tc.wantLen)
+			}
+			assert.Contains(t, path, tc.wantInput)
+			assert.Equal(t, InputHardDrop, path[len(path)-1])
+
+			// Following the path reaches the target.
+			for _, input := range path[:len(path)-1] {
+				switch input {
+				case InputLeft:
+					tet.MoveLeft(tc.matrix)
+				case InputRight:
+					tet.MoveRight(tc.matrix)
+				case InputRotateClockwise:
+					require.NoError(t, tet.Rotate(tc.matrix, true))
+				case InputRotateCounterClockwise:
+					require.NoError(t, tet.Rotate(tc.matrix, false))
+				case InputSoftDrop:
+					tet.MoveDown(tc.matrix)
+				}
+			}
+			hardDrop(tet, tc.matrix)
+			assert.Equal(t, minos(target), minos(tet))
+		})
+	}
+}
+
+func TestReachable(t *testing.T) {
+	matrix := newMatrix(t,
+		"XXXXXX....",
+		"..........",
+		"..........",
+	)
+
+	// Every placement found by moving, rotating and soft dropping can be reached, including the tucks.
+	reachable := Reachable(spawn(t, 'O'), matrix)
+	assert.Len(t, reachable, len(Placements(spawn(t, 'O'), matrix)))
+	for _, move := range reachable {
+		path, err := Path(spawn(t, 'O'), move.Placement, matrix)
+		require.NoError(t, err)
+		assert.Equal(t, move.Inputs, path)
+	}
+}
+
+func TestPlay_Path(t *testing.T) {
+	matrix := newMatrix(t,
+		"XXXXXX....",
+		"..........",
+		"..........",
+	)
+	game, err := single.NewGame(&single.Input{Level: 1, Sequence: []byte("OO"), Matrix: matrix})
+	require.NoError(t, err)
+
+	// A move without inputs is played by the path to its placement, which tucks the O under the overhang.
+	target := game.GetTetInPlay()
+	target.Position.X, target.Position.Y = 4, len(matrix)-2
+	_, err = Play(game, Move{Placement: target})
+	require.NoError(t, err)
+	assert.Equal(t, minos(target), minos(game.GetLastPlacement()))
+}
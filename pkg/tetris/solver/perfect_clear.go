@@ -0,0 +1,297 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+)
+
+var (
+	// ErrNoPerfectClear is returned when no perfect clear can be made with the known Tetriminos.
+	ErrNoPerfectClear = errors.New("no perfect clear is possible")
+	// ErrNotEnoughTetriminos is returned when no perfect clear can be made with the known Tetriminos, but one could be
+	// made with more of them.
+	ErrNotEnoughTetriminos = errors.New("not enough tetriminos are known to perfect clear")
+	// ErrSearchLimit is returned when the search gave up before finding a perfect clear or ruling one out.
+	ErrSearchLimit = errors.New("search limit reached")
+)
+
+// PerfectClearSearch is a search for a perfect clear. It is configured with options passed to FindPerfectClear.
+type PerfectClearSearch struct {
+	maxHeight int // The most rows the stack may reach.
+	maxNodes  int // The most placements to try before giving up.
+
+	ctx    context.Context
+	queue  []tetris.Tetrimino
+	nodes  int
+	failed map[string]bool // The keys of states which are known not to lead to a perfect clear.
+}
+
+// WithMaxHeight sets the most rows the stack may reach in a perfect clear. The default is 4.
+func WithMaxHeight(rows int) func(*PerfectClearSearch) {
+	return func(s *PerfectClearSearch) {
+		s.maxHeight = rows
+	}
+}
+
+// WithMaxNodes sets the most placements to try before giving up with ErrSearchLimit. The default is 200,000.
+func WithMaxNodes(nodes int) func(*PerfectClearSearch) {
+	return func(s *PerfectClearSearch) {
+		s.maxNodes = nodes
+	}
+}
+
+// FindPerfectClear searches for moves which leave the Matrix empty, using the Tetrimino in play, the held Tetrimino
+// and the Next Queue of the position. The stack is kept within the bottom rows of the Matrix, which must already
+// contain every mino (see WithMaxHeight), and every empty region of them must be fillable by Tetriminos.
+//
+// The search is bounded by the number of placements it tries (see WithMaxNodes) and by the context. If no perfect
+// clear is possible with the known Tetriminos ErrNoPerfectClear is returned, or ErrNotEnoughTetriminos if one might
+// be possible with more of them. If the search gave up ErrSearchLimit or the error of the context is returned.
+func FindPerfectClear(ctx context.Context, pos *Position, opts ...func(*PerfectClearSearch)) ([]Move, error) {
+	s := &PerfectClearSearch{
+		maxHeight: 4,
+		maxNodes:  200_000,
+		ctx:       ctx,
+		queue:     pos.Queue,
+		failed:    make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	filled := 0
+	for _, row := range pos.Matrix {
+		for _, cell := range row {
+			if !isEmpty(cell) {
+				filled++
+			}
+		}
+	}
+	pieces := 1 + len(pos.Queue)
+	if pos.Hold != nil {
+		pieces++
+	}
+
+	// The search is tried at each height the stack could be cleared from, lowest first.
+	width := len(pos.Matrix[0])
+	limited, tooFew := false, false
+	for height := max(len(pos.Matrix)-highestRow(pos.Matrix), 1); height <= s.maxHeight; height++ {
+		empty := height*width - filled
+		if empty%4 != 0 {
+			continue
+		}
+		if empty/4 > pieces {
+			tooFew = true
+			continue
+		}
+
+		moves, err := s.search(pos.Matrix, height, pos.Current, pos.Hold, pos.CanHold, 0)
+		switch {
+		case err == nil:
+			return moves, nil
+		case errors.Is(err, ErrSearchLimit):
+			limited = true
+		case !errors.Is(err, ErrNoPerfectClear):
+			return nil, err
+		}
+	}
+	switch {
+	case limited:
+		return nil, ErrSearchLimit
+	case tooFew:
+		return nil, ErrNotEnoughTetriminos
+	}
+	return nil, ErrNoPerfectClear
+}
+
+// CanPerfectClear returns false if the Matrix cannot be perfect cleared without the stack going above the bottom rows
+// of the given height. This is the case if there is a mino above them, or an empty region of them is not a multiple
+// of 4 cells (so Tetriminos cannot fill it).
+func CanPerfectClear(matrix tetris.Matrix, height int) bool {
+	if len(matrix)-highestRow(matrix) > height {
+		return false
+	}
+
+	top := len(matrix) - height
+	visited := make([][]bool, height)
+	for row := range visited {
+		visited[row] = make([]bool, len(matrix[0]))
+	}
+	for row := top; row < len(matrix); row++ {
+		for col := range matrix[row] {
+			if isEmpty(matrix[row][col]) && !visited[row-top][col] {
+				if floodFill(matrix, visited, top, row, col)%4 != 0 {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// emptyCells returns the number of empty cells in the bottom rows of the Matrix of the given height.
+func emptyCells(matrix tetris.Matrix, height int) int {
+	count := 0
+	for _, row := range matrix[len(matrix)-height:] {
+		for _, cell := range row {
+			if isEmpty(cell) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// floodFill marks the empty cells connected to the given cell, within the rows from top down, and returns how many
+// there are.
+func floodFill(matrix tetris.Matrix, visited [][]bool, top, row, col int) int {
+	if row < top || row >= len(matrix) || col < 0 || col >= len(matrix[row]) {
+		return 0
+	}
+	if visited[row-top][col] || !isEmpty(matrix[row][col]) {
+		return 0
+	}
+	visited[row-top][col] = true
+	return 1 +
+		floodFill(matrix, visited, top, row-1, col) +
+		floodFill(matrix, visited, top, row+1, col) +
+		floodFill(matrix, visited, top, row, col-1) +
+		floodFill(matrix, visited, top, row, col+1)
+}
+
+// search returns moves which perfect clear the Matrix within the bottom rows of the given height, starting with the
+// Tetrimino in play. Next is the index of the Next Queue to draw from.
+//
+// Once the Next Queue runs out the Tetrimino in play is unknown (nil), but it can still be held to place the held
+// Tetrimino.
+func (s *PerfectClearSearch) search(
+	matrix tetris.Matrix, height int, current, hold *tetris.Tetrimino, canHold bool, next int,
+) ([]Move, error) {
+	if current == nil && hold == nil {
+		return nil, ErrNoPerfectClear
+	}
+	key := s.stateKey(matrix, height, current, hold, canHold, next)
+	if s.failed[key] {
+		return nil, ErrNoPerfectClear
+	}
+
+	type option struct {
+		tet    *tetris.Tetrimino
+		isHold bool
+		hold   *tetris.Tetrimino
+		next   int
+	}
+	var options []option
+	if current != nil {
+		options = append(options, option{tet: current, hold: hold, next: next})
+	}
+	switch {
+	case !canHold:
+	case current == nil:
+		options = append(options, option{tet: hold, isHold: true, next: next})
+	case hold != nil && hold.Value != current.Value:
+		options = append(options, option{tet: hold, isHold: true, hold: current, next: next})
+	case hold == nil && next < len(s.queue):
+		options = append(options, option{tet: &s.queue[next], isHold: true, hold: current, next: next + 1})
+	}
+
+	limited := false
+	for _, opt := range options {
+		for _, placement := range Placements(opt.tet, matrix) {
+			moves, err := s.tryPlacement(matrix, height, placement, opt.hold, opt.next)
+			switch {
+			case err == nil:
+				return append([]Move{{Hold: opt.isHold, Placement: placement}}, moves...), nil
+			case errors.Is(err, ErrSearchLimit):
+				limited = true
+			case !errors.Is(err, ErrNoPerfectClear):
+				return nil, err
+			}
+		}
+	}
+	if limited {
+		return nil, ErrSearchLimit
+	}
+	s.failed[key] = true
+	return nil, ErrNoPerfectClear
+}
+
+// tryPlacement locks down the placement and searches for moves which perfect clear the rest of the Matrix.
+// If the placement is the last needed, no moves are returned.
+func (s *PerfectClearSearch) tryPlacement(
+	matrix tetris.Matrix, height int, placement, hold *tetris.Tetrimino, next int,
+) ([]Move, error) {
+	if minos(placement)[0].Y < len(matrix)-height {
+		return nil, ErrNoPerfectClear
+	}
+
+	s.nodes++
+	if s.nodes > s.maxNodes {
+		return nil, ErrSearchLimit
+	}
+	if s.nodes%1000 == 0 {
+		if err := s.ctx.Err(); err != nil {
+			return nil, fmt.Errorf("searching for perfect clear: %w", err)
+		}
+	}
+
+	locked, completed, err := lock(matrix, placement)
+	if err != nil {
+		return nil, fmt.Errorf("locking down placement: %w", err)
+	}
+	if locked.IsEmpty() {
+		return nil, nil
+	}
+	height -= len(completed)
+	if !CanPerfectClear(locked, height) {
+		return nil, ErrNoPerfectClear
+	}
+
+	// Every empty cell must be filled by the Tetriminos which are left.
+	pieces := len(s.queue) - next
+	if hold != nil {
+		pieces++
+	}
+	if emptyCells(locked, height) > pieces*4 {
+		return nil, ErrNoPerfectClear
+	}
+
+	var current *tetris.Tetrimino
+	if next < len(s.queue) {
+		current = &s.queue[next]
+	}
+	return s.search(locked, height, current, hold, true, next+1)
+}
+
+// stateKey returns a key which is the same for states of the search with the same outcome.
+func (s *PerfectClearSearch) stateKey(
+	matrix tetris.Matrix, height int, current, hold *tetris.Tetrimino, canHold bool, next int,
+) string {
+	var b strings.Builder
+	for _, row := range matrix[len(matrix)-height:] {
+		for _, cell := range row {
+			if isEmpty(cell) {
+				b.WriteByte('.')
+			} else {
+				b.WriteByte('#')
+			}
+		}
+	}
+	if current != nil {
+		b.WriteByte(current.Value)
+	}
+	b.WriteByte('/')
+	if hold != nil {
+		b.WriteByte(hold.Value)
+	}
+	if canHold {
+		b.WriteByte('+')
+	}
+	b.WriteString(strconv.Itoa(next))
+	return b.String()
+}
@@ -0,0 +1,169 @@
+package solver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+)
+
+func TestFindPerfectClear(t *testing.T) {
+	tt := map[string]struct {
+		matrix    tetris.Matrix
+		current   byte
+		hold      byte
+		canHold   bool
+		queue     string
+		opts      []func(*PerfectClearSearch)
+		wantHolds []bool
+		wantErr   error
+	}{
+		"one move": {
+			matrix:    newMatrix(t, "XXXXXX...."),
+			current:   'I',
+			wantHolds: []bool{false},
+		},
+		"hold for the next tetrimino": {
+			matrix:    newMatrix(t, "..XXXXXXXX", "..XXXXXXXX"),
+			current:   'I',
+			canHold:   true,
+			queue:     "O",
+			wantHolds: []bool{true},
+		},
+		"swap with the held tetrimino": {
+			matrix:    newMatrix(t, "..XXXXXXXX", "..XXXXXXXX"),
+			current:   'I',
+			hold:      'O',
+			canHold:   true,
+			wantHolds: []bool{true},
+		},
+		"cannot hold": {
+			matrix:  newMatrix(t, "..XXXXXXXX", "..XXXXXXXX"),
+			current: 'I',
+			hold:    'O',
+			opts:    []func(*PerfectClearSearch){WithMaxHeight(2)},
+			wantErr: ErrNoPerfectClear,
+		},
+		"clear lines with each tetrimino": {
+			matrix:    newMatrix(t, "XXXXXXXX..", "XXXXXXXX..", "XXXXXX...."),
+			current:   'O',
+			queue:     "I",
+			canHold:   true,
+			wantHolds: []bool{true, true},
+		},
+		"empty region cannot be filled": {
+			matrix:  newMatrix(t, "XX..XXXX..", "XXXXXXXXXX"),
+			current: 'O',
+			queue:   "O",
+			opts:    []func(*PerfectClearSearch){WithMaxHeight(2)},
+			wantErr: ErrNoPerfectClear,
+		},
+		"too few tetriminos": {
+			matrix:  newMatrix(t),
+			current: 'I',
+			queue:   "OT",
+			wantErr: ErrNotEnoughTetriminos,
+		},
+		"empty matrix": {
+			matrix:    newMatrix(t),
+			current:   'I',
+			queue:     "OLJSZTIOLJ",
+			canHold:   true,
+			wantHolds: []bool{false, false, false, false, false, false, true, true, false, false},
+		},
+		"search limit": {
+			matrix:  newMatrix(t),
+			current: 'I',
+			queue:   "OLJSZTIOLJ",
+			canHold: true,
+			opts:    []func(*PerfectClearSearch){WithMaxNodes(10)},
+			wantErr: ErrSearchLimit,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			pos := &Position{
+				Matrix:  tc.matrix,
+				Current: spawn(t, tc.current),
+				CanHold: tc.canHold,
+				Queue:   queue(t, tc.queue),
+			}
+			if tc.hold != 0 {
+				pos.Hold = spawn(t, tc.hold)
+			}
+
+			moves, err := FindPerfectClear(context.Background(), pos, tc.opts...)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var holds []bool
+			for _, m := range moves {
+				holds = append(holds, m.Hold)
+			}
+			assert.Equal(t, tc.wantHolds, holds)
+
+			// Overlaying the moves fills every row of the stack.
+			overlay, err := Overlay(tc.matrix, moves)
+			require.NoError(t, err)
+			for row := highestRow(overlay); row < len(overlay); row++ {
+				assert.True(t, isRowFull(overlay[row]), "row %d is not full", row)
+			}
+		})
+	}
+}
+
+func TestFindPerfectClear_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	pos := &Position{Matrix: newMatrix(t), Current: spawn(t, 'S'), Queue: queue(t, "ZSZSZSZSZSZ")}
+	_, err := FindPerfectClear(ctx, pos)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCanPerfectClear(t *testing.T) {
+	tt := map[string]struct {
+		matrix tetris.Matrix
+		height int
+		want   bool
+	}{
+		"empty": {
+			matrix: newMatrix(t),
+			height: 4,
+			want:   true,
+		},
+		"regions of 4 cells": {
+			matrix: newMatrix(t, "XX..XXXX..", "XX..XXXX.."),
+			height: 2,
+			want:   true,
+		},
+		"region of 2 cells": {
+			matrix: newMatrix(t, "XX..XXXX..", "XXXXXXXX.."),
+			height: 2,
+			want:   false,
+		},
+		"regions are joined above the stack": {
+			matrix: newMatrix(t, "XX..XXXX..", "XXXXXXXX.."),
+			height: 3,
+			want:   true,
+		},
+		"mino above the height": {
+			matrix: newMatrix(t, "X.........", "XXXXXX...."),
+			height: 1,
+			want:   false,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, CanPerfectClear(tc.matrix, tc.height))
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+)
+
+// Solver chooses the next move of a game.
+type Solver interface {
+	// Solve returns the move to make from the position. The search is bounded by the context: once it is done the best
+	// move found so far is returned. If no Tetrimino can be placed ErrNoMoves is returned.
+	Solve(ctx context.Context, pos *Position) (Move, error)
+}
+
+// bagValues are the values of the Tetriminos in a 7-bag, in a fixed order so that sampling from it is deterministic.
+var bagValues = []byte{'I', 'J', 'L', 'O', 'S', 'T', 'Z'}
+
+// successor is a move from a position, with the score the evaluator gave it and the position it leads to.
+type successor struct {
+	move  Move
+	score float64
+	next  *Position
+	over  bool // Whether the move ends the game.
+}
+
+// scorer scores placements with an evaluator, caching the scores in a transposition table if there is one.
+type scorer struct {
+	evaluator eval.Evaluator
+	table     *TranspositionTable
+}
+
+// successors returns the moves from the position (see moves) and the positions they lead to. Moves which end the game
+// are left out, unless every move does.
+func (sc *scorer) successors(pos *Position) ([]successor, error) {
+	var alive, over []successor
+	hash := hashMatrix(pos.Matrix)
+	for _, move := range moves(pos) {
+		s, err := sc.successor(pos, hash, move)
+		if err != nil {
+			return nil, err
+		}
+		if s.over {
+			over = append(over, s)
+		} else {
+			alive = append(alive, s)
+		}
+	}
+	if len(alive) == 0 {
+		return over, nil
+	}
+	return alive, nil
+}
+
+// successor makes the move from the position, whose Matrix has the given hash (see hashMatrix).
+func (sc *scorer) successor(pos *Position, hash uint64, move Move) (successor, error) {
+	matrix, _, err := lock(pos.Matrix, move.Placement)
+	if err != nil {
+		return successor{}, fmt.Errorf("locking down placement: %w", err)
+	}
+
+	key := hash ^ hashPlacement(move.Placement)
+	score, ok := 0.0, false
+	if sc.table != nil {
+		score, ok = sc.table.Get(key)
+	}
+	if !ok {
+		score, _, err = sc.evaluator.Evaluate(pos.Matrix, move.Placement)
+		if err != nil {
+			return successor{}, fmt.Errorf("evaluating placement: %w", err)
+		}
+		if sc.table != nil {
+			sc.table.Put(key, score)
+		}
+	}
+
+	next, alive := pos.after(move, matrix)
+	return successor{move: move, score: score, next: next, over: !alive}, nil
+}
+
+// moves returns the moves from the position: the drops (see Drops) of the Tetrimino in play, and of the Tetrimino
+// holding would swap in if it can be held.
+func moves(pos *Position) []Move {
+	var all []Move
+	for _, c := range candidates(pos) {
+		if c.hold && pos.Current != nil && pos.spawn(pos.Current.Value) == nil {
+			// It is not known where to put the Tetrimino in play back to, so holding it cannot be searched.
+			continue
+		}
+		for _, move := range Drops(c.tet, pos.Matrix) {
+			move.Hold = c.hold
+			all = append(all, move)
+		}
+	}
+	return all
+}
+
+// parallel calls fn with each index below n, on at most the given number of goroutines at once. The first error is
+// returned, after which the remaining indices are skipped.
+func parallel(workers, n int, fn func(i int) error) error {
+	if workers <= 1 || n <= 1 {
+		for i := range n {
+			if err := fn(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for range min(workers, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				if err := fn(i); err != nil {
+					once.Do(func() {
+						firstErr = err
+						next.Store(int64(n))
+					})
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// after returns the position once the move has been made, given the Matrix after its placement locked down. The next
+// Tetrimino enters play from the queue. False is returned if it cannot enter the Matrix, which ends the game.
+func (p *Position) after(move Move, matrix tetris.Matrix) (*Position, bool) {
+	next := &Position{
+		Matrix:  matrix,
+		Hold:    p.Hold,
+		CanHold: true,
+		Queue:   p.Queue,
+		Bag:     p.Bag,
+		spawns:  p.spawnSources(),
+	}
+	if move.Hold {
+		next.Hold = nil
+		if p.Current != nil {
+			next.Hold = p.spawn(p.Current.Value)
+		}
+		if p.Hold == nil && len(next.Queue) > 0 {
+			// The first hold draws the Tetrimino which was placed from the queue.
+			next.Queue = next.Queue[1:]
+		}
+	}
+
+	if len(next.Queue) == 0 {
+		return next, true
+	}
+	next.Current = entered(&next.Queue[0], matrix)
+	next.Queue = next.Queue[1:]
+	return next, next.Current.IsValid(matrix, false)
+}
+
+// hash returns a Zobrist hash of the position, which is the same for positions which can be searched the same way: the
+// minos of the Matrix, the Tetriminos in play and held, whether it can be held, and the length of the queue.
+func (p *Position) hash() uint64 {
+	h := hashMatrix(p.Matrix)
+	var pieces uint64
+	if p.Current != nil {
+		pieces |= uint64(p.Current.Value)
+	}
+	if p.Hold != nil {
+		pieces |= uint64(p.Hold.Value) << 8
+	}
+	if p.CanHold {
+		pieces |= 1 << 16
+	}
+	//nolint:gosec // The length of the queue is non-negative.
+	pieces |= uint64(len(p.Queue)) << 17
+	return h ^ zobristKey(zobristPieces, 0, int(pieces))
+}
+
+// spawn returns the Tetrimino with the given value where it spawns. A Tetrimino which has not been seen is assumed to
+// spawn where the SRS Tetrimino would, offset like the Tetriminos which have been (eg. by the buffer zone). Nil is
+// returned if none have been seen.
+func (p *Position) spawn(value byte) *tetris.Tetrimino {
+	sources := p.spawnSources()
+	for _, t := range sources {
+		if t.Value == value {
+			return &t
+		}
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+
+	seen, err := tetris.GetTetrimino(sources[0].Value)
+	if err != nil {
+		return nil
+	}
+	t, err := tetris.GetTetrimino(value)
+	if err != nil {
+		return nil
+	}
+	t.Position.X += sources[0].Position.X - seen.Position.X
+	t.Position.Y += sources[0].Position.Y - seen.Position.Y
+	return t
+}
+
+// spawnSources returns the Tetriminos which show where each Tetrimino spawns: those in the queue and hold of the
+// position the search started from.
+func (p *Position) spawnSources() []tetris.Tetrimino {
+	if p.spawns != nil {
+		return p.spawns
+	}
+	sources := append([]tetris.Tetrimino{}, p.Queue...)
+	if p.Hold != nil {
+		sources = append(sources, *p.Hold)
+	}
+	return sources
+}
+
+// sampleQueue returns a copy of the position with the given number of Tetriminos added to the end of its queue. They
+// are drawn at random from the rest of the current 7-bag and then from new bags, like the Next Queue. None are added
+// if it is not known where they spawn (see spawn).
+func (p *Position) sampleQueue(r *rand.Rand, count int) *Position {
+	sampled := *p
+	sampled.spawns = p.spawnSources()
+	sampled.Queue = append(make([]tetris.Tetrimino, 0, len(p.Queue)+count), p.Queue...)
+
+	bag := append([]byte{}, p.Bag...)
+	for range count {
+		if len(bag) == 0 {
+			bag = append(bag, bagValues...)
+		}
+		i := r.IntN(len(bag))
+		t := p.spawn(bag[i])
+		if t == nil {
+			break
+		}
+		sampled.Queue = append(sampled.Queue, *t)
+		bag = append(bag[:i], bag[i+1:]...)
+	}
+	sampled.Bag = nil
+	return &sampled
+}
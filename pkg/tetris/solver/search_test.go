@@ -0,0 +1,144 @@
+package solver
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/eval"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+)
+
+func TestPosition_After(t *testing.T) {
+	tt := map[string]struct {
+		pos       *Position
+		hold      bool
+		place     byte
+		wantNext  byte
+		wantHold  byte
+		wantQueue int
+	}{
+		"no hold": {
+			pos:       &Position{Current: spawn(t, 'T'), Hold: spawn(t, 'S'), Queue: queue(t, "IO")},
+			place:     'T',
+			wantNext:  'I',
+			wantHold:  'S',
+			wantQueue: 1,
+		},
+		"swap with the held tetrimino": {
+			pos:       &Position{Current: spawn(t, 'T'), Hold: spawn(t, 'S'), CanHold: true, Queue: queue(t, "IO")},
+			hold:      true,
+			place:     'S',
+			wantNext:  'I',
+			wantHold:  'T',
+			wantQueue: 1,
+		},
+		"first hold draws from the queue": {
+			pos:       &Position{Current: spawn(t, 'T'), CanHold: true, Queue: queue(t, "IOT")},
+			hold:      true,
+			place:     'I',
+			wantNext:  'O',
+			wantHold:  'T',
+			wantQueue: 1,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			matrix := newMatrix(t)
+			tc.pos.Matrix = matrix
+			placement := spawn(t, tc.place)
+			hardDrop(placement, matrix)
+			locked, _, err := lock(matrix, placement)
+			require.NoError(t, err)
+
+			next, ok := tc.pos.after(Move{Hold: tc.hold, Placement: placement}, locked)
+			require.True(t, ok)
+			assert.Equal(t, tc.wantNext, next.Current.Value)
+			require.NotNil(t, next.Hold)
+			assert.Equal(t, tc.wantHold, next.Hold.Value)
+			assert.True(t, next.CanHold)
+			assert.Len(t, next.Queue, tc.wantQueue)
+
+			// The held Tetrimino is put back where it spawns.
+			assert.Equal(t, spawn(t, tc.wantHold).Position, next.Hold.Position)
+			assert.Equal(t, 0, next.Hold.CompassDirection)
+		})
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	// The cells where the I spawns are filled, so holding for it would end the game.
+	blocked := newMatrix(t)
+	for _, c := range minos(spawn(t, 'I')) {
+		blocked[c.Y][c.X] = 'X'
+	}
+
+	tt := map[string]struct {
+		pos  *Position
+		want []byte
+	}{
+		"cannot hold": {
+			pos:  &Position{Current: spawn(t, 'T'), Hold: spawn(t, 'S'), Queue: queue(t, "I")},
+			want: []byte("T"),
+		},
+		"held tetrimino": {
+			pos:  &Position{Current: spawn(t, 'T'), Hold: spawn(t, 'S'), CanHold: true, Queue: queue(t, "I")},
+			want: []byte("TS"),
+		},
+		"next in the queue": {
+			pos:  &Position{Current: spawn(t, 'T'), CanHold: true, Queue: queue(t, "I")},
+			want: []byte("TI"),
+		},
+		"holding swaps in the same tetrimino": {
+			pos:  &Position{Current: spawn(t, 'T'), Hold: spawn(t, 'T'), CanHold: true, Queue: queue(t, "I")},
+			want: []byte("T"),
+		},
+		"nothing to swap in": {
+			pos:  &Position{Current: spawn(t, 'T'), CanHold: true},
+			want: []byte("T"),
+		},
+		"swapped in tetrimino is blocked": {
+			pos:  &Position{Matrix: blocked, Current: spawn(t, 'T'), CanHold: true, Queue: queue(t, "I")},
+			want: []byte("T"),
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			if tc.pos.Matrix == nil {
+				tc.pos.Matrix = newMatrix(t)
+			}
+			var got []byte
+			for i, c := range candidates(tc.pos) {
+				got = append(got, c.tet.Value)
+				assert.Equal(t, i > 0, c.hold)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBeamSearch_Plan(t *testing.T) {
+	game, err := single.NewGame(&single.Input{Level: 1, Rand: rand.New(rand.NewPCG(3, 3))})
+	require.NoError(t, err)
+
+	// The plan can be played, holding when it says to, while the Tetriminos are the ones which were known.
+	pos := NewPosition(game)
+	plan, err := NewBeamSearch(eval.ElTetris(), WithBeamWidth(4)).Plan(context.Background(), pos)
+	require.NoError(t, err)
+	require.Len(t, plan, 1+len(pos.Queue))
+
+	holds := 0
+	for i, move := range plan {
+		if move.Hold {
+			holds++
+		}
+		_, err = Play(game, move)
+		require.NoError(t, err, "move %d", i+1)
+	}
+	assert.Positive(t, holds)
+}
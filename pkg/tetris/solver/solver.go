@@ -0,0 +1,186 @@
+// Package solver searches for where to place the Tetriminos of a game.
+package solver
+
+import (
+	"fmt"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+)
+
+// Move is where to place a Tetrimino, and whether the Tetrimino in play must be held to get it.
+type Move struct {
+	Hold      bool              // Whether to hold the Tetrimino in play before moving it.
+	Placement *tetris.Tetrimino // The Tetrimino where it locks down.
+	Inputs    []Input           // The inputs which move the Tetrimino to the placement and lock it down, if known.
+}
+
+// Position is the state of a game to search from.
+type Position struct {
+	Matrix  tetris.Matrix      // The whole Matrix, without the Tetrimino in play.
+	Current *tetris.Tetrimino  // The Tetrimino in play.
+	Hold    *tetris.Tetrimino  // The held Tetrimino. Nil means the hold slot is empty.
+	CanHold bool               // Whether the Tetrimino in play can be held.
+	Queue   []tetris.Tetrimino // The upcoming Tetriminos, positioned where they spawn in the Matrix.
+	Bag     []byte             // The values of the Tetriminos left in the current 7-bag after the queue, in any order.
+
+	spawns []tetris.Tetrimino // The Tetriminos where they spawn, for putting held Tetriminos back. Nil means Queue.
+}
+
+// NewPosition returns the position of the given game. During an entry delay the next Tetrimino is treated as the one
+// in play. The Next Queue is filled a whole bag at a time, so its last Tetrimino ends a bag and Bag is empty.
+func NewPosition(g *single.Game) *Position {
+	dims := g.GetDimensions()
+	queue := make([]tetris.Tetrimino, 0, len(g.GetBagTetriminos()))
+	for _, t := range g.GetBagTetriminos() {
+		t.Position.Y += dims.Buffer
+		t.Position.X = tetris.SpawnColumn(t.Position.X, dims.Width)
+		queue = append(queue, t)
+	}
+
+	pos := &Position{
+		Matrix:  g.GetMatrix(),
+		Current: g.GetTetInPlay(),
+		CanHold: g.CanHold(),
+		Queue:   queue,
+	}
+	if g.IsInEntryDelay() && len(queue) > 0 {
+		pos.Current, pos.Queue, pos.CanHold = &queue[0], queue[1:], true
+	}
+	if hold := g.GetHoldTetrimino(); hold.Value != 0 {
+		pos.Hold = hold.DeepCopy()
+	}
+	return pos
+}
+
+// Placements returns every distinct placement the Tetrimino can lock down in, by moving, rotating and soft dropping it
+// from where it is. Placements are distinct if they fill different cells, so orientations of the I, S, Z and O
+// Tetriminos which fill the same cells are the same placement.
+//
+// Every column and orientation can be reached above the highest mino, so the Tetrimino is first dropped to there.
+func Placements(tet *tetris.Tetrimino, matrix tetris.Matrix) []*tetris.Tetrimino {
+	// Moving and rotating a Tetrimino replaces its cells rather than modifying them, so shallow copies are safe.
+	start := *tet
+	top := highestRow(matrix)
+	for start.Position.Y+lowestCellRow(&start) < top-1 {
+		if !start.MoveDown(matrix) {
+			break
+		}
+	}
+
+	var placements []*tetris.Tetrimino
+	seen := make(map[[4]tetris.Coordinate]bool)
+	searchPaths(&start, matrix, func(landed *tetris.Tetrimino, _ func() []Input) bool {
+		if key := minos(landed); !seen[key] {
+			seen[key] = true
+			placements = append(placements, landed.DeepCopy())
+		}
+		return false
+	})
+	return placements
+}
+
+// lock returns a copy of the Matrix with the Tetrimino locked down in it and completed lines removed, and the rows of
+// the Matrix which were completed.
+func lock(matrix tetris.Matrix, tet *tetris.Tetrimino) (tetris.Matrix, []int, error) {
+	locked := *matrix.DeepCopy()
+	if err := locked.AddTetrimino(tet); err != nil {
+		return nil, nil, err
+	}
+
+	var completed []int
+	for row := range tet.Cells {
+		if isRowFull(locked[tet.Position.Y+row]) {
+			completed = append(completed, tet.Position.Y+row)
+		}
+	}
+	locked.RemoveCompletedLines(tet)
+	return locked, completed, nil
+}
+
+// minos returns the cells of the Matrix filled by the Tetrimino, ordered by row then column.
+func minos(t *tetris.Tetrimino) [4]tetris.Coordinate {
+	var cells [4]tetris.Coordinate
+	i := 0
+	for row := range t.Cells {
+		for col := range t.Cells[row] {
+			if t.Cells[row][col] && i < len(cells) {
+				cells[i] = tetris.Coordinate{X: t.Position.X + col, Y: t.Position.Y + row}
+				i++
+			}
+		}
+	}
+	return cells
+}
+
+// lowestCellRow returns the lowest row of the Tetrimino's cells which has a mino.
+func lowestCellRow(t *tetris.Tetrimino) int {
+	for row := len(t.Cells) - 1; row >= 0; row-- {
+		for _, filled := range t.Cells[row] {
+			if filled {
+				return row
+			}
+		}
+	}
+	return 0
+}
+
+// highestRow returns the highest row of the Matrix with a mino, or the height of the Matrix if it is empty.
+func highestRow(matrix tetris.Matrix) int {
+	for row := range matrix {
+		for _, cell := range matrix[row] {
+			if !isEmpty(cell) {
+				return row
+			}
+		}
+	}
+	return len(matrix)
+}
+
+func isRowFull(row []byte) bool {
+	for _, cell := range row {
+		if isEmpty(cell) {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmpty returns true if the cell has no mino. Ghost cells are empty.
+func isEmpty(cell byte) bool {
+	return cell == 0 || cell == 'G'
+}
+
+// Overlay returns a copy of the Matrix with the placements of the moves added where they would be if no lines were
+// cleared, so that moves which clear lines (eg. a perfect clear) can be shown in a single Matrix.
+func Overlay(matrix tetris.Matrix, moves []Move) (tetris.Matrix, error) {
+	overlay := *matrix.DeepCopy()
+	current := *matrix.DeepCopy()
+
+	// rows maps each row of the current Matrix to its row in the overlay, or -1 if it is above the overlay.
+	rows := make([]int, len(matrix))
+	for i := range rows {
+		rows[i] = i
+	}
+
+	for i, move := range moves {
+		for _, mino := range minos(move.Placement) {
+			row := rows[mino.Y]
+			if row < 0 || !isEmpty(overlay[row][mino.X]) {
+				return nil, fmt.Errorf("move %d cannot be overlaid on the matrix", i+1)
+			}
+			overlay[row][mino.X] = move.Placement.Value
+		}
+
+		var completed []int
+		var err error
+		current, completed, err = lock(current, move.Placement)
+		if err != nil {
+			return nil, fmt.Errorf("locking down move %d: %w", i+1, err)
+		}
+		for _, row := range completed {
+			rows = append(append([]int{-1}, rows[:row]...), rows[row+1:]...)
+		}
+	}
+	return overlay, nil
+}
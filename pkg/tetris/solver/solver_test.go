@@ -0,0 +1,112 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris/modes/single"
+)
+
+func TestPlacements(t *testing.T) {
+	tt := map[string]struct {
+		value  byte
+		matrix tetris.Matrix
+		want   int
+	}{
+		"I has 7 flat and 10 upright placements": {
+			value:  'I',
+			matrix: newMatrix(t),
+			want:   17,
+		},
+		"O has 9 placements": {
+			value:  'O',
+			matrix: newMatrix(t),
+			want:   9,
+		},
+		"T has 8 flat and 9 upright placements in each of two orientations": {
+			value:  'T',
+			matrix: newMatrix(t),
+			want:   34,
+		},
+		"O can be tucked under an overhang": {
+			value: 'O',
+			matrix: newMatrix(t,
+				"XXXXXX....",
+				"..........",
+				"..........",
+			),
+			// 6 on top of the overhang, and 9 on the floor beside and under it.
+			want: 15,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			placements := Placements(spawn(t, tc.value), tc.matrix)
+			assert.Len(t, placements, tc.want)
+			for _, p := range placements {
+				assert.True(t, p.IsValid(tc.matrix, true))
+				assert.False(t, p.DeepCopy().MoveDown(tc.matrix))
+			}
+		})
+	}
+}
+
+func TestNewPosition(t *testing.T) {
+	game, err := single.NewGame(&single.Input{Level: 1, Sequence: []byte("TIO"), Hold: 'S'})
+	require.NoError(t, err)
+
+	pos := NewPosition(game)
+	assert.Equal(t, byte('T'), pos.Current.Value)
+	require.NotNil(t, pos.Hold)
+	assert.Equal(t, byte('S'), pos.Hold.Value)
+	assert.True(t, pos.CanHold)
+	require.Len(t, pos.Queue, 2)
+
+	// The Tetriminos of the queue are where they spawn in the Matrix.
+	_, err = game.HardDrop()
+	require.NoError(t, err)
+	assert.Equal(t, game.GetTetInPlay().Position.X, pos.Queue[0].Position.X)
+	assert.Equal(t, game.GetTetInPlay().Value, pos.Queue[0].Value)
+}
+
+// newMatrix returns a Matrix of the default dimensions with the given rows at the bottom.
+// Each row has a character per cell, where "." is empty.
+func newMatrix(t *testing.T, rows ...string) tetris.Matrix {
+	t.Helper()
+	matrix, err := tetris.NewMatrixWithDimensions(tetris.DefaultDimensions)
+	require.NoError(t, err)
+
+	offset := len(matrix) - len(rows)
+	for i, row := range rows {
+		require.Len(t, row, len(matrix[0]))
+		for col := range len(row) {
+			if row[col] != '.' {
+				matrix[offset+i][col] = row[col]
+			}
+		}
+	}
+	return matrix
+}
+
+// spawn returns the Tetrimino with the given value where it spawns in a Matrix of the default dimensions.
+func spawn(t *testing.T, value byte) *tetris.Tetrimino {
+	t.Helper()
+	tet, err := tetris.GetTetrimino(value)
+	require.NoError(t, err)
+	tet.Position.Y += tetris.DefaultDimensions.Buffer
+	return tet
+}
+
+// queue returns the Tetriminos with the given values where they spawn in a Matrix of the default dimensions.
+func queue(t *testing.T, values string) []tetris.Tetrimino {
+	t.Helper()
+	tets := make([]tetris.Tetrimino, 0, len(values))
+	for i := range len(values) {
+		tets = append(tets, *spawn(t, values[i]))
+	}
+	return tets
+}
@@ -0,0 +1,99 @@
+package solver
+
+import (
+	"math"
+	"math/bits"
+	"sync/atomic"
+
+	"github.com/Broderick-Westrope/tetrigo/pkg/tetris"
+)
+
+// ttEntrySize is the number of bytes of an entry of a TranspositionTable.
+const ttEntrySize = 16
+
+// TranspositionTable caches the scores of placements, keyed by the Zobrist hash of the Matrix and the placement, so
+// that placements reached by different orders of moves are only evaluated once. A table must only be used with one
+// evaluator. It is safe for concurrent use.
+//
+// Entries are replaced when their slot is needed. A score is stored with its key XORed in (Hyatt's lockless hashing),
+// so an entry which is read while it is being replaced is a miss rather than a wrong score.
+type TranspositionTable struct {
+	entries []ttEntry
+	mask    uint64
+}
+
+type ttEntry struct {
+	check atomic.Uint64 // The key XOR the score.
+	score atomic.Uint64 // The bits of the score.
+}
+
+// NewTranspositionTable creates a TranspositionTable which uses at most the given number of megabytes.
+func NewTranspositionTable(megabytes int) *TranspositionTable {
+	entries := max(megabytes, 1) << 20 / ttEntrySize
+	// The number of entries is rounded down to a power of two, so the slot of a key is a mask of it.
+	size := uint64(1) << (bits.Len(uint(entries)) - 1)
+	return &TranspositionTable{
+		entries: make([]ttEntry, size),
+		mask:    size - 1,
+	}
+}
+
+// Get returns the score stored for the key, and whether there is one.
+func (t *TranspositionTable) Get(key uint64) (float64, bool) {
+	e := &t.entries[key&t.mask]
+	score := e.score.Load()
+	if e.check.Load()^score != key {
+		return 0, false
+	}
+	return math.Float64frombits(score), true
+}
+
+// Put stores the score for the key, replacing the entry in its slot.
+func (t *TranspositionTable) Put(key uint64, score float64) {
+	e := &t.entries[key&t.mask]
+	bits := math.Float64bits(score)
+	e.score.Store(bits)
+	e.check.Store(key ^ bits)
+}
+
+// Zobrist keys are given for the minos of the Matrix and of the placement separately, since the score of a placement
+// depends on which minos are its own. The Tetriminos of a position are given a key of their own.
+const (
+	zobristMatrix = iota
+	zobristPlacement
+	zobristPieces
+)
+
+// zobristKey returns the Zobrist key of a mino of the kind in the cell. Keys are derived from the cell by the
+// SplitMix64 mixer rather than looked up in a table, so Matrices of any size can be hashed.
+func zobristKey(kind, row, col int) uint64 {
+	//nolint:gosec // Kinds, rows and columns are non-negative.
+	z := uint64(kind)<<48 | uint64(row)<<24 | uint64(col)
+	z += 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// hashMatrix returns the Zobrist hash of the minos of the Matrix.
+func hashMatrix(matrix tetris.Matrix) uint64 {
+	var h uint64
+	for row := range matrix {
+		for col, cell := range matrix[row] {
+			if !isEmpty(cell) {
+				h ^= zobristKey(zobristMatrix, row, col)
+			}
+		}
+	}
+	return h
+}
+
+// hashPlacement returns the Zobrist hash of the minos of the placement. XORing it with the hash of the Matrix it is
+// locked down in gives the key of its score.
+func hashPlacement(placement *tetris.Tetrimino) uint64 {
+	var h uint64
+	for _, mino := range minos(placement) {
+		h ^= zobristKey(zobristPlacement, mino.Y, mino.X)
+	}
+	return h
+}
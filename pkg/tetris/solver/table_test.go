@@ -0,0 +1,77 @@
+package solver
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTranspositionTable(t *testing.T) {
+	tt := map[string]struct {
+		megabytes int
+		want      int
+	}{
+		"1 MB": {
+			megabytes: 1,
+			want:      1 << 16,
+		},
+		"rounded down to a power of two": {
+			megabytes: 3,
+			want:      1 << 17,
+		},
+		"at least 1 MB": {
+			megabytes: 0,
+			want:      1 << 16,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			assert.Len(t, NewTranspositionTable(tc.megabytes).entries, tc.want)
+		})
+	}
+}
+
+func TestTranspositionTable_Concurrent(t *testing.T) {
+	table := NewTranspositionTable(1)
+
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 10_000 {
+				key := zobristKey(zobristMatrix, w, i)
+				table.Put(key, float64(i))
+				if score, ok := table.Get(key); ok {
+					assert.InDelta(t, float64(i), score, 0)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// A key which shares a slot with a stored key is a miss.
+	key := zobristKey(zobristMatrix, 0, 1)
+	table.Put(key, 1)
+	_, ok := table.Get(key + uint64(len(table.entries)))
+	assert.False(t, ok)
+}
+
+func TestHashPlacement(t *testing.T) {
+	matrix := newMatrix(t,
+		"..........",
+		"XXXX......",
+	)
+	placement := spawn(t, 'I')
+	hardDrop(placement, matrix)
+
+	locked, _, err := lock(matrix, placement)
+	require.NoError(t, err)
+
+	// The same minos give a different key when some are the placement's.
+	assert.NotEqual(t, hashMatrix(locked), hashMatrix(matrix)^hashPlacement(placement))
+	assert.Equal(t, hashMatrix(matrix)^hashPlacement(placement), hashMatrix(matrix)^hashPlacement(placement.DeepCopy()))
+}
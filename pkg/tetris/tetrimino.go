@@ -11,7 +11,7 @@ const invalidRotationPoint = -1
 
 // A Tetrimino is a geometric Tetris piece formed by connecting four square blocks (Minos) along their edges.
 // Each Tetrimino has a unique shape, position, rotation state, and rotation behavior defined by
-// the Super Rotation System (SRS).
+// a RotationSystem (the Super Rotation System (SRS) by default).
 type Tetrimino struct {
 	// Value is the character identifier for the Tetrimino (I, O, T, S, Z, J, L).
 	// This is used internally and may differ from the display representation.
@@ -28,9 +28,14 @@ type Tetrimino struct {
 	// CompassDirection tracks the current rotation state (0-3, representing North, East, South, West).
 	CompassDirection int
 
-	// RotationCompass defines the piece's rotation behavior according to the Super Rotation System (SRS).
+	// RotationCompass defines the piece's clockwise rotation behavior according to its RotationSystem.
 	// It contains offset data for each rotation state to handle various kicks.
 	RotationCompass RotationCompass
+
+	// CounterRotationCompass defines the piece's counter-clockwise rotation behavior, indexed by the
+	// rotation state being rotated out of. If it has no offsets for a rotation state then the offsets of
+	// RotationCompass are negated instead, as is the case in SRS.
+	CounterRotationCompass RotationCompass
 }
 
 // Coordinate represents a position in the game matrix using X (horizontal) and Y (vertical) coordinates.
@@ -51,9 +56,9 @@ type RotationCompass [4]RotationOffsets
 // This is a key component of the Super Rotation System (SRS).
 type RotationOffsets []*Coordinate
 
-// RotationCompasses maps each Tetrimino type to its rotation behavior definition.
-// The offsets are used to adjust piece position during rotation to implement kicks
-// according to the Super Rotation System (SRS).
+// RotationCompasses maps each Tetrimino type to its rotation behavior definition in the Super Rotation System (SRS).
+// The offsets are used to adjust piece position during rotation to implement kicks.
+// Other rotation systems provide their own compasses through RotationSystem.
 //
 // When rotating clockwise (right), the offsets are applied in order.
 // When rotating counter-clockwise (left), the offsets are applied in reverse order.
@@ -115,102 +120,52 @@ var startingPositions = map[byte]Coordinate{
 	'6': {X: 3, Y: -2},
 }
 
-func getMapOfValidTetriminos() map[byte]Tetrimino {
-	return map[byte]Tetrimino{
-		'I': {
-			Value: 'I',
-			Cells: [][]bool{
-				{true, true, true, true},
-			},
-			Position:        startingPositions['I'],
-			RotationCompass: RotationCompasses['I'],
-		},
-		'O': {
-			Value: 'O',
-			Cells: [][]bool{
-				{true, true},
-				{true, true},
-			},
-			Position:        startingPositions['O'],
-			RotationCompass: RotationCompasses['O'],
-		},
-		'T': {
-			Value: 'T',
-			Cells: [][]bool{
-				{false, true, false},
-				{true, true, true},
-			},
-			Position:        startingPositions['6'],
-			RotationCompass: RotationCompasses['6'],
-		},
-		'S': {
-			Value: 'S',
-			Cells: [][]bool{
-				{false, true, true},
-				{true, true, false},
-			},
-			Position:        startingPositions['6'],
-			RotationCompass: RotationCompasses['6'],
-		},
-		'Z': {
-			Value: 'Z',
-			Cells: [][]bool{
-				{true, true, false},
-				{false, true, true},
-			},
-			Position:        startingPositions['6'],
-			RotationCompass: RotationCompasses['6'],
-		},
-		'J': {
-			Value: 'J',
-			Cells: [][]bool{
-				{true, false, false},
-				{true, true, true},
-			},
-			Position:        startingPositions['6'],
-			RotationCompass: RotationCompasses['6'],
-		},
-		'L': {
-			Value: 'L',
-			Cells: [][]bool{
-				{false, false, true},
-				{true, true, true},
-			},
-			Position:        startingPositions['6'],
-			RotationCompass: RotationCompasses['6'],
-		},
-	}
+// GetValidTetriminos returns a slice containing all seven valid Tetriminos (I, O, T, S, Z, J, L)
+// using the Super Rotation System (SRS).
+// The Tetrminos are sorted by their value to ensure determinism in tests.
+func GetValidTetriminos() []Tetrimino {
+	return GetValidTetriminosFor(SRS)
 }
 
-// GetValidTetriminos returns a slice containing all seven valid Tetriminos (I, O, T, S, Z, J, L).
+// GetValidTetriminosFor returns a slice containing all seven valid Tetriminos (I, O, T, S, Z, J, L)
+// using the given RotationSystem.
 // The Tetrminos are sorted by their value to ensure determinism in tests.
-func GetValidTetriminos() []Tetrimino {
-	validTetriminos := getMapOfValidTetriminos()
-	result := make([]Tetrimino, 0, len(validTetriminos))
-	for _, t := range validTetriminos {
-		result = append(result, t)
+func GetValidTetriminosFor(rs RotationSystem) []Tetrimino {
+	result := make([]Tetrimino, 0, len(validTetriminoValues))
+	for _, value := range validTetriminoValues {
+		result = append(result, *newTetrimino(value, rs))
 	}
-	slices.SortFunc(result, func(a, b Tetrimino) int {
-		switch {
-		case a.Value < b.Value:
-			return -1
-		case a.Value > b.Value:
-			return 1
-		default:
-			return 0
-		}
-	})
 	return result
 }
 
-// GetTetrimino returns the Tetrmino with the given value.
+// GetTetrimino returns the Tetrmino with the given value using the Super Rotation System (SRS).
 // Valid values are: I, O, T, S, Z, J, L.
 func GetTetrimino(value byte) (*Tetrimino, error) {
-	result, ok := getMapOfValidTetriminos()[value]
-	if !ok {
+	return GetTetriminoFor(value, SRS)
+}
+
+// GetTetriminoFor returns the Tetrmino with the given value using the given RotationSystem.
+// Valid values are: I, O, T, S, Z, J, L.
+func GetTetriminoFor(value byte, rs RotationSystem) (*Tetrimino, error) {
+	if !slices.Contains(validTetriminoValues, value) {
 		return nil, errors.New("invalid value")
 	}
-	return result.DeepCopy(), nil
+	return newTetrimino(value, rs), nil
+}
+
+// validTetriminoValues contains the value of each of the seven valid Tetriminos, sorted.
+var validTetriminoValues = []byte{'I', 'J', 'L', 'O', 'S', 'T', 'Z'}
+
+func newTetrimino(value byte, rs RotationSystem) *Tetrimino {
+	clockwise, counterClockwise := rs.Compasses(value)
+	t := &Tetrimino{
+		Value:                  value,
+		Cells:                  rs.SpawnCells(value),
+		Position:               rs.SpawnPosition(value),
+		RotationCompass:        clockwise,
+		CounterRotationCompass: counterClockwise,
+	}
+	return t.DeepCopy()
 }
 
 // GetEmptyTetrimino returns a tetrimino to be used for the starting (empty) hold.
@@ -286,7 +241,7 @@ func (t *Tetrimino) MoveRight(matrix Matrix) bool {
 
 // Rotate rotates the Tetrimino clockwise or counter-clockwise.
 // This does not modify the matrix.
-// This will automatically use Super Rotation System (SRS).
+// This will automatically apply the kicks of the Tetrimino's rotation compasses.
 // If no valid rotation is found, the Tetrimino will not be modified and an error will be returned.
 func (t *Tetrimino) Rotate(matrix Matrix, clockwise bool) error {
 	if t.Value == 'O' {
@@ -294,7 +249,9 @@ func (t *Tetrimino) Rotate(matrix Matrix, clockwise bool) error {
 		return nil
 	}
 
-	rotated := t.DeepCopy()
+	// Rotation only modifies the cells and position, so the rotation compasses do not need to be copied.
+	rotated := *t
+	rotated.Cells = deepCopyCells(t.Cells)
 	var err error
 	var rotationPoint int
 	if clockwise {
@@ -364,11 +321,19 @@ func (t *Tetrimino) rotateCounterClockwise(matrix Matrix) (int, error) {
 
 	t.transpose()
 
+	offsets := t.CounterRotationCompass[t.CompassDirection]
+	if offsets == nil {
+		offsets = make(RotationOffsets, len(t.RotationCompass[t.CompassDirection]))
+		for i, coord := range t.RotationCompass[t.CompassDirection] {
+			offsets[i] = &Coordinate{X: -coord.X, Y: -coord.Y}
+		}
+	}
+
 	rotationPoint := invalidRotationPoint
 	originalX, originalY := t.Position.X, t.Position.Y
-	for i, coord := range t.RotationCompass[t.CompassDirection] {
-		t.Position.X = originalX - coord.X
-		t.Position.Y = originalY - coord.Y
+	for i, coord := range offsets {
+		t.Position.X = originalX + coord.X
+		t.Position.Y = originalY + coord.Y
 
 		if t.IsValid(matrix, true) {
 			rotationPoint = i + 1
@@ -439,29 +404,34 @@ func (t *Tetrimino) DeepCopy() *Tetrimino {
 		cells = deepCopyCells(t.Cells)
 	}
 
-	var compass RotationCompass
-	for i := range t.RotationCompass {
-		if t.RotationCompass[i] == nil {
-			compass[i] = nil
+	return &Tetrimino{
+		Value:                  t.Value,
+		Cells:                  cells,
+		Position:               t.Position,
+		CompassDirection:       t.CompassDirection,
+		RotationCompass:        deepCopyCompass(t.RotationCompass),
+		CounterRotationCompass: deepCopyCompass(t.CounterRotationCompass),
+	}
+}
+
+// deepCopyCompass creates a deep copy of the given RotationCompass.
+func deepCopyCompass(compass RotationCompass) RotationCompass {
+	var result RotationCompass
+	for i := range compass {
+		if compass[i] == nil {
+			result[i] = nil
 			continue
 		}
 
-		compass[i] = make(RotationOffsets, len(t.RotationCompass[i]))
-		for j := range t.RotationCompass[i] {
-			compass[i][j] = &Coordinate{
-				X: t.RotationCompass[i][j].X,
-				Y: t.RotationCompass[i][j].Y,
+		result[i] = make(RotationOffsets, len(compass[i]))
+		for j := range compass[i] {
+			result[i][j] = &Coordinate{
+				X: compass[i][j].X,
+				Y: compass[i][j].Y,
 			}
 		}
 	}
-
-	return &Tetrimino{
-		Value:            t.Value,
-		Cells:            cells,
-		Position:         t.Position,
-		CompassDirection: t.CompassDirection,
-		RotationCompass:  compass,
-	}
+	return result
 }
 
 // IsAboveSkyline returns true if the entire Tetrimino is above the skyline.
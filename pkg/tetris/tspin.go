@@ -0,0 +1,139 @@
+package tetris
+
+// TSpin is the kind of T-Spin performed by a T Tetrimino locking down.
+type TSpin int
+
+const (
+	// TSpinNone means no T-Spin was performed.
+	TSpinNone = TSpin(iota)
+	// TSpinMini is a T-Spin with only one of the corners in front of the T occupied.
+	TSpinMini
+	// TSpinFull is a T-Spin with both of the corners in front of the T occupied.
+	TSpinFull
+)
+
+// DetectTSpin returns the kind of T-Spin performed by the given Tetrimino locking down, using the three corner rule.
+// It is a T-Spin when the Tetrimino is a T and at least three of the four cells diagonal to its center are occupied
+// (walls and the floor count as occupied). It is a Mini T-Spin unless both corners on the pointing side are occupied.
+// This should only be called when the last successful movement of the Tetrimino was a rotation.
+func (m *Matrix) DetectTSpin(tet *Tetrimino) TSpin {
+	if tet.Value != 'T' {
+		return TSpinNone
+	}
+	center, facing, ok := tCenter(tet)
+	if !ok {
+		return TSpinNone
+	}
+
+	occupied := func(c Coordinate) bool {
+		return !m.canPlaceInCell(center.Y+c.Y, center.X+c.X)
+	}
+	// The corners on either side of the direction the T is pointing.
+	front := []Coordinate{
+		{X: facing.X + facing.Y, Y: facing.Y + facing.X},
+		{X: facing.X - facing.Y, Y: facing.Y - facing.X},
+	}
+	back := []Coordinate{
+		{X: -facing.X + facing.Y, Y: -facing.Y + facing.X},
+		{X: -facing.X - facing.Y, Y: -facing.Y - facing.X},
+	}
+
+	frontCount, backCount := 0, 0
+	for i := range front {
+		if occupied(front[i]) {
+			frontCount++
+		}
+		if occupied(back[i]) {
+			backCount++
+		}
+	}
+
+	switch {
+	case frontCount+backCount < 3:
+		return TSpinNone
+	case frontCount == 2:
+		return TSpinFull
+	default:
+		return TSpinMini
+	}
+}
+
+// tCenter returns the position of the center Mino of the T Tetrimino within the Matrix, and the unit direction
+// the T is pointing in (eg. {X: 0, Y: -1} when pointing up). The center is the Mino with three neighbours, and
+// it points away from the side without a neighbour. If there is no such Mino, false is returned.
+func tCenter(tet *Tetrimino) (Coordinate, Coordinate, bool) {
+	filled := func(row, col int) bool {
+		return row >= 0 && row < len(tet.Cells) && col >= 0 && col < len(tet.Cells[row]) && tet.Cells[row][col]
+	}
+	directions := []Coordinate{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}}
+
+	for row := range tet.Cells {
+		for col := range tet.Cells[row] {
+			if !tet.Cells[row][col] {
+				continue
+			}
+
+			var missing *Coordinate
+			neighbours := 0
+			for _, d := range directions {
+				if filled(row+d.Y, col+d.X) {
+					neighbours++
+				} else {
+					missing = &d
+				}
+			}
+			if neighbours == 3 {
+				center := Coordinate{X: tet.Position.X + col, Y: tet.Position.Y + row}
+				return center, Coordinate{X: -missing.X, Y: -missing.Y}, true
+			}
+		}
+	}
+	return Coordinate{}, Coordinate{}, false
+}
+
+// TSpinAction returns the Action for a T-Spin of the given kind which cleared the given number of lines.
+// If kind is TSpinNone, the Action for clearing the lines without a T-Spin is returned.
+func TSpinAction(kind TSpin, lines int) Action {
+	switch kind {
+	case TSpinNone:
+		return linesAction(lines)
+	case TSpinMini:
+		switch lines {
+		case 0:
+			return Actions.MiniTSpin
+		case 1:
+			return Actions.MiniTSpinSingle
+		}
+		// A Mini T-Spin which clears more lines is scored as a T-Spin.
+		return TSpinAction(TSpinFull, lines)
+	case TSpinFull:
+		switch lines {
+		case 0:
+			return Actions.TSpin
+		case 1:
+			return Actions.TSpinSingle
+		case 2:
+			return Actions.TSpinDouble
+		case 3:
+			return Actions.TSpinTriple
+		}
+	}
+	return Actions.Unknown
+}
+
+// linesAction returns the Action for clearing the given number of lines without a T-Spin.
+func linesAction(lines int) Action {
+	switch lines {
+	case 0:
+		return Actions.None
+	case 1:
+		return Actions.Single
+	case 2:
+		return Actions.Double
+	case 3:
+		return Actions.Triple
+	case 4:
+		return Actions.Tetris
+	}
+	return Actions.Unknown
+}
@@ -0,0 +1,99 @@
+package tetris
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrix_DetectTSpin(t *testing.T) {
+	pointingDown := [][]bool{
+		{true, true, true},
+		{false, true, false},
+	}
+	pointingUp := [][]bool{
+		{false, true, false},
+		{true, true, true},
+	}
+
+	tt := map[string]struct {
+		matrix Matrix
+		tet    *Tetrimino
+		want   TSpin
+	}{
+		"full": {
+			matrix: Matrix{
+				{'X', 0, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+				{'X', 0, 'X', 'X', 'X'},
+			},
+			tet:  &Tetrimino{Value: 'T', Cells: pointingDown, Position: Coordinate{X: 0, Y: 1}},
+			want: TSpinFull,
+		},
+		"walls count as occupied": {
+			matrix: Matrix{
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+				{0, 'X', 0, 0, 0},
+			},
+			tet:  &Tetrimino{Value: 'T', Cells: pointingDown, Position: Coordinate{X: -1, Y: 1}},
+			want: TSpinFull,
+		},
+		"mini": {
+			matrix: Matrix{
+				{0, 0, 0, 0, 0},
+				{'X', 0, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+			},
+			tet:  &Tetrimino{Value: 'T', Cells: pointingUp, Position: Coordinate{X: 0, Y: 1}},
+			want: TSpinMini,
+		},
+		"two corners": {
+			matrix: Matrix{
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+				{'X', 0, 'X', 0, 0},
+			},
+			tet:  &Tetrimino{Value: 'T', Cells: pointingDown, Position: Coordinate{X: 0, Y: 0}},
+			want: TSpinNone,
+		},
+		"not a T": {
+			matrix: Matrix{
+				{'X', 0, 'X', 0, 0},
+				{0, 0, 0, 0, 0},
+				{'X', 0, 'X', 'X', 'X'},
+			},
+			tet:  &Tetrimino{Value: 'S', Cells: pointingDown, Position: Coordinate{X: 0, Y: 1}},
+			want: TSpinNone,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.matrix.DetectTSpin(tc.tet))
+		})
+	}
+}
+
+func TestTSpinAction(t *testing.T) {
+	tt := map[string]struct {
+		kind  TSpin
+		lines int
+		want  Action
+	}{
+		"none":                {kind: TSpinNone, lines: 2, want: Actions.Double},
+		"mini":                {kind: TSpinMini, lines: 0, want: Actions.MiniTSpin},
+		"mini single":         {kind: TSpinMini, lines: 1, want: Actions.MiniTSpinSingle},
+		"mini double":         {kind: TSpinMini, lines: 2, want: Actions.TSpinDouble},
+		"full":                {kind: TSpinFull, lines: 0, want: Actions.TSpin},
+		"full triple":         {kind: TSpinFull, lines: 3, want: Actions.TSpinTriple},
+		"too many lines":      {kind: TSpinFull, lines: 4, want: Actions.Unknown},
+		"tetris without spin": {kind: TSpinNone, lines: 4, want: Actions.Tetris},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, TSpinAction(tc.kind, tc.lines))
+		})
+	}
+}